@@ -0,0 +1,61 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-zookeeper/zk"
+)
+
+// ErrorACLWorldOpen is returned by operations creating or updating ZNodes with a world-open ACL,
+// when the Client is configured to refuse them (see WithWorldOpenACLsDenied).
+var ErrorACLWorldOpen = errors.New("world-open ACL")
+
+// worldOpenPerms are the permissions that allow modifying a ZNode, its children or its ACL.
+const worldOpenPerms = zk.PermWrite | zk.PermCreate | zk.PermDelete | zk.PermAdmin
+
+// WithWorldOpenACLsDenied makes the Client refuse to create or update ZNodes with an ACL granting
+// anyone the permission to modify them (see WorldOpenACLEntries): those operations fail with ErrorACLWorldOpen,
+// without reaching ZooKeeper.
+func WithWorldOpenACLsDenied() ClientOption {
+	return func(c *Client) error {
+		c.denyWorldOpenACLs = true
+		return nil
+	}
+}
+
+// WorldOpenACLEntries returns the entries of the given ACL granting `world:anyone` any permission
+// other than READ (i.e. WRITE, CREATE, DELETE or ADMIN).
+func WorldOpenACLEntries(acl []zk.ACL) []zk.ACL {
+	var entries []zk.ACL
+	for _, entry := range acl {
+		if entry.Scheme == "world" && entry.ID == "anyone" && entry.Perms&worldOpenPerms != 0 {
+			entries = append(entries, entry)
+		}
+	}
+
+	return entries
+}
+
+// CheckACL returns an error wrapping ErrorACLWorldOpen, if the Client is configured to refuse
+// world-open ACLs (see WithWorldOpenACLsDenied) and the given one is.
+//
+// Every operation creating or updating a ZNode already does this check: this is to fail early (ex. when planning).
+func (c *Client) CheckACL(acl []zk.ACL) error {
+	if !c.denyWorldOpenACLs {
+		return nil
+	}
+
+	entries := WorldOpenACLEntries(acl)
+	if len(entries) == 0 {
+		return nil
+	}
+
+	grants := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		grants = append(grants, fmt.Sprintf("world:anyone (permissions: %d)", entry.Perms))
+	}
+
+	return fmt.Errorf("ACL grants %s the permission to modify the ZNode: %w", strings.Join(grants, ", "), ErrorACLWorldOpen)
+}
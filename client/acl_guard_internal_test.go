@@ -0,0 +1,37 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/go-zookeeper/zk"
+	testifyAssert "github.com/stretchr/testify/assert"
+)
+
+func TestWorldOpenACLEntries(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	assert.Empty(WorldOpenACLEntries(zk.WorldACL(zk.PermRead)))
+	assert.Empty(WorldOpenACLEntries(zk.DigestACL(zk.PermAll, "user", "pass")))
+	assert.Equal(zk.WorldACL(zk.PermAll), WorldOpenACLEntries(zk.WorldACL(zk.PermAll)))
+
+	acl := append(zk.AuthACL(zk.PermAll), zk.WorldACL(zk.PermRead|zk.PermCreate)...)
+	assert.Equal(zk.WorldACL(zk.PermRead|zk.PermCreate), WorldOpenACLEntries(acl))
+}
+
+func TestCheckACL(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	c := &Client{}
+	assert.NoError(c.CheckACL(zk.WorldACL(zk.PermAll)))
+
+	assert.NoError(WithWorldOpenACLsDenied()(c))
+	assert.NoError(c.CheckACL(zk.WorldACL(zk.PermRead)))
+	assert.ErrorIs(c.CheckACL(zk.WorldACL(zk.PermAll)), ErrorACLWorldOpen)
+
+	_, err := c.Create("/a", nil, zk.WorldACL(zk.PermWrite))
+	assert.ErrorIs(err, ErrorACLWorldOpen)
+	_, err = c.CreateSequential("/a/", nil, zk.WorldACL(zk.PermAdmin))
+	assert.ErrorIs(err, ErrorACLWorldOpen)
+	_, err = c.Update("/a", nil, zk.WorldACL(zk.PermAll))
+	assert.ErrorIs(err, ErrorACLWorldOpen)
+}
@@ -0,0 +1,92 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultAdminServerPort is the port the ZooKeeper AdminServer listens on by default (`admin.serverPort`).
+	// See: https://zookeeper.apache.org/doc/current/zookeeperAdmin.html#sc_adminserver.
+	DefaultAdminServerPort = "8080"
+
+	adminServerCommandsPath = "/commands/"
+	adminServerErrorKey     = "error"
+)
+
+// AdminCommandResult is the response of a ZooKeeper AdminServer command.
+type AdminCommandResult struct {
+	// URL the command was requested from.
+	URL string
+	// Raw JSON response.
+	Raw []byte
+	// Fields of the JSON response.
+	Fields map[string]interface{}
+}
+
+// DefaultAdminServerURL returns the URL of the AdminServer running on the first
+// of the Server(s) the Client was configured with, assuming the DefaultAdminServerPort.
+func (c *Client) DefaultAdminServerURL() (string, error) {
+	if len(c.servers) == 0 {
+		return "", fmt.Errorf("no ZooKeeper server configured")
+	}
+
+	host, _, err := net.SplitHostPort(c.servers[0])
+	if err != nil {
+		return "", fmt.Errorf("failed to parse ZooKeeper server '%s': %w", c.servers[0], err)
+	}
+
+	return "http://" + net.JoinHostPort(host, DefaultAdminServerPort), nil
+}
+
+// RunAdminCommand runs a command (ex. `monitor`, `connections`, `watch_summary`) against
+// the ZooKeeper AdminServer at the given base URL, by requesting `<baseURL>/commands/<command>`.
+//
+// Fails if the AdminServer reports an `error` in the response.
+func RunAdminCommand(ctx context.Context, baseURL string, command string, timeout time.Duration) (*AdminCommandResult, error) {
+	commandURL, err := url.JoinPath(strings.TrimSuffix(baseURL, "/"), adminServerCommandsPath, command)
+	if err != nil {
+		return nil, fmt.Errorf("invalid AdminServer URL '%s': %w", baseURL, err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, commandURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build AdminServer request '%s': %w", commandURL, err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request '%s': %w", commandURL, err)
+	}
+	defer resp.Body.Close()
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response from '%s': %w", commandURL, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status '%s' from '%s': %s", resp.Status, commandURL, raw)
+	}
+
+	result := &AdminCommandResult{URL: commandURL, Raw: raw}
+	if err := json.Unmarshal(raw, &result.Fields); err != nil {
+		return nil, fmt.Errorf("failed to parse response from '%s': %w", commandURL, err)
+	}
+
+	if cmdErr, ok := result.Fields[adminServerErrorKey]; ok && cmdErr != nil {
+		return nil, fmt.Errorf("command '%s' failed on '%s': %v", command, commandURL, cmdErr)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,40 @@
+package client_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	testifyAssert "github.com/stretchr/testify/assert"
+	"github.com/tfzk/terraform-provider-zookeeper/client"
+)
+
+func TestRunAdminCommand(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	adminServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/commands/watch_summary":
+			_, _ = w.Write([]byte(`{"command":"watch_summary","num_connections":2,"num_paths":5,"error":null}`))
+		case "/commands/failing":
+			_, _ = w.Write([]byte(`{"command":"failing","error":"something went wrong"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer adminServer.Close()
+
+	result, err := client.RunAdminCommand(context.Background(), adminServer.URL, "watch_summary", time.Second)
+	assert.NoError(err)
+	assert.Equal(adminServer.URL+"/commands/watch_summary", result.URL)
+	assert.Equal("watch_summary", result.Fields["command"])
+	assert.InEpsilon(float64(5), result.Fields["num_paths"], 0.001)
+
+	_, err = client.RunAdminCommand(context.Background(), adminServer.URL+"/", "failing", time.Second)
+	assert.ErrorContains(err, "something went wrong")
+
+	_, err = client.RunAdminCommand(context.Background(), adminServer.URL, "unknown", time.Second)
+	assert.ErrorContains(err, "unexpected status '404 Not Found'")
+}
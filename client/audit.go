@@ -0,0 +1,169 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-zookeeper/zk"
+)
+
+// AuditOperation is a kind of mutating operation recorded in the audit log.
+type AuditOperation string
+
+const (
+	AuditOperationCreate   AuditOperation = "create"
+	AuditOperationSetData  AuditOperation = "set_data"
+	AuditOperationSetACL   AuditOperation = "set_acl"
+	AuditOperationDelete   AuditOperation = "delete"
+	AuditOperationReconfig AuditOperation = "reconfig"
+
+	// auditEntryPrefix is the name prefix of the sequential ZNodes, recording an AuditEntry each,
+	// created under the audit ZNode (see WithAuditZNode).
+	auditEntryPrefix = "entry-"
+)
+
+// AuditEntry is a mutating operation performed by a Client on a ZNode, as recorded in the audit log.
+type AuditEntry struct {
+	Time      time.Time      `json:"time"`
+	Operation AuditOperation `json:"operation"`
+	Path      string         `json:"path"`
+	// Identity is the identity the Client is authenticated with (ex. `digest:alice`), or `world:anyone`.
+	Identity string `json:"identity"`
+	// Error is set if the operation failed.
+	Error string `json:"error,omitempty"`
+}
+
+// WithAuditLogFile appends an AuditEntry, as a line of JSON, to the file at the given path
+// for every mutating operation performed by the Client. The file is created if missing.
+func WithAuditLogFile(path string) ClientOption {
+	return func(c *Client) error {
+		file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+		if err != nil {
+			return fmt.Errorf("failed to open audit log file '%s': %w", path, err)
+		}
+
+		c.auditLog.file = file
+		return nil
+	}
+}
+
+// WithAuditZNode records an AuditEntry, as the JSON data of a persistent sequential child
+// of the ZNode at the given path, for every mutating operation performed by the Client.
+//
+// The entries are created with the same ACL of the ZNode at the given path, that must exist.
+func WithAuditZNode(path string) ClientOption {
+	return func(c *Client) error {
+		if !strings.HasPrefix(path, zNodeRootPath) || (path != zNodeRootPath && strings.HasSuffix(path, zNodeRootPath)) {
+			return fmt.Errorf("audit ZNode path '%s' must be absolute, and must not end in '%c'", path, zNodePathSeparator)
+		}
+
+		c.auditLog.znodePath = path
+		return nil
+	}
+}
+
+// auditLog records the mutating operations performed by a Client, if configured
+// via WithAuditLogFile and/or WithAuditZNode. A `nil` auditLog records nothing.
+type auditLog struct {
+	mu        sync.Mutex
+	identity  string
+	file      *os.File
+	znodePath string
+}
+
+// enabled returns true if the audit log records anything.
+func (al *auditLog) enabled() bool {
+	return al != nil && (al.file != nil || al.znodePath != "")
+}
+
+// audited performs the given mutating operation on the ZNode at the given path, recording it in the audit log first.
+//
+// If recording fails, the operation is not performed: operations that can't be audited must not happen.
+// If the operation fails, that is recorded too, in another AuditEntry with the Error.
+// Before anything else, the Ensemble is checked to have a leader, and the write lock is acquired,
+// if configured (see WithLeaderCheck and WithWriteLock).
+func (c *Client) audited(operation AuditOperation, path string, perform func() error) error {
+	if err := c.checkLeader(); err != nil {
+		return err
+	}
+	if err := c.acquireWriteLock(); err != nil {
+		return err
+	}
+
+	if !c.auditLog.enabled() {
+		return perform()
+	}
+
+	if err := c.recordAuditEntry(operation, path, nil); err != nil {
+		return err
+	}
+
+	opErr := perform()
+	if opErr == nil {
+		return opErr
+	}
+
+	if err := c.recordAuditEntry(operation, path, opErr); err != nil {
+		return errors.Join(opErr, err)
+	}
+
+	return opErr
+}
+
+// recordAuditEntry records an AuditEntry for the given operation on the ZNode at the given path,
+// that failed if `opErr` is not `nil`.
+func (c *Client) recordAuditEntry(operation AuditOperation, path string, opErr error) error {
+	entry := AuditEntry{
+		Time:      time.Now().UTC(),
+		Operation: operation,
+		Path:      path,
+		Identity:  c.auditLog.identity,
+	}
+	if opErr != nil {
+		entry.Error = opErr.Error()
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit log entry: %w", err)
+	}
+
+	if err := c.writeAuditEntry(line); err != nil {
+		return fmt.Errorf("failed to record %s of ZNode '%s' in the audit log: %w", operation, path, err)
+	}
+
+	return nil
+}
+
+// writeAuditEntry writes the given, JSON encoded, AuditEntry to the configured audit log(s).
+func (c *Client) writeAuditEntry(entry []byte) error {
+	al := c.auditLog
+
+	if al.file != nil {
+		al.mu.Lock()
+		_, err := al.file.Write(append(entry, '\n'))
+		al.mu.Unlock()
+		if err != nil {
+			return fmt.Errorf("failed to write audit log file '%s': %w", al.file.Name(), err)
+		}
+	}
+
+	if al.znodePath != "" {
+		// Not recorded in the audit log itself
+		acl, _, err := c.zkConn.GetACL(al.znodePath)
+		if err != nil {
+			return fmt.Errorf("failed to fetch ACLs for audit ZNode '%s': %w", al.znodePath, err)
+		}
+
+		if _, err := c.zkConn.Create(JoinPath(al.znodePath, auditEntryPrefix), entry, zk.FlagSequence, acl); err != nil {
+			return fmt.Errorf("failed to create audit entry under ZNode '%s': %w", al.znodePath, err)
+		}
+	}
+
+	return nil
+}
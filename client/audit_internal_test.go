@@ -0,0 +1,102 @@
+package client
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	testifyAssert "github.com/stretchr/testify/assert"
+)
+
+func readAuditLogFile(t *testing.T, path string) []AuditEntry {
+	file, err := os.Open(path)
+	testifyAssert.NoError(t, err)
+	defer file.Close()
+
+	entries := []AuditEntry{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var entry AuditEntry
+		testifyAssert.NoError(t, json.Unmarshal(scanner.Bytes(), &entry))
+		entries = append(entries, entry)
+	}
+
+	return entries
+}
+
+func TestAuditLogFile(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	logPath := filepath.Join(t.TempDir(), "audit.jsonl")
+	c := &Client{auditLog: &auditLog{identity: "digest:alice"}}
+	assert.NoError(WithAuditLogFile(logPath)(c))
+
+	performed := false
+	err := c.audited(AuditOperationCreate, "/a", func() error {
+		performed = true
+		return nil
+	})
+	assert.NoError(err)
+	assert.True(performed)
+
+	opErr := errors.New("zk: node does not exist")
+	err = c.audited(AuditOperationDelete, "/b", func() error {
+		return opErr
+	})
+	assert.ErrorIs(err, opErr)
+
+	entries := readAuditLogFile(t, logPath)
+	assert.Len(entries, 3)
+	assert.Equal(AuditOperationCreate, entries[0].Operation)
+	assert.Equal("/a", entries[0].Path)
+	assert.Equal("digest:alice", entries[0].Identity)
+	assert.Empty(entries[0].Error)
+	assert.False(entries[0].Time.IsZero())
+	assert.Equal(AuditOperationDelete, entries[1].Operation)
+	assert.Empty(entries[1].Error)
+	assert.Equal(AuditOperationDelete, entries[2].Operation)
+	assert.Equal("zk: node does not exist", entries[2].Error)
+}
+
+func TestAuditLogFailureSkipsOperation(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	c := &Client{auditLog: &auditLog{identity: "world:anyone"}}
+	assert.NoError(WithAuditLogFile(filepath.Join(t.TempDir(), "audit.jsonl"))(c))
+	assert.NoError(c.auditLog.file.Close())
+
+	performed := false
+	err := c.audited(AuditOperationSetData, "/a", func() error {
+		performed = true
+		return nil
+	})
+	assert.ErrorContains(err, "failed to record set_data of ZNode '/a' in the audit log")
+	assert.False(performed)
+}
+
+func TestAuditLogDisabled(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	for _, c := range []*Client{{}, {auditLog: &auditLog{}}} {
+		performed := false
+		err := c.audited(AuditOperationSetACL, "/a", func() error {
+			performed = true
+			return nil
+		})
+		assert.NoError(err)
+		assert.True(performed)
+	}
+}
+
+func TestWithAuditZNodeValidatesPath(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	c := &Client{auditLog: &auditLog{}}
+	assert.Error(WithAuditZNode("relative")(c))
+	assert.Error(WithAuditZNode("/trailing/")(c))
+	assert.NoError(WithAuditZNode("/audit")(c))
+	assert.Equal("/audit", c.auditLog.znodePath)
+}
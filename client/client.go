@@ -0,0 +1,1083 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	pathpkg "path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+	"unicode/utf8"
+
+	"github.com/go-zookeeper/zk"
+)
+
+// Client wraps a go-zookeeper `zk.Conn` object.
+//
+// It's designed to offer the functionalities that we will expose via the
+// actual Terraform Provider.
+//
+// A Client is safe for concurrent use, as Terraform walks the graph of resources
+// in parallel: its fields are never modified after NewClient, and the underlying
+// `zk.Conn` pipelines concurrent requests over the same session (re-authenticating
+// it on reconnection). Operations made of multiple requests (ex. Create, Delete)
+// tolerate concurrent changes to the ZNodes they touch, where possible.
+type Client struct {
+	zkConn    *zk.Conn
+	servers   []string
+	reads     *readCache
+	telemetry *telemetryRecorder
+	auditLog  *auditLog
+	paths     pathGuard
+
+	redactData        bool
+	denyWorldOpenACLs bool
+	skipACLReads      bool
+	checkServersDNS   bool
+	tolerateNoAuth    bool
+
+	// tlsFiles is `nil` if connections are in plaintext (see WithTLS)
+	tlsFiles   *tlsFiles
+	requireTLS bool
+
+	// writeLock is `nil` if writes don't require a lock (see WithWriteLock)
+	writeLock *writeLock
+
+	// leaderCheck is `nil` if writes don't require a leader to be checked first (see WithLeaderCheck)
+	leaderCheck *leaderCheck
+
+	// serverPreference is `nil` if servers are connected to in random order (see WithServerPreference)
+	serverPreference *serverPreference
+
+	// defaultPort of the servers listed without one: DefaultClientPort, or DefaultSecureClientPort, if zero (see WithDefaultPort)
+	defaultPort int
+
+	// username for digest authentication, with the password read from passwordFile if not `nil` (see WithPasswordFile)
+	username     string
+	passwordFile *passwordFile
+
+	// sessionTimeoutMs is the session timeout negotiated with the Ensemble, once the session is established (see Session)
+	sessionTimeoutMs atomic.Int32
+}
+
+// ZNode represents, obviously, a ZooKeeper Node.
+//
+// While `Path` and `Data` fields are pretty self-explanatory,
+// the `Stat` contains multiple ZooKeeper related metadata.
+// See `zk.Stat` for details.
+//
+// `ACL` is `nil` if it was not read: every ZNode has at least one ACL entry.
+type ZNode struct {
+	Path string
+	Stat *zk.Stat
+	Data []byte
+	ACL  []zk.ACL
+}
+
+// Re-exporting errors from ZK library for better encapsulation.
+var (
+	ErrorZNodeAlreadyExists = zk.ErrNodeExists
+	ErrorZNodeDoesNotExist  = zk.ErrNoNode
+	ErrorZNodeHasChildren   = zk.ErrNotEmpty
+	ErrorConnectionClosed   = zk.ErrConnectionClosed
+	ErrorInvalidArguments   = zk.ErrBadArguments
+	ErrorNotAuthorized      = zk.ErrNoAuth
+	ErrorAuthFailed         = zk.ErrAuthFailed
+	ErrorInvalidACL         = zk.ErrInvalidACL
+	ErrorBadVersion         = zk.ErrBadVersion
+	ErrorReconfigDisabled   = zk.ErrReconfigDisabled
+
+	// ErrorStopWalk can be returned by a WalkFunc to stop Walk early, without Walk reporting an error.
+	ErrorStopWalk = errors.New("stop walk")
+)
+
+const (
+	serversStringSeparator = ","
+	zNodeRootPath          = "/"
+	zNodePathSeparator     = '/'
+
+	// maxConcurrentRequests is the maximum number of requests that methods like ReadMany
+	// issue concurrently, pipelined over the same ZooKeeper session.
+	maxConcurrentRequests = 16
+
+	// matchAnyVersion is used when submitting an update/delete request.
+	// Providing `version = -1` means that the operation will match any
+	// version of the ZNode found.
+	matchAnyVersion = -1
+
+	// sequentialSuffixLen is the length of the unique, monotonically increasing, suffix
+	// that ZooKeeper appends to the path of sequential ZNodes.
+	sequentialSuffixLen = 10
+
+	// maxCreateAttempts is how many times creating a ZNode is attempted, if a parent is deleted concurrently
+	// (i.e. between creating the parents and the ZNode itself).
+	maxCreateAttempts = 3
+
+	// EnvZooKeeperServer environment variable containing a comma separated
+	// list of 'host:port' pairs, pointing at ZooKeeper Server(s).
+	// This is used by NewClientFromEnv.
+	EnvZooKeeperServer = "ZOOKEEPER_SERVERS"
+
+	// EnvZooKeeperSessionSec environment variable defining how many seconds
+	// a session is considered valid after losing connectivity.
+	// This is used by NewClientFromEnv.
+	EnvZooKeeperSessionSec = "ZOOKEEPER_SESSION"
+
+	// DefaultZooKeeperSessionSec is the default amount of seconds configured for the
+	// Client timeout session, in case EnvZooKeeperSessionSec is not set.
+	DefaultZooKeeperSessionSec = 30
+
+	// Environment variables to provide digest auth credentials.
+	EnvZooKeeperUsername = "ZOOKEEPER_USERNAME"
+	EnvZooKeeperPassword = "ZOOKEEPER_PASSWORD"
+	// EnvZooKeeperPasswordFile environment variable pointing at a file containing
+	// the digest auth password, as alternative to EnvZooKeeperPassword.
+	EnvZooKeeperPasswordFile = "ZOOKEEPER_PASSWORD_FILE"
+)
+
+// ClientOption configures optional behaviours of a Client, see NewClient.
+type ClientOption func(c *Client) error
+
+// WithDataRedaction marks the Client as configured to keep the data of ZNodes out of any diagnostic
+// (see RedactsData). The Client itself never includes the data of ZNodes in its errors.
+func WithDataRedaction() ClientOption {
+	return func(c *Client) error {
+		c.redactData = true
+		return nil
+	}
+}
+
+// WithoutACLReads makes the Client never read the ACL of ZNodes (ex. when its identity lacks the permission to):
+// the ZNode(s) returned by its methods have no ACL populated, unless just written by the Client.
+func WithoutACLReads() ClientOption {
+	return func(c *Client) error {
+		c.skipACLReads = true
+		return nil
+	}
+}
+
+// WithUnreadableZNodesTolerated marks the Client as configured to tolerate ZNodes it's not authorized to read,
+// when refreshing what was read before (see ToleratesUnreadableZNodes). The Client itself still fails reading them.
+func WithUnreadableZNodesTolerated() ClientOption {
+	return func(c *Client) error {
+		c.tolerateNoAuth = true
+		return nil
+	}
+}
+
+// NewClient constructs a new Client instance.
+//
+// Optional behaviours, like the audit log (see WithAuditLogFile), can be enabled via ClientOption(s).
+func NewClient(servers string, sessionTimeoutSec int, username string, password string, opts ...ClientOption) (*Client, error) {
+	identity := "world:anyone"
+	if username != "" {
+		identity = "digest:" + username
+	}
+
+	c := &Client{
+		reads:     newReadCache(),
+		telemetry: newTelemetryRecorder(),
+		auditLog:  &auditLog{identity: identity},
+		username:  username,
+	}
+	for _, opt := range opts {
+		if err := opt(c); err != nil {
+			return nil, err
+		}
+	}
+	if c.passwordFile != nil {
+		if password != "" {
+			return nil, fmt.Errorf("password and password file cannot be specified together")
+		}
+		var err error
+		if password, err = c.passwordFile.password(); err != nil {
+			return nil, err
+		}
+	}
+	if (username == "") != (password == "") {
+		return nil, fmt.Errorf("both username and password must be specified together")
+	}
+	if c.requireTLS && c.tlsFiles == nil {
+		return nil, fmt.Errorf("TLS is required, but not configured: %w", ErrorTLSRequired)
+	}
+
+	defaultPort := c.defaultPort
+	if defaultPort == 0 {
+		defaultPort = DefaultClientPort
+		if c.tlsFiles != nil {
+			defaultPort = DefaultSecureClientPort
+		}
+	}
+	var err error
+	if c.servers, err = normalizeServers(servers, defaultPort); err != nil {
+		return nil, err
+	}
+	if c.checkServersDNS {
+		if err := checkServersResolve(c.servers, net.DefaultResolver.LookupHost); err != nil {
+			return nil, err
+		}
+	}
+
+	connectServers, hostProvider := c.servers, zk.HostProvider(zk.NewDNSHostProvider())
+	if c.serverPreference != nil {
+		if connectServers, err = c.serverPreference.order(c.servers, defaultPort, c.isObserver); err != nil {
+			return nil, err
+		}
+		hostProvider = &orderedHostProvider{}
+	}
+
+	conn, _, err := zk.Connect(connectServers, time.Duration(sessionTimeoutSec)*time.Second,
+		zk.WithDialer(c.dial), zk.WithEventCallback(c.onEvent), zk.WithHostProvider(hostProvider),
+		zk.WithLogger(sessionLogger{sessionTimeoutMs: &c.sessionTimeoutMs}))
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect to ZooKeeper: %w", err)
+	}
+
+	if username != "" {
+		auth := "digest"
+		credentials := fmt.Sprintf("%s:%s", username, password)
+		err = conn.AddAuth(auth, []byte(credentials))
+		if err != nil {
+			// Don't leak the session, as the Client is not returned
+			conn.Close()
+			return nil, fmt.Errorf("unable to add digest auth: %w", err)
+		}
+	}
+	if c.passwordFile != nil {
+		c.passwordFile.attach(conn)
+	}
+
+	c.zkConn = conn
+	return c, nil
+}
+
+// onEvent is the zk.EventCallback of the Client.
+func (c *Client) onEvent(event zk.Event) {
+	c.reauthenticate(event)
+	c.expireWriteLock(event)
+}
+
+// NewClientFromEnv constructs a new Client instance from environment variables.
+//
+// The only mandatory environment variable is EnvZooKeeperServer.
+func NewClientFromEnv() (*Client, error) {
+	zkServers, ok := os.LookupEnv(EnvZooKeeperServer)
+	if !ok {
+		return nil, fmt.Errorf("missing environment variable: %s", EnvZooKeeperServer)
+	}
+
+	zkSession, ok := os.LookupEnv(EnvZooKeeperSessionSec)
+	if !ok {
+		zkSession = strconv.FormatInt(DefaultZooKeeperSessionSec, 10)
+	}
+
+	zkSessionInt, err := strconv.Atoi(zkSession)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert '%s' to integer: %w", zkSession, err)
+	}
+
+	zkUsername, _ := os.LookupEnv(EnvZooKeeperUsername)
+	zkPassword, _ := os.LookupEnv(EnvZooKeeperPassword)
+
+	var opts []ClientOption
+	if zkPasswordFile, ok := os.LookupEnv(EnvZooKeeperPasswordFile); ok {
+		opts = append(opts, WithPasswordFile(zkPasswordFile))
+	}
+
+	return NewClient(zkServers, zkSessionInt, zkUsername, zkPassword, opts...)
+}
+
+// RedactsData returns true if the data of ZNodes must be kept out of diagnostics (see WithDataRedaction).
+func (c *Client) RedactsData() bool {
+	return c != nil && c.redactData
+}
+
+// SkipsACLReads returns true if the ACL of ZNodes is never read (see WithoutACLReads).
+func (c *Client) SkipsACLReads() bool {
+	return c != nil && c.skipACLReads
+}
+
+// ToleratesUnreadableZNodes returns true if ZNodes not authorized to be read must be kept as previously read,
+// instead of failing (see WithUnreadableZNodesTolerated).
+func (c *Client) ToleratesUnreadableZNodes() bool {
+	return c != nil && c.tolerateNoAuth
+}
+
+// Close ends the session of the Client, releasing what is bound to it (ex. the lock of WithWriteLock).
+//
+// The Client must not be used afterwards.
+func (c *Client) Close() {
+	c.zkConn.Close()
+}
+
+// Servers returns the list of 'host:port' ZooKeeper Server(s) the Client was configured with.
+func (c *Client) Servers() []string {
+	return append([]string{}, c.servers...)
+}
+
+// Create a ZNode at the given path.
+//
+// Note that any necessary ZNode parents will be created if absent.
+func (c *Client) Create(path string, data []byte, acl []zk.ACL) (*ZNode, error) {
+	defer c.telemetry.record("Create", path, time.Now())
+
+	if err := c.paths.checkWrite(path, false); err != nil {
+		return nil, err
+	}
+	if err := c.CheckACL(acl); err != nil {
+		return nil, err
+	}
+
+	if path[len(path)-1] == zNodePathSeparator {
+		return nil, fmt.Errorf("non-sequential ZNode cannot have path '%s' because it ends in '%c'", path, zNodePathSeparator)
+	}
+
+	return c.doCreate(path, data, 0, acl)
+}
+
+// CreateSequential will create a ZNode at the given path, using the Sequential Node flag.
+//
+// See: https://zookeeper.apache.org/doc/r3.6.3/zookeeperProgrammers.html#Sequence+Nodes+--+Unique+Naming
+//
+// This will ensure unique naming within the same parent ZNode,
+// by appending a monotonically increasing counter in the format `%010d`
+// (that is 10 digits with 0 (zero) padding).
+// Note that if the `path` ends in `/`, the ZNode name will be just the counter
+// described above. For example:
+//
+//   - input path         -> `/this/is/a/path/`
+//   - created znode path -> `/this/is/a/path/0000000001`
+//
+// Note also that any necessary ZNode parents will be created if absent.
+func (c *Client) CreateSequential(path string, data []byte, acl []zk.ACL) (*ZNode, error) {
+	defer c.telemetry.record("CreateSequential", path, time.Now())
+
+	if err := c.paths.checkWrite(path, false); err != nil {
+		return nil, err
+	}
+	if err := c.CheckACL(acl); err != nil {
+		return nil, err
+	}
+
+	return c.doCreate(path, data, zk.FlagSequence, acl)
+}
+
+func (c *Client) doCreate(path string, data []byte, createFlags int32, acl []zk.ACL) (*ZNode, error) {
+	parentZNodes := listParentsInOrder(path)
+
+	// NOTE: Based on the `createFlags`, the path returned by `Create` can change (ex. sequential nodes)
+	var createdPath string
+	var err error
+	for attempt := 1; attempt <= maxCreateAttempts; attempt++ {
+		// Create any necessary parent for the ZNode we need to crete
+		err = c.createEmptyZNodes(parentZNodes, 0, acl)
+		if err == nil {
+			err = c.audited(AuditOperationCreate, path, func() (createErr error) {
+				createdPath, createErr = c.zkConn.Create(path, data, createFlags, acl)
+				return createErr
+			})
+		}
+
+		// A parent was deleted concurrently (ex. by another process cleaning up): create it again
+		if !errors.Is(err, ErrorZNodeDoesNotExist) {
+			break
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create ZNode '%s' (size: %d, createFlags: %d, acl: %v): %w", path, len(data), createFlags, acl, err)
+	}
+
+	// Only the zk.Stat is unknown: ZooKeeper `create` doesn't return it
+	exists, stat, err := c.zkConn.Exists(createdPath)
+	if err != nil || !exists || stat.Mzxid != stat.Czxid || stat.Aversion != 0 {
+		// Modified (or deleted) concurrently, since created
+		c.reads.invalidate(createdPath, false)
+		return c.Read(createdPath)
+	}
+
+	return c.written(createdPath, data, acl, stat)
+}
+
+// written returns the ZNode just written with the given data and ACL, without reading it again,
+// and remembers it for later reads (see readCache).
+//
+// The ACL is read again only if it uses the `auth` scheme, that the server expands into
+// the identities the Client is authenticated with (unless configured via WithoutACLReads).
+// If the given ACL is `nil` (i.e. not written), the ZNode is remembered only if ACLs are never read.
+func (c *Client) written(path string, data []byte, acl []zk.ACL, stat *zk.Stat) (*ZNode, error) {
+	for _, entry := range acl {
+		if entry.Scheme == "auth" && !c.skipACLReads {
+			var err error
+			acl, _, err = c.zkConn.GetACL(path)
+			if err != nil {
+				c.reads.invalidate(path, false)
+				return nil, fmt.Errorf("failed to fetch ACLs for ZNode '%s': %w", path, err)
+			}
+			break
+		}
+	}
+
+	// Like ZooKeeper `get` does for empty data
+	if len(data) == 0 {
+		data = nil
+	}
+
+	znode := &ZNode{
+		Path: path,
+		Stat: stat,
+		Data: data,
+		ACL:  acl,
+	}
+	if acl != nil || c.skipACLReads {
+		c.reads.store(znode)
+	} else {
+		c.reads.invalidate(path, false)
+	}
+
+	return znode, nil
+}
+
+func listParentsInOrder(path string) []string {
+	// Split the path one parent directory at a time
+	parentPaths := []string{filepath.Dir(path)}
+	for parentPaths[len(parentPaths)-1] != zNodeRootPath {
+		parentPaths = append(parentPaths, filepath.Dir(parentPaths[len(parentPaths)-1]))
+	}
+
+	// Sort by increasing length (i.e. each parent before each child)
+	sort.Strings(parentPaths)
+
+	// Return all the parents, excluding `root`
+	return parentPaths[1:]
+}
+
+func (c *Client) createEmptyZNodes(pathsInOrder []string, createFlags int32, acl []zk.ACL) error {
+	for _, path := range pathsInOrder {
+		// Parents outside of the allowed path prefixes, or protected, are left alone: they must exist already
+		if c.paths.checkWrite(path, false) != nil {
+			continue
+		}
+
+		exists, err := c.Exists(path)
+		if err != nil {
+			return err
+		}
+
+		// Will only create the znode if they don't already exist.
+		//
+		// NOTE: Terraform graph can sometimes decide to create multiple
+		// ZNodes that share part of their path ancestry at the same time.
+		// When that happens, we have contention in this area of code,
+		// where a `path` that didn't exist above, it exists once we try
+		// to create it.
+		// For this reason, we avoid reporting an error if it is about
+		// a ZNode already existing. The opposite, a parent being deleted after
+		// this, is handled by doCreate attempting again.
+		if !exists {
+			err := c.audited(AuditOperationCreate, path, func() error {
+				_, createErr := c.zkConn.Create(path, nil, createFlags, acl)
+				return createErr
+			})
+			if err != nil && !errors.Is(err, ErrorZNodeAlreadyExists) {
+				return fmt.Errorf("failed to create parent ZNode '%s' (createFlags: %d, acl: %v): %w", path, createFlags, acl, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Read the ZNode at the given path.
+//
+// Reads of the same ZNode are deduplicated for the lifetime of the Client (see readCache):
+// the returned ZNode might be shared, and must not be modified.
+func (c *Client) Read(path string) (*ZNode, error) {
+	defer c.telemetry.record("Read", path, time.Now())
+
+	if err := c.paths.check(path); err != nil {
+		return nil, err
+	}
+
+	return c.reads.read(path, func(cached *ZNode) (*ZNode, error) {
+		if cached != nil {
+			return c.readIfChanged(cached)
+		}
+		return c.read(path)
+	})
+}
+
+// ReadIfChanged is like Read, but given the ZNode as known from a previous read (ex. persisted elsewhere):
+// its data and ACL are read again only if its zk.Stat reports that they have changed since.
+// Otherwise, `known` is returned with the up-to-date zk.Stat, saving the download of unchanged data.
+//
+// Only the `Czxid`, `Mzxid` and `Aversion` of the zk.Stat of `known` are compared. If `known` has no ACL populated,
+// it is read like Read does, unless the Client never reads ACLs (see WithoutACLReads).
+func (c *Client) ReadIfChanged(known *ZNode) (*ZNode, error) {
+	if known.ACL == nil && !c.skipACLReads {
+		return c.Read(known.Path)
+	}
+
+	defer c.telemetry.record("ReadIfChanged", known.Path, time.Now())
+
+	if err := c.paths.check(known.Path); err != nil {
+		return nil, err
+	}
+
+	return c.reads.read(known.Path, func(cached *ZNode) (*ZNode, error) {
+		if cached == nil {
+			cached = known
+		}
+		return c.readIfChanged(cached)
+	})
+}
+
+// readIfChanged reads again the given ZNode, only if its zk.Stat reports that
+// its data or ACL have changed since. Otherwise, it returns it with the up-to-date zk.Stat.
+func (c *Client) readIfChanged(cached *ZNode) (*ZNode, error) {
+	exists, stat, err := c.zkConn.Exists(cached.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ZNode '%s': %w", cached.Path, err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("failed to read ZNode '%s': %w", cached.Path, ErrorZNodeDoesNotExist)
+	}
+
+	// Same creation (i.e. not deleted and re-created), with no modification to data or ACL since
+	if stat.Czxid != cached.Stat.Czxid || stat.Mzxid != cached.Stat.Mzxid || stat.Aversion != cached.Stat.Aversion {
+		return c.read(cached.Path)
+	}
+
+	return &ZNode{
+		Path: cached.Path,
+		Stat: stat,
+		Data: cached.Data,
+		ACL:  cached.ACL,
+	}, nil
+}
+
+func (c *Client) read(path string) (*ZNode, error) {
+	data, stat, err := c.zkConn.Get(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ZNode '%s': %w", path, err)
+	}
+
+	znode := &ZNode{
+		Path: path,
+		Stat: stat,
+		Data: data,
+	}
+
+	if !c.skipACLReads {
+		znode.ACL, _, err = c.zkConn.GetACL(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch ACLs for ZNode '%s': %w", path, err)
+		}
+	}
+
+	return znode, nil
+}
+
+// ReadACL reads the ACL of the ZNode at the given path.
+func (c *Client) ReadACL(path string) ([]zk.ACL, error) {
+	defer c.telemetry.record("ReadACL", path, time.Now())
+
+	if err := c.paths.check(path); err != nil {
+		return nil, err
+	}
+
+	acls, _, err := c.zkConn.GetACL(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch ACLs for ZNode '%s': %w", path, err)
+	}
+
+	return acls, nil
+}
+
+// ReadMany reads all the ZNodes at the given paths, returning them in the same order.
+//
+// Reads are executed concurrently, pipelined over the same ZooKeeper session.
+// If any read fails, the error of the first failing path (in the given order) is returned.
+//
+// NOTE: ZooKeeper 3.6+ offers a `multiRead` operation that would allow doing this in a
+// single round trip, but it is not supported by the underlying ZooKeeper client library yet.
+func (c *Client) ReadMany(paths []string) ([]*ZNode, error) {
+	znodes := make([]*ZNode, len(paths))
+	errs := make([]error, len(paths))
+
+	forEachConcurrently(len(paths), func(i int) {
+		znodes[i], errs[i] = c.Read(paths[i])
+	})
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return znodes, nil
+}
+
+// ReadChildren lists the children of the ZNode at the given path, sorted by name,
+// returning each one with its Data and zk.Stat.
+//
+// ACL is not populated. Children deleted while this is in progress are silently skipped.
+func (c *Client) ReadChildren(path string) ([]*ZNode, error) {
+	defer c.telemetry.record("ReadChildren", path, time.Now())
+
+	if err := c.paths.check(path); err != nil {
+		return nil, err
+	}
+
+	return c.readChildren(path, func(childPath string) (*zk.Stat, []byte, error) {
+		data, stat, err := c.zkConn.Get(childPath)
+		if errors.Is(err, ErrorZNodeDoesNotExist) {
+			return nil, nil, nil
+		}
+		return stat, data, err
+	})
+}
+
+// ReadChildrenStats lists the children of the ZNode at the given path, sorted by name,
+// returning each one with its zk.Stat.
+//
+// Only the Stat is fetched for each child: Data and ACL are not populated.
+// Children deleted while this is in progress are silently skipped.
+func (c *Client) ReadChildrenStats(path string) ([]*ZNode, error) {
+	defer c.telemetry.record("ReadChildrenStats", path, time.Now())
+
+	if err := c.paths.check(path); err != nil {
+		return nil, err
+	}
+
+	return c.readChildren(path, func(childPath string) (*zk.Stat, []byte, error) {
+		exists, stat, err := c.zkConn.Exists(childPath)
+		if !exists {
+			return nil, nil, err
+		}
+		return stat, nil, err
+	})
+}
+
+// readChildren lists the children of the ZNode at the given path, and reads each of them
+// concurrently via readFn. Children for which readFn returns a `nil` zk.Stat are skipped.
+func (c *Client) readChildren(path string, readFn func(childPath string) (*zk.Stat, []byte, error)) ([]*ZNode, error) {
+	children, _, err := c.zkConn.Children(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list children for ZNode '%s': %w", path, err)
+	}
+	sort.Strings(children)
+
+	childrenZNodes := make([]*ZNode, len(children))
+	errs := make([]error, len(children))
+
+	forEachConcurrently(len(children), func(i int) {
+		childPath := JoinPath(path, children[i])
+
+		stat, data, err := readFn(childPath)
+		if err != nil {
+			errs[i] = fmt.Errorf("failed to read ZNode '%s': %w", childPath, err)
+		} else if stat != nil {
+			childrenZNodes[i] = &ZNode{Path: childPath, Stat: stat, Data: data}
+		}
+	})
+
+	existingChildrenZNodes := make([]*ZNode, 0, len(childrenZNodes))
+	for i, childZNode := range childrenZNodes {
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+		if childZNode != nil {
+			existingChildrenZNodes = append(existingChildrenZNodes, childZNode)
+		}
+	}
+
+	return existingChildrenZNodes, nil
+}
+
+// forEachConcurrently invokes fn for each index in [0, n),
+// with at most maxConcurrentRequests invocations running at the same time.
+func forEachConcurrently(n int, fn func(i int)) {
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, maxConcurrentRequests)
+
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		semaphore <- struct{}{}
+
+		go func() {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			fn(i)
+		}()
+	}
+
+	wg.Wait()
+}
+
+// Update the ZNode at the given path, under the assumption that it is there.
+//
+// If `acl` is `nil`, only the data is updated. Will return an error if it doesn't already exist.
+func (c *Client) Update(path string, data []byte, acl []zk.ACL) (*ZNode, error) {
+	defer c.telemetry.record("Update", path, time.Now())
+
+	if err := c.paths.checkWrite(path, false); err != nil {
+		return nil, err
+	}
+	if err := c.CheckACL(acl); err != nil {
+		return nil, err
+	}
+
+	// Even if only partially successful, the update invalidates any previous read
+	var aclStat *zk.Stat
+	if acl != nil {
+		err := c.audited(AuditOperationSetACL, path, func() (setErr error) {
+			aclStat, setErr = c.zkConn.SetACL(path, acl, matchAnyVersion)
+			return setErr
+		})
+		if err != nil {
+			c.reads.invalidate(path, false)
+			if errors.Is(err, ErrorZNodeDoesNotExist) {
+				return nil, fmt.Errorf("failed to update ZNode '%s': %w", path, err)
+			}
+			return nil, fmt.Errorf("failed to update ZNode '%s' ACL: %w", path, err)
+		}
+	}
+
+	var stat *zk.Stat
+	err := c.audited(AuditOperationSetData, path, func() (setErr error) {
+		stat, setErr = c.zkConn.Set(path, data, matchAnyVersion)
+		return setErr
+	})
+	if err != nil {
+		c.reads.invalidate(path, false)
+		return nil, fmt.Errorf("failed to update ZNode '%s': %w", path, err)
+	}
+
+	if aclStat != nil && stat.Aversion != aclStat.Aversion {
+		// ACL modified concurrently, between the two updates
+		c.reads.invalidate(path, false)
+		return c.Read(path)
+	}
+
+	return c.written(path, data, acl, stat)
+}
+
+// Delete the given ZNode.
+//
+// Note that will also delete any child ZNode, recursively.
+// Children deleted concurrently, while this is in progress, are silently skipped.
+func (c *Client) Delete(path string) error {
+	defer c.telemetry.record("Delete", path, time.Now())
+
+	if err := c.paths.checkWrite(path, true); err != nil {
+		return err
+	}
+
+	defer c.reads.invalidate(path, true)
+
+	return c.delete(path, false)
+}
+
+func (c *Client) delete(path string, isChild bool) error {
+	children, _, err := c.zkConn.Children(path)
+	if err != nil {
+		if isChild && errors.Is(err, ErrorZNodeDoesNotExist) {
+			return nil
+		}
+		return fmt.Errorf("failed to list children for ZNode '%s': %w", path, err)
+	}
+
+	for _, child := range children {
+		childPath := JoinPath(path, child)
+		err = c.delete(childPath, true)
+		if err != nil {
+			return fmt.Errorf("failed to delete child '%s' of ZNode '%s': %w", childPath, path, err)
+		}
+	}
+
+	err = c.audited(AuditOperationDelete, path, func() error {
+		return c.zkConn.Delete(path, matchAnyVersion)
+	})
+	if err != nil {
+		if isChild && errors.Is(err, ErrorZNodeDoesNotExist) {
+			return nil
+		}
+		return fmt.Errorf("failed to delete ZNode '%s': %w", path, err)
+	}
+	return nil
+}
+
+// Exists checks for the existence of the given ZNode.
+func (c *Client) Exists(path string) (bool, error) {
+	defer c.telemetry.record("Exists", path, time.Now())
+
+	if err := c.paths.check(path); err != nil {
+		return false, err
+	}
+
+	exists, _, err := c.zkConn.Exists(path)
+	if err != nil {
+		return false, fmt.Errorf("failed to check existence of ZNode '%s': %w", path, err)
+	}
+
+	return exists, nil
+}
+
+// WalkFunc is the type of the function called by Walk, for each visited ZNode.
+//
+// The ZNode passed to it has no ACL populated.
+// If it returns ErrorStopWalk, the walk stops without error; any other error aborts the walk.
+type WalkFunc func(znode *ZNode, depth int) error
+
+// Walk visits the ZNode at the given path, and all its descendants, depth-first.
+//
+// Children are visited in lexicographic order. The ZNode at `path` has depth 0,
+// its children depth 1 and so forth: ZNodes deeper than `maxDepth` are not visited,
+// unless `maxDepth` is negative (i.e. no limit).
+//
+// ZNodes deleted while the walk is in progress are silently skipped.
+func (c *Client) Walk(path string, maxDepth int, walkFn WalkFunc) error {
+	defer c.telemetry.record("Walk", path, time.Now())
+
+	if err := c.paths.check(path); err != nil {
+		return err
+	}
+
+	err := c.walk(path, 0, maxDepth, walkFn)
+	if errors.Is(err, ErrorStopWalk) {
+		return nil
+	}
+
+	return err
+}
+
+func (c *Client) walk(path string, depth int, maxDepth int, walkFn WalkFunc) error {
+	data, stat, err := c.zkConn.Get(path)
+	if err != nil {
+		if depth > 0 && errors.Is(err, ErrorZNodeDoesNotExist) {
+			return nil
+		}
+		return fmt.Errorf("failed to read ZNode '%s': %w", path, err)
+	}
+
+	if err := walkFn(&ZNode{Path: path, Stat: stat, Data: data}, depth); err != nil {
+		return err
+	}
+
+	if (maxDepth >= 0 && depth >= maxDepth) || stat.NumChildren == 0 {
+		return nil
+	}
+
+	children, _, err := c.zkConn.Children(path)
+	if err != nil {
+		if errors.Is(err, ErrorZNodeDoesNotExist) {
+			return nil
+		}
+		return fmt.Errorf("failed to list children for ZNode '%s': %w", path, err)
+	}
+
+	sort.Strings(children)
+	for _, child := range children {
+		if err := c.walk(JoinPath(path, child), depth+1, maxDepth, walkFn); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// WaitForExists blocks until the ZNode at the given path exists, or the given timeout expires.
+//
+// Instead of polling, it relies on a ZooKeeper Watch set via `ExistsW`,
+// that fires as soon as the ZNode is created.
+func (c *Client) WaitForExists(ctx context.Context, path string, timeout time.Duration) error {
+	defer c.telemetry.record("WaitForExists", path, time.Now())
+
+	if err := c.paths.check(path); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	for {
+		exists, _, watch, err := c.zkConn.ExistsW(path)
+		if err != nil {
+			return fmt.Errorf("failed to watch existence of ZNode '%s': %w", path, err)
+		}
+
+		if exists {
+			return nil
+		}
+
+		if err := awaitWatch(ctx, watch); err != nil {
+			return fmt.Errorf("timed out after %s waiting for ZNode '%s' to exist: %w", timeout, path, err)
+		}
+	}
+}
+
+// WaitForData blocks until the data of the ZNode at the given path satisfies `matches`,
+// or the given timeout expires.
+//
+// If the ZNode doesn't exist yet, it will first wait for it to be created.
+// Like WaitForExists, it relies on ZooKeeper Watches instead of polling.
+func (c *Client) WaitForData(ctx context.Context, path string, timeout time.Duration, matches func(data []byte) bool) error {
+	defer c.telemetry.record("WaitForData", path, time.Now())
+
+	if err := c.paths.check(path); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	for {
+		data, _, watch, err := c.zkConn.GetW(path)
+		if errors.Is(err, ErrorZNodeDoesNotExist) {
+			// The ZNode is not there (yet): watch for its creation instead
+			var exists bool
+			exists, _, watch, err = c.zkConn.ExistsW(path)
+			if err == nil && exists {
+				// Created in the meantime: read it again
+				continue
+			}
+		} else if err == nil && matches(data) {
+			return nil
+		}
+
+		if err != nil {
+			return fmt.Errorf("failed to watch data of ZNode '%s': %w", path, err)
+		}
+
+		if err := awaitWatch(ctx, watch); err != nil {
+			return fmt.Errorf("timed out after %s waiting for ZNode '%s' to contain the expected data: %w", timeout, path, err)
+		}
+	}
+}
+
+// awaitWatch blocks until the given ZooKeeper Watch fires, or the context is done.
+func awaitWatch(ctx context.Context, watch <-chan zk.Event) error {
+	select {
+	case <-watch:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// JoinPath returns the path of the child ZNode with the given name, under the given parent path.
+func JoinPath(parentPath string, childName string) string {
+	if parentPath == zNodeRootPath {
+		return zNodeRootPath + childName
+	}
+
+	return fmt.Sprintf("%s%c%s", parentPath, zNodePathSeparator, childName)
+}
+
+// RemoveSequentialSuffix takes the path to a sequential ZNode, maybe created via CreateSequential,
+// and truncates the unique suffix.
+//
+// See: https://zookeeper.apache.org/doc/r3.6.3/zookeeperProgrammers.html#Sequence+Nodes+--+Unique+Naming
+func RemoveSequentialSuffix(path string) string {
+	return path[:len(path)-sequentialSuffixLen]
+}
+
+// SequenceNumber takes the path to a sequential ZNode, maybe created via CreateSequential,
+// and returns the number contained in its unique suffix.
+//
+// See: https://zookeeper.apache.org/doc/r3.6.3/zookeeperProgrammers.html#Sequence+Nodes+--+Unique+Naming
+func SequenceNumber(path string) (int64, error) {
+	if len(path) < sequentialSuffixLen {
+		return 0, fmt.Errorf("path '%s' is too short to have a sequential suffix", path)
+	}
+
+	// The counter is a signed int32: once it overflows, the suffix is negative (ex. `-000000001`)
+	suffix := path[len(path)-sequentialSuffixLen:]
+	number, err := strconv.ParseInt(suffix, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("path '%s' does not end with a sequential suffix: %w", path, err)
+	}
+
+	return number, nil
+}
+
+// JoinPaths joins the given path parts with the ZNode path separator, into an absolute path.
+//
+// Empty parts and redundant separators are ignored, so that for example
+// `JoinPaths("/parent/", "", "child")` returns `/parent/child`.
+func JoinPaths(parts ...string) string {
+	return pathpkg.Join(append([]string{zNodeRootPath}, parts...)...)
+}
+
+// EscapeName escapes the given string so that it can be safely used as name of a ZNode,
+// i.e. as a single path segment: path separators and other special characters are percent-encoded,
+// the same way they would be in URL path, and so are the reserved names `.` and `..`.
+func EscapeName(name string) string {
+	if name == "." || name == ".." {
+		return strings.ReplaceAll(name, ".", "%2E")
+	}
+
+	return url.PathEscape(name)
+}
+
+// UnescapeName reverses EscapeName, returning the original string from the name of a ZNode.
+func UnescapeName(name string) (string, error) {
+	unescaped, err := url.PathUnescape(name)
+	if err != nil {
+		return "", fmt.Errorf("name '%s' is not escaped: %w", name, err)
+	}
+
+	return unescaped, nil
+}
+
+// ValidatePath confirms the given path is valid for ZooKeeper, the same way its servers do:
+// absolute, without a trailing separator, without empty or relative (i.e. `.` and `..`) segments,
+// and without characters ZooKeeper doesn't allow in names (ex. control characters).
+//
+// Any other character, like spaces, `%` or unicode ones, is allowed: see EscapeName to avoid them.
+func ValidatePath(path string) error {
+	if !strings.HasPrefix(path, zNodeRootPath) {
+		return fmt.Errorf("invalid path '%s': must be absolute", path)
+	}
+	if path == zNodeRootPath {
+		return nil
+	}
+
+	for _, segment := range strings.Split(path[1:], string(zNodePathSeparator)) {
+		if segment == "" || segment == "." || segment == ".." {
+			return fmt.Errorf("invalid path '%s': must not contain empty or relative segments, or end with '/'", path)
+		}
+
+		for _, r := range segment {
+			if !isValidNameRune(r) {
+				return fmt.Errorf("invalid path '%s': character %U is not allowed", path, r)
+			}
+		}
+	}
+
+	return nil
+}
+
+// isValidNameRune returns false for the characters ZooKeeper doesn't allow in the name of a ZNode:
+// control and private use characters, and invalid UTF-8 (see ValidatePath).
+func isValidNameRune(r rune) bool {
+	switch {
+	case r == utf8.RuneError:
+		return false
+	case r <= 0x1f || (r >= 0x7f && r <= 0x9f):
+		return false
+	case (r >= 0xd800 && r <= 0xf8ff) || (r >= 0xfff0 && r <= 0xffff):
+		return false
+	default:
+		return true
+	}
+}
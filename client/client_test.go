@@ -0,0 +1,687 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-zookeeper/zk"
+	testifyAssert "github.com/stretchr/testify/assert"
+	"github.com/tfzk/terraform-provider-zookeeper/client"
+)
+
+func initTest(t *testing.T) (*client.Client, *testifyAssert.Assertions) {
+	assert := testifyAssert.New(t)
+
+	client, err := client.NewClientFromEnv()
+	assert.NoError(err)
+
+	return client, assert
+}
+
+func TestClassicCRUD(t *testing.T) {
+	client, assert := initTest(t)
+
+	// confirm not exists yet
+	znodeExists, err := client.Exists("/test/ClassicCRUD")
+	assert.NoError(err)
+	assert.False(znodeExists)
+
+	// create
+	znode, err := client.Create("/test/ClassicCRUD", []byte("one"), zk.WorldACL(zk.PermAll))
+	assert.NoError(err)
+	assert.Equal("/test/ClassicCRUD", znode.Path)
+	assert.Equal([]byte("one"), znode.Data)
+
+	// confirm exists
+	znodeExists, err = client.Exists("/test/ClassicCRUD")
+	assert.NoError(err)
+	assert.True(znodeExists)
+
+	// read
+	znode, err = client.Read("/test/ClassicCRUD")
+	assert.NoError(err)
+	assert.Equal("/test/ClassicCRUD", znode.Path)
+	assert.Equal([]byte("one"), znode.Data)
+
+	// update
+	znode, err = client.Update("/test/ClassicCRUD", []byte("two"), zk.WorldACL(zk.PermAll))
+	assert.NoError(err)
+	assert.Equal("/test/ClassicCRUD", znode.Path)
+	assert.Equal([]byte("two"), znode.Data)
+
+	// delete
+	err = client.Delete("/test/ClassicCRUD")
+	assert.NoError(err)
+
+	// confirm not exists
+	znodeExists, err = client.Exists("/test/ClassicCRUD")
+	assert.NoError(err)
+	assert.False(znodeExists)
+
+	// confirm container still exists
+	znodeExists, err = client.Exists("/test")
+	assert.NoError(err)
+	assert.True(znodeExists)
+
+	// delete container
+	err = client.Delete("/test")
+	assert.NoError(err)
+}
+
+func TestCreateSequential(t *testing.T) {
+	client, assert := initTest(t)
+
+	noPrefixSeqZNode, err := client.CreateSequential("/test/CreateSequential/", []byte("seq"), zk.WorldACL(zk.PermAll))
+	assert.NoError(err)
+	assert.Equal("/test/CreateSequential/0000000000", noPrefixSeqZNode.Path)
+
+	prefixSeqZNode, err := client.CreateSequential("/test/CreateSequentialWithPrefix/prefix-", []byte("seq"), zk.WorldACL(zk.PermAll))
+	assert.NoError(err)
+	assert.Equal("/test/CreateSequentialWithPrefix/prefix-0000000000", prefixSeqZNode.Path)
+
+	// delete, recursively
+	err = client.Delete("/test")
+	assert.NoError(err)
+}
+
+func TestDigestAuthenticationSuccess(t *testing.T) {
+	t.Setenv(client.EnvZooKeeperUsername, "username")
+	t.Setenv(client.EnvZooKeeperPassword, "password")
+	client, assert := initTest(t)
+
+	// Create a ZNode accessible only by the given user
+	acl := zk.DigestACL(zk.PermAll, "username", "password")
+	znode, err := client.Create("/auth-test/DigestAuthentication", []byte("data"), acl)
+	assert.NoError(err)
+	assert.Equal("/auth-test/DigestAuthentication", znode.Path)
+	assert.Equal([]byte("data"), znode.Data)
+	assert.Equal(acl, znode.ACL)
+
+	// Make sure it's accessible
+	znode, err = client.Read("/auth-test/DigestAuthentication")
+	assert.NoError(err)
+	assert.Equal("/auth-test/DigestAuthentication", znode.Path)
+	assert.Equal([]byte("data"), znode.Data)
+	assert.Equal(acl, znode.ACL)
+
+	// Cleanup
+	err = client.Delete("/auth-test/DigestAuthentication")
+	assert.NoError(err)
+	err = client.Delete("/auth-test")
+	assert.NoError(err)
+}
+
+func TestFailureWhenReadingZNodeWithIncorrectAuth(t *testing.T) {
+	// Create client authenticated as foo user
+	t.Setenv(client.EnvZooKeeperUsername, "foo")
+	t.Setenv(client.EnvZooKeeperPassword, "password")
+	fooClient, assert := initTest(t)
+
+	// Create a ZNode accessible only by foo user
+	acl := zk.DigestACL(zk.PermAll, "foo", "password")
+	znode, err := fooClient.Create("/auth-fail-test/AccessibleOnlyByFoo", []byte("data"), acl)
+	assert.NoError(err)
+	assert.Equal("/auth-fail-test/AccessibleOnlyByFoo", znode.Path)
+	assert.Equal([]byte("data"), znode.Data)
+	assert.Equal(acl, znode.ACL)
+
+	// Make sure it's accessible by foo user
+	znode, err = fooClient.Read("/auth-fail-test/AccessibleOnlyByFoo")
+	assert.NoError(err)
+	assert.Equal("/auth-fail-test/AccessibleOnlyByFoo", znode.Path)
+	assert.Equal([]byte("data"), znode.Data)
+	assert.Equal(acl, znode.ACL)
+
+	// Create client authenticated as bar user
+	t.Setenv(client.EnvZooKeeperUsername, "bar")
+	t.Setenv(client.EnvZooKeeperPassword, "password")
+	barClient, err := client.NewClientFromEnv()
+	assert.NoError(err)
+
+	// The node should be inaccessible by bar user
+	_, err = barClient.Read("/auth-fail-test/AccessibleOnlyByFoo")
+	assert.EqualError(err, "failed to read ZNode '/auth-fail-test/AccessibleOnlyByFoo': zk: not authenticated")
+
+	// Cleanup
+	err = fooClient.Delete("/auth-fail-test/AccessibleOnlyByFoo")
+	assert.NoError(err)
+	err = fooClient.Delete("/auth-fail-test")
+	assert.NoError(err)
+}
+
+func TestFailureWhenCreatingForNonSequentialZNodeEndingInSlash(t *testing.T) {
+	client, assert := initTest(t)
+
+	_, err := client.Create("/test/willFail/", nil, zk.WorldACL(zk.PermAll))
+	assert.Error(err)
+	assert.Equal("non-sequential ZNode cannot have path '/test/willFail/' because it ends in '/'", err.Error())
+}
+
+func TestFailureWhenCreatingWhenZNodeAlreadyExists(t *testing.T) {
+	client, assert := initTest(t)
+
+	_, err := client.Create("/test/node", nil, zk.WorldACL(zk.PermAll))
+	assert.NoError(err)
+	_, err = client.Create("/test/node", nil, zk.WorldACL(zk.PermAll))
+	assert.Error(err)
+	assert.Equal("failed to create ZNode '/test/node' (size: 0, createFlags: 0, acl: [{31 world anyone}]): zk: node already exists", err.Error())
+
+	err = client.Delete("/test")
+	assert.NoError(err)
+}
+
+func TestFailureWithNonExistingZNodes(t *testing.T) {
+	client, assert := initTest(t)
+
+	_, err := client.Read("/does-not-exist")
+	assert.Error(err)
+	assert.Equal("failed to read ZNode '/does-not-exist': zk: node does not exist", err.Error())
+
+	_, err = client.Update("/also-does-not-exist", nil, zk.WorldACL(zk.PermAll))
+	assert.Error(err)
+	assert.ErrorIs(err, zk.ErrNoNode)
+	assert.Equal("failed to update ZNode '/also-does-not-exist': zk: node does not exist", err.Error())
+}
+
+func TestWaitForExists(t *testing.T) {
+	client, assert := initTest(t)
+
+	// create the ZNode after a short delay
+	go func() {
+		time.Sleep(500 * time.Millisecond)
+		_, err := client.Create("/test/WaitForExists", []byte("appeared"), zk.WorldACL(zk.PermAll))
+		assert.NoError(err)
+	}()
+
+	err := client.WaitForExists(context.Background(), "/test/WaitForExists", 10*time.Second)
+	assert.NoError(err)
+
+	// confirm exists
+	znodeExists, err := client.Exists("/test/WaitForExists")
+	assert.NoError(err)
+	assert.True(znodeExists)
+
+	// delete, recursively
+	err = client.Delete("/test")
+	assert.NoError(err)
+}
+
+func TestFailureWhenWaitingForExistsTimesOut(t *testing.T) {
+	client, assert := initTest(t)
+
+	err := client.WaitForExists(context.Background(), "/does-not-exist", 500*time.Millisecond)
+	assert.Error(err)
+	assert.Equal("timed out after 500ms waiting for ZNode '/does-not-exist' to exist: context deadline exceeded", err.Error())
+}
+
+func TestWaitForData(t *testing.T) {
+	client, assert := initTest(t)
+
+	// create the ZNode, then update it to the expected data after a short delay
+	go func() {
+		_, err := client.Create("/test/WaitForData", []byte("starting"), zk.WorldACL(zk.PermAll))
+		assert.NoError(err)
+		time.Sleep(500 * time.Millisecond)
+		_, err = client.Update("/test/WaitForData", []byte("ready"), zk.WorldACL(zk.PermAll))
+		assert.NoError(err)
+	}()
+
+	err := client.WaitForData(context.Background(), "/test/WaitForData", 10*time.Second, func(data []byte) bool {
+		return string(data) == "ready"
+	})
+	assert.NoError(err)
+
+	// read
+	znode, err := client.Read("/test/WaitForData")
+	assert.NoError(err)
+	assert.Equal([]byte("ready"), znode.Data)
+
+	// delete, recursively
+	err = client.Delete("/test")
+	assert.NoError(err)
+}
+
+func TestReadMany(t *testing.T) {
+	client, assert := initTest(t)
+
+	paths := []string{"/test/ReadMany/one", "/test/ReadMany/two", "/test/ReadMany/three"}
+	for _, path := range paths {
+		_, err := client.Create(path, []byte(path), zk.WorldACL(zk.PermAll))
+		assert.NoError(err)
+	}
+
+	// read, in the given order
+	znodes, err := client.ReadMany(paths)
+	assert.NoError(err)
+	assert.Len(znodes, len(paths))
+	for i, path := range paths {
+		assert.Equal(path, znodes[i].Path)
+		assert.Equal([]byte(path), znodes[i].Data)
+	}
+
+	// fails if any is missing
+	_, err = client.ReadMany([]string{"/test/ReadMany/one", "/test/ReadMany/missing"})
+	assert.Error(err)
+	assert.Equal("failed to read ZNode '/test/ReadMany/missing': zk: node does not exist", err.Error())
+
+	// delete, recursively
+	err = client.Delete("/test")
+	assert.NoError(err)
+}
+
+func TestWalk(t *testing.T) {
+	zkClient, assert := initTest(t)
+
+	for _, path := range []string{"/test/Walk/b", "/test/Walk/a/deep", "/test/Walk/c"} {
+		_, err := zkClient.Create(path, []byte(path), zk.WorldACL(zk.PermAll))
+		assert.NoError(err)
+	}
+
+	// walk everything, depth-first in lexicographic order
+	visited := []string{}
+	err := zkClient.Walk("/test/Walk", -1, func(znode *client.ZNode, _ int) error {
+		visited = append(visited, znode.Path)
+		return nil
+	})
+	assert.NoError(err)
+	assert.Equal([]string{"/test/Walk", "/test/Walk/a", "/test/Walk/a/deep", "/test/Walk/b", "/test/Walk/c"}, visited)
+
+	// walk only direct children
+	visited = []string{}
+	err = zkClient.Walk("/test/Walk", 1, func(znode *client.ZNode, _ int) error {
+		visited = append(visited, znode.Path)
+		return nil
+	})
+	assert.NoError(err)
+	assert.Equal([]string{"/test/Walk", "/test/Walk/a", "/test/Walk/b", "/test/Walk/c"}, visited)
+
+	// stop early
+	visited = []string{}
+	err = zkClient.Walk("/test/Walk", -1, func(znode *client.ZNode, _ int) error {
+		if len(visited) == 2 {
+			return client.ErrorStopWalk
+		}
+		visited = append(visited, znode.Path)
+		return nil
+	})
+	assert.NoError(err)
+	assert.Equal([]string{"/test/Walk", "/test/Walk/a"}, visited)
+
+	// walk concurrently, in the same order, with ACL
+	visited = []string{}
+	err = zkClient.WalkConcurrently("/test/Walk", client.WalkOptions{MaxDepth: -1, Concurrency: 4, IncludeACL: true}, func(znode *client.ZNode, _ int) error {
+		assert.Equal(zk.WorldACL(zk.PermAll), znode.ACL)
+		visited = append(visited, znode.Path)
+		return nil
+	})
+	assert.NoError(err)
+	assert.Equal([]string{"/test/Walk", "/test/Walk/a", "/test/Walk/a/deep", "/test/Walk/b", "/test/Walk/c"}, visited)
+
+	// delete, recursively
+	err = zkClient.Delete("/test")
+	assert.NoError(err)
+}
+
+func TestJoinPath(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	assert.Equal("/child", client.JoinPath("/", "child"))
+	assert.Equal("/parent/child", client.JoinPath("/parent", "child"))
+}
+
+func TestJoinPaths(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	assert.Equal("/", client.JoinPaths())
+	assert.Equal("/a/b/c", client.JoinPaths("a", "b", "c"))
+	assert.Equal("/parent/child", client.JoinPaths("/parent/", "", "child"))
+}
+
+func TestEscapeName(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	assert.Equal("service", client.EscapeName("service"))
+	assert.Equal("host:2181", client.EscapeName("host:2181"))
+	assert.Equal("a%2Fb%20c", client.EscapeName("a/b c"))
+	assert.Equal("%2E%2E", client.EscapeName(".."))
+	assert.Equal("100%25%20caf%C3%A9", client.EscapeName("100% café"))
+}
+
+func TestUnescapeName(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	for _, name := range []string{"service", "a/b c", "..", "100% café", "tab\there"} {
+		unescaped, err := client.UnescapeName(client.EscapeName(name))
+		assert.NoError(err, name)
+		assert.Equal(name, unescaped)
+	}
+
+	_, err := client.UnescapeName("100%")
+	assert.ErrorContains(err, "name '100%' is not escaped")
+}
+
+func TestValidatePath(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	for _, valid := range []string{"/", "/forza", "/forza/napoli", "/forza napoli/100%", "/città/caffè", "/.forza/napoli."} {
+		assert.NoError(client.ValidatePath(valid), valid)
+	}
+
+	for _, invalid := range []string{"", "forza", "/forza/", "//forza", "/forza/./napoli", "/forza/..", "/tab\there", "/null\x00", "/\xff"} {
+		assert.Error(client.ValidatePath(invalid), invalid)
+	}
+}
+
+func TestSequenceNumber(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	number, err := client.SequenceNumber("/locks/lock-0000000042")
+	assert.NoError(err)
+	assert.Equal(int64(42), number)
+
+	number, err = client.SequenceNumber("/locks/lock--000000001")
+	assert.NoError(err)
+	assert.Equal(int64(-1), number)
+
+	_, err = client.SequenceNumber("/locks/lock")
+	assert.Error(err)
+
+	_, err = client.SequenceNumber("/config/not-a-sequential")
+	assert.Error(err)
+}
+
+func TestReadChildrenStats(t *testing.T) {
+	client, assert := initTest(t)
+
+	for _, path := range []string{"/test/ReadChildrenStats/b", "/test/ReadChildrenStats/a"} {
+		_, err := client.Create(path, []byte(path), zk.WorldACL(zk.PermAll))
+		assert.NoError(err)
+	}
+
+	children, err := client.ReadChildrenStats("/test/ReadChildrenStats")
+	assert.NoError(err)
+	assert.Len(children, 2)
+	assert.Equal("/test/ReadChildrenStats/a", children[0].Path)
+	assert.Equal(int32(len("/test/ReadChildrenStats/a")), children[0].Stat.DataLength)
+	assert.Nil(children[0].Data)
+	assert.Equal("/test/ReadChildrenStats/b", children[1].Path)
+
+	// delete, recursively
+	err = client.Delete("/test")
+	assert.NoError(err)
+}
+
+func TestConcurrentOperationsSharingParents(t *testing.T) {
+	zkClient, assert := initTest(t)
+
+	const workers = 16
+	errs := make([]error, workers)
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			// All the ZNodes share the same parents, created concurrently
+			path := fmt.Sprintf("/test/ConcurrentOperations/parent/%d", i)
+			if _, errs[i] = zkClient.Create(path, []byte("one"), zk.WorldACL(zk.PermAll)); errs[i] != nil {
+				return
+			}
+			if _, errs[i] = zkClient.Update(path, []byte("two"), zk.WorldACL(zk.PermAll)); errs[i] != nil {
+				return
+			}
+			_, errs[i] = zkClient.Read(path)
+		}()
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		assert.NoError(err)
+	}
+
+	children, err := zkClient.ReadChildren("/test/ConcurrentOperations/parent")
+	assert.NoError(err)
+	assert.Len(children, workers)
+	for _, child := range children {
+		assert.Equal([]byte("two"), child.Data)
+	}
+
+	// delete, recursively
+	err = zkClient.Delete("/test")
+	assert.NoError(err)
+}
+
+func TestDeleteWhileChildrenAreDeletedConcurrently(t *testing.T) {
+	zkClient, assert := initTest(t)
+
+	const children = 32
+	for i := 0; i < children; i++ {
+		_, err := zkClient.Create(fmt.Sprintf("/test/ConcurrentDelete/%d/grandchild", i), nil, zk.WorldACL(zk.PermAll))
+		assert.NoError(err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < children; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			// Races with the recursive delete below: either can delete each child
+			_ = zkClient.Delete(fmt.Sprintf("/test/ConcurrentDelete/%d", i))
+		}()
+	}
+
+	err := zkClient.Delete("/test")
+	wg.Wait()
+	assert.NoError(err)
+
+	exists, err := zkClient.Exists("/test")
+	assert.NoError(err)
+	assert.False(exists)
+}
+
+func TestCreateWhileParentsAreDeletedConcurrently(t *testing.T) {
+	zkClient, assert := initTest(t)
+	otherClient, _ := initTest(t)
+
+	// Another process keeps cleaning up the parent, racing with the creation of its children
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-done:
+				return
+			default:
+				_ = otherClient.Delete("/test/ConcurrentCreate/parent")
+				time.Sleep(5 * time.Millisecond)
+			}
+		}
+	}()
+
+	for i := 0; i < 32; i++ {
+		_, err := zkClient.Create(fmt.Sprintf("/test/ConcurrentCreate/parent/%d", i), nil, zk.WorldACL(zk.PermAll))
+		assert.NoError(err)
+	}
+	close(done)
+	wg.Wait()
+
+	err := zkClient.Delete("/test")
+	assert.NoError(err)
+}
+
+func TestReadIsUpToDateWithChangesByOtherClients(t *testing.T) {
+	zkClient, assert := initTest(t)
+	otherClient, _ := initTest(t)
+
+	_, err := zkClient.Create("/test/ReadIsUpToDate", []byte("one"), zk.WorldACL(zk.PermAll))
+	assert.NoError(err)
+
+	znode, err := zkClient.Read("/test/ReadIsUpToDate")
+	assert.NoError(err)
+	assert.Equal([]byte("one"), znode.Data)
+
+	// Repeated reads of an unchanged ZNode are deduplicated
+	znode, err = zkClient.Read("/test/ReadIsUpToDate")
+	assert.NoError(err)
+	assert.Equal([]byte("one"), znode.Data)
+
+	// Changes made by other clients are still read
+	_, err = otherClient.Update("/test/ReadIsUpToDate", []byte("two"), zk.WorldACL(zk.PermRead|zk.PermWrite|zk.PermDelete))
+	assert.NoError(err)
+
+	znode, err = zkClient.Read("/test/ReadIsUpToDate")
+	assert.NoError(err)
+	assert.Equal([]byte("two"), znode.Data)
+	assert.Equal(zk.WorldACL(zk.PermRead|zk.PermWrite|zk.PermDelete), znode.ACL)
+
+	err = otherClient.Delete("/test")
+	assert.NoError(err)
+
+	_, err = zkClient.Read("/test/ReadIsUpToDate")
+	assert.ErrorIs(err, zk.ErrNoNode)
+}
+
+func TestAuditZNode(t *testing.T) {
+	zkClient, assert := initTest(t)
+
+	_, err := zkClient.Create("/test/Audit", nil, zk.WorldACL(zk.PermAll))
+	assert.NoError(err)
+
+	auditedClient, err := client.NewClient(os.Getenv(client.EnvZooKeeperServer), client.DefaultZooKeeperSessionSec, "", "",
+		client.WithAuditZNode("/test/Audit"))
+	assert.NoError(err)
+
+	_, err = auditedClient.Create("/test/Audited/node", []byte("one"), zk.WorldACL(zk.PermAll))
+	assert.NoError(err)
+	_, err = auditedClient.Update("/test/Audited/node", []byte("two"), zk.WorldACL(zk.PermAll))
+	assert.NoError(err)
+	assert.NoError(auditedClient.Delete("/test/Audited"))
+
+	entries, err := zkClient.ReadChildren("/test/Audit")
+	assert.NoError(err)
+
+	operations := []string{}
+	for _, entry := range entries {
+		var auditEntry client.AuditEntry
+		assert.NoError(json.Unmarshal(entry.Data, &auditEntry))
+		assert.Equal("world:anyone", auditEntry.Identity)
+		operations = append(operations, fmt.Sprintf("%s %s", auditEntry.Operation, auditEntry.Path))
+	}
+	assert.Equal([]string{
+		"create /test/Audited",
+		"create /test/Audited/node",
+		"set_acl /test/Audited/node",
+		"set_data /test/Audited/node",
+		"delete /test/Audited/node",
+		"delete /test/Audited",
+	}, operations)
+
+	// delete, recursively
+	err = zkClient.Delete("/test")
+	assert.NoError(err)
+}
+
+func TestReadWithoutACLReads(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	withoutACLReads, err := client.NewClient(os.Getenv(client.EnvZooKeeperServer), client.DefaultZooKeeperSessionSec, "", "",
+		client.WithoutACLReads())
+	assert.NoError(err)
+	assert.True(withoutACLReads.SkipsACLReads())
+
+	acl := zk.WorldACL(zk.PermAll)
+	znode, err := withoutACLReads.Create("/without-acl-reads-test/node", []byte("data"), acl)
+	assert.NoError(err)
+	assert.Equal(acl, znode.ACL)
+
+	// Reading another client's change does not read the ACL
+	otherClient, _ := initTest(t)
+	_, err = otherClient.Update("/without-acl-reads-test/node", []byte("changed"), zk.WorldACL(zk.PermRead|zk.PermWrite|zk.PermDelete))
+	assert.NoError(err)
+	znode, err = withoutACLReads.Read("/without-acl-reads-test/node")
+	assert.NoError(err)
+	assert.Equal([]byte("changed"), znode.Data)
+	assert.Nil(znode.ACL)
+
+	// Updating without an ACL leaves it as it is
+	_, err = withoutACLReads.Update("/without-acl-reads-test/node", []byte("updated"), nil)
+	assert.NoError(err)
+	znode, err = otherClient.Read("/without-acl-reads-test/node")
+	assert.NoError(err)
+	assert.Equal([]byte("updated"), znode.Data)
+	assert.Equal(zk.WorldACL(zk.PermRead|zk.PermWrite|zk.PermDelete), znode.ACL)
+
+	// Cleanup
+	err = otherClient.Delete("/without-acl-reads-test")
+	assert.NoError(err)
+}
+
+func TestReadIfChanged(t *testing.T) {
+	zkClient, assert := initTest(t)
+
+	created, err := zkClient.Create("/read-if-changed-test/node", []byte("data"), zk.WorldACL(zk.PermAll))
+	assert.NoError(err)
+
+	// As if persisted elsewhere: only the stat fields that are compared
+	known := &client.ZNode{
+		Path: created.Path,
+		Stat: &zk.Stat{Czxid: created.Stat.Czxid, Mzxid: created.Stat.Mzxid, Aversion: created.Stat.Aversion},
+		Data: []byte("data"),
+		ACL:  created.ACL,
+	}
+	otherClient, _ := initTest(t)
+	znode, err := otherClient.ReadIfChanged(known)
+	assert.NoError(err)
+	assert.Same(&known.Data[0], &znode.Data[0])
+	assert.Equal(created.Stat.Mtime, znode.Stat.Mtime)
+
+	_, err = zkClient.Update("/read-if-changed-test/node", []byte("changed"), nil)
+	assert.NoError(err)
+	otherClient, _ = initTest(t)
+	znode, err = otherClient.ReadIfChanged(known)
+	assert.NoError(err)
+	assert.Equal([]byte("changed"), znode.Data)
+
+	// Cleanup
+	err = zkClient.Delete("/read-if-changed-test")
+	assert.NoError(err)
+}
+
+func TestWriteLock(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	newLockingClient := func(timeout time.Duration) *client.Client {
+		c, err := client.NewClient(os.Getenv(client.EnvZooKeeperServer), client.DefaultZooKeeperSessionSec, "", "",
+			client.WithWriteLock("/write-lock-test/lock", timeout))
+		assert.NoError(err)
+		return c
+	}
+
+	// The first write acquires the lock, held until the session ends
+	holder := newLockingClient(time.Second)
+	_, err := holder.Create("/write-lock-test/holder", nil, zk.WorldACL(zk.PermAll))
+	assert.NoError(err)
+
+	waiter := newLockingClient(500 * time.Millisecond)
+	_, err = waiter.Create("/write-lock-test/waiter", nil, zk.WorldACL(zk.PermAll))
+	assert.ErrorIs(err, client.ErrorWriteLockTimeout)
+
+	holder.Close()
+	_, err = waiter.Create("/write-lock-test/waiter", nil, zk.WorldACL(zk.PermAll))
+	assert.NoError(err)
+	waiter.Close()
+
+	// Cleanup
+	cleaner, _ := initTest(t)
+	err = cleaner.Delete("/write-lock-test")
+	assert.NoError(err)
+}
@@ -0,0 +1,124 @@
+package client
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-zookeeper/zk"
+)
+
+// WithPasswordFile makes the Client read the password for digest authentication from the given file,
+// instead of the `password` passed to NewClient. Leading and trailing whitespace (ex. a final newline) is ignored.
+//
+// The file is read again whenever it changes, once the session is re-established after a disconnection:
+// if the password was rotated, the session is authenticated again with the new one.
+func WithPasswordFile(file string) ClientOption {
+	return func(c *Client) error {
+		pf := &passwordFile{file: file}
+		if _, err := pf.password(); err != nil {
+			return err
+		}
+
+		c.passwordFile = pf
+		return nil
+	}
+}
+
+// passwordFile is the digest authentication password of a Client, read from a file and read again when it changes
+// (see WithPasswordFile).
+type passwordFile struct {
+	file string
+
+	mu sync.Mutex
+	// loaded is the password last read, from the file last modified at loadedModTime
+	loaded        string
+	loadedModTime time.Time
+	// changed is true if loaded was not used to authenticate conn yet
+	changed bool
+	// conn is the connection authenticated with the password, `nil` until established by NewClient
+	conn *zk.Conn
+}
+
+// password returns the password, reading the file again if it was modified since last read.
+func (pf *passwordFile) password() (string, error) {
+	pf.mu.Lock()
+	defer pf.mu.Unlock()
+
+	if err := pf.reload(); err != nil {
+		return "", err
+	}
+
+	pf.changed = false
+	return pf.loaded, nil
+}
+
+// reload reads the file again if it was modified since last read. The caller must hold `mu`.
+//
+// If reading fails after a modification (ex. the file is being replaced), the password last read is kept:
+// reading will be attempted again next time.
+func (pf *passwordFile) reload() error {
+	info, err := os.Stat(pf.file)
+	if err == nil && !info.ModTime().Equal(pf.loadedModTime) {
+		var content []byte
+		if content, err = os.ReadFile(pf.file); err == nil {
+			password := strings.TrimSpace(string(content))
+			if password == "" {
+				err = fmt.Errorf("file is empty")
+			} else {
+				pf.changed = pf.changed || password != pf.loaded
+				pf.loaded, pf.loadedModTime = password, info.ModTime()
+			}
+		}
+	}
+	if err != nil && pf.loaded == "" {
+		return fmt.Errorf("failed to read password file '%s': %w", pf.file, err)
+	}
+
+	return nil
+}
+
+// attach records the connection authenticated with the password last returned.
+func (pf *passwordFile) attach(conn *zk.Conn) {
+	pf.mu.Lock()
+	defer pf.mu.Unlock()
+
+	pf.conn = conn
+}
+
+// reauthenticate is called on every zk.Event of the Client (see onEvent). If configured via WithPasswordFile,
+// once the session is re-established, it authenticates it again if the password changed.
+//
+// ZooKeeper has no way to drop the credentials a session was authenticated with: the previous password
+// keeps being sent on reconnection, granting the identity it was for (if any) along with the new one.
+func (c *Client) reauthenticate(event zk.Event) {
+	if c.passwordFile == nil || event.Type != zk.EventSession || event.State != zk.StateHasSession {
+		return
+	}
+
+	pf := c.passwordFile
+	pf.mu.Lock()
+	defer pf.mu.Unlock()
+
+	// Not connected yet: NewClient authenticates the session
+	if pf.conn == nil {
+		return
+	}
+	if err := pf.reload(); err != nil || !pf.changed {
+		return
+	}
+	pf.changed = false
+
+	// Events are delivered while the connection is being handled: authenticating must not block that
+	conn, credentials := pf.conn, []byte(c.username+":"+pf.loaded)
+	go func() {
+		if err := conn.AddAuth("digest", credentials); err != nil {
+			// Authenticate again on the next re-connection
+			pf.mu.Lock()
+			pf.changed = true
+			pf.mu.Unlock()
+		}
+	}()
+}
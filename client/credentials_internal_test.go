@@ -0,0 +1,59 @@
+package client
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	testifyAssert "github.com/stretchr/testify/assert"
+)
+
+func TestPasswordFileReloadedWhenChanged(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	file := filepath.Join(t.TempDir(), "password")
+	assert.NoError(os.WriteFile(file, []byte("first\n"), 0o600))
+
+	c := &Client{}
+	assert.NoError(WithPasswordFile(file)(c))
+	password, err := c.passwordFile.password()
+	assert.NoError(err)
+	assert.Equal("first", password)
+
+	// Rotating the password marks it as changed, until used
+	assert.NoError(os.WriteFile(file, []byte("second"), 0o600))
+	assert.NoError(os.Chtimes(file, time.Now(), time.Now().Add(time.Minute)))
+	assert.NoError(c.passwordFile.reload())
+	assert.True(c.passwordFile.changed)
+	password, err = c.passwordFile.password()
+	assert.NoError(err)
+	assert.Equal("second", password)
+	assert.False(c.passwordFile.changed)
+
+	// An invalid file, ex. while being rotated, is ignored until fixed
+	assert.NoError(os.WriteFile(file, nil, 0o600))
+	assert.NoError(os.Chtimes(file, time.Now(), time.Now().Add(2*time.Minute)))
+	password, err = c.passwordFile.password()
+	assert.NoError(err)
+	assert.Equal("second", password)
+}
+
+func TestWithPasswordFileValidatesFile(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	dir := t.TempDir()
+	assert.ErrorContains(WithPasswordFile(filepath.Join(dir, "missing"))(&Client{}), "failed to read password file")
+
+	empty := filepath.Join(dir, "empty")
+	assert.NoError(os.WriteFile(empty, []byte("\n"), 0o600))
+	assert.ErrorContains(WithPasswordFile(empty)(&Client{}), "file is empty")
+
+	// Connecting happens in the background: no ZooKeeper Server is necessary
+	file := filepath.Join(dir, "password")
+	assert.NoError(os.WriteFile(file, []byte("password"), 0o600))
+	_, err := NewClient("127.0.0.1:1", 1, "foo", "password", WithPasswordFile(file))
+	assert.ErrorContains(err, "cannot be specified together")
+	_, err = NewClient("127.0.0.1:1", 1, "", "", WithPasswordFile(file))
+	assert.ErrorContains(err, "must be specified together")
+}
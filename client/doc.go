@@ -0,0 +1,8 @@
+// Package client is the ZooKeeper client the provider is built on, wrapping go-zookeeper `zk.Conn`.
+//
+// It is public so that companion tooling (ex. scripts bootstrapping an Ensemble, or tests of other providers)
+// can reuse the same connection, authentication, retry and path handling logic: see NewClient, and the
+// ClientOption(s) to configure it with (ex. WithTLS, WithPasswordFile).
+//
+// The package follows the versioning of the provider: breaking changes to it are listed in the changelog.
+package client
@@ -0,0 +1,234 @@
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// ensembleConfigPath is the ZNode where ZooKeeper 3.5+ exposes the dynamic configuration of the Ensemble.
+	// See: https://zookeeper.apache.org/doc/current/zookeeperReconfig.html.
+	ensembleConfigPath = "/zookeeper/config"
+
+	ensembleConfigServerPrefix = "server."
+	ensembleConfigVersionKey   = "version"
+	ensembleConfigKeyValueSep  = "="
+	ensembleConfigClientSep    = ";"
+	ensembleConfigPortSep      = ":"
+
+	// EnsembleRoleParticipant is the role of Ensemble servers that take part in leader election and quorum.
+	EnsembleRoleParticipant = "participant"
+	// EnsembleRoleObserver is the role of Ensemble servers that don't take part in leader election and quorum.
+	EnsembleRoleObserver = "observer"
+
+	// ensembleConfigAnyVersion makes a reconfiguration apply to the current configuration, whatever its version.
+	ensembleConfigAnyVersion = -1
+)
+
+// EnsembleConfig represents the dynamic configuration of a ZooKeeper Ensemble.
+type EnsembleConfig struct {
+	// Version of the configuration, as hexadecimal string (ex. `100000000`).
+	Version string
+	Servers []EnsembleServer
+}
+
+// EnsembleServer represents one of the `server.<id>=...` entries of EnsembleConfig.
+type EnsembleServer struct {
+	ID            int
+	Address       string
+	QuorumPort    int
+	ElectionPort  int
+	Role          string
+	ClientAddress string
+	ClientPort    int
+}
+
+// String returns the server specification in the same format used by the Ensemble configuration,
+// i.e. `<address>:<quorum port>:<election port>:<role>;<client address>:<client port>`
+// (or `...;<client port>`, without a client address).
+func (s EnsembleServer) String() string {
+	spec := fmt.Sprintf("%s:%d:%d:%s", joinHostForConfig(s.Address), s.QuorumPort, s.ElectionPort, s.Role)
+	switch {
+	case s.ClientPort > 0 && s.ClientAddress == "":
+		// Without a client address, ZooKeeper listens for clients on all interfaces
+		spec += fmt.Sprintf("%s%d", ensembleConfigClientSep, s.ClientPort)
+	case s.ClientPort > 0:
+		spec += fmt.Sprintf("%s%s:%d", ensembleConfigClientSep, joinHostForConfig(s.ClientAddress), s.ClientPort)
+	}
+
+	return spec
+}
+
+// ReadEnsembleConfig reads and parses the dynamic configuration of the ZooKeeper Ensemble.
+//
+// Requires ZooKeeper 3.5+.
+func (c *Client) ReadEnsembleConfig() (*EnsembleConfig, error) {
+	defer c.telemetry.record("ReadEnsembleConfig", ensembleConfigPath, time.Now())
+
+	data, _, err := c.zkConn.Get(ensembleConfigPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Ensemble configuration from '%s': %w", ensembleConfigPath, err)
+	}
+
+	return ParseEnsembleConfig(data)
+}
+
+// AddEnsembleServer adds the given server to the Ensemble, or updates it if one with the same ID is already part of it,
+// via an incremental reconfiguration, returning the new configuration of the Ensemble.
+//
+// Requires ZooKeeper 3.5+ with `reconfigEnabled`, and the Client to be authorized to reconfigure the Ensemble
+// (ex. as super user). A joining server must be running, and configured to connect to the Ensemble.
+func (c *Client) AddEnsembleServer(server EnsembleServer) (*EnsembleConfig, error) {
+	defer c.telemetry.record("AddEnsembleServer", ensembleConfigPath, time.Now())
+
+	joining := fmt.Sprintf("%s%d%s%s", ensembleConfigServerPrefix, server.ID, ensembleConfigKeyValueSep, server)
+	return c.reconfigure([]string{joining}, nil)
+}
+
+// RemoveEnsembleServer removes the server with the given ID from the Ensemble, via an incremental reconfiguration,
+// returning the new configuration of the Ensemble. Like AddEnsembleServer, it requires ZooKeeper 3.5+ with `reconfigEnabled`.
+func (c *Client) RemoveEnsembleServer(id int) (*EnsembleConfig, error) {
+	defer c.telemetry.record("RemoveEnsembleServer", ensembleConfigPath, time.Now())
+
+	return c.reconfigure(nil, []string{strconv.Itoa(id)})
+}
+
+// reconfigure performs an incremental reconfiguration of the Ensemble, with the given joining servers
+// (as `server.<id>=<spec>`) and IDs of leaving servers, then reads the new configuration.
+//
+// Reading it from the same session sees the reconfiguration, even if the server is not the leader.
+func (c *Client) reconfigure(joining []string, leaving []string) (*EnsembleConfig, error) {
+	err := c.audited(AuditOperationReconfig, ensembleConfigPath, func() error {
+		_, err := c.zkConn.IncrementalReconfig(joining, leaving, ensembleConfigAnyVersion)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to reconfigure Ensemble: %w", err)
+	}
+
+	return c.ReadEnsembleConfig()
+}
+
+// ParseEnsembleConfig parses the dynamic configuration of a ZooKeeper Ensemble,
+// in the format stored in the `/zookeeper/config` ZNode.
+//
+// For example:
+//
+//	server.1=zk1:2888:3888:participant;0.0.0.0:2181
+//	server.2=zk2:2888:3888:observer;2181
+//	version=100000000
+func ParseEnsembleConfig(data []byte) (*EnsembleConfig, error) {
+	config := &EnsembleConfig{}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		key, value, found := strings.Cut(line, ensembleConfigKeyValueSep)
+		if !found {
+			return nil, fmt.Errorf("invalid Ensemble configuration line '%s'", line)
+		}
+
+		switch {
+		case key == ensembleConfigVersionKey:
+			config.Version = value
+		case strings.HasPrefix(key, ensembleConfigServerPrefix):
+			id, err := strconv.Atoi(strings.TrimPrefix(key, ensembleConfigServerPrefix))
+			if err != nil {
+				return nil, fmt.Errorf("invalid Ensemble configuration server ID in '%s': %w", line, err)
+			}
+
+			server, err := ParseEnsembleServer(id, value)
+			if err != nil {
+				return nil, err
+			}
+			config.Servers = append(config.Servers, *server)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read Ensemble configuration: %w", err)
+	}
+
+	return config, nil
+}
+
+// ParseEnsembleServer parses the specification of a server, as found in the dynamic configuration
+// of a ZooKeeper Ensemble: `<address>:<quorum port>:<election port>[:<role>][;[<client address>:]<client port>]`.
+func ParseEnsembleServer(id int, spec string) (*EnsembleServer, error) {
+	server := &EnsembleServer{ID: id, Role: EnsembleRoleParticipant}
+
+	serverSpec, clientSpec, hasClient := strings.Cut(spec, ensembleConfigClientSep)
+
+	host, ports, err := splitHostForConfig(serverSpec)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Ensemble server '%d' specification '%s': %w", id, spec, err)
+	}
+	server.Address = host
+
+	portParts := strings.Split(ports, ensembleConfigPortSep)
+	if len(portParts) < 2 || len(portParts) > 3 {
+		return nil, fmt.Errorf("invalid Ensemble server '%d' specification '%s': expected quorum and election ports", id, spec)
+	}
+	if server.QuorumPort, err = strconv.Atoi(portParts[0]); err != nil {
+		return nil, fmt.Errorf("invalid Ensemble server '%d' quorum port: %w", id, err)
+	}
+	if server.ElectionPort, err = strconv.Atoi(portParts[1]); err != nil {
+		return nil, fmt.Errorf("invalid Ensemble server '%d' election port: %w", id, err)
+	}
+	if len(portParts) == 3 {
+		server.Role = portParts[2]
+	}
+	if server.Role != EnsembleRoleParticipant && server.Role != EnsembleRoleObserver {
+		return nil, fmt.Errorf("invalid Ensemble server '%d' role '%s'", id, server.Role)
+	}
+
+	if hasClient {
+		clientHost, clientPort := "", clientSpec
+		if strings.Contains(clientSpec, ensembleConfigPortSep) {
+			if clientHost, clientPort, err = net.SplitHostPort(clientSpec); err != nil {
+				return nil, fmt.Errorf("invalid Ensemble server '%d' client address: %w", id, err)
+			}
+		}
+
+		server.ClientAddress = clientHost
+		if server.ClientPort, err = strconv.Atoi(clientPort); err != nil {
+			return nil, fmt.Errorf("invalid Ensemble server '%d' client port: %w", id, err)
+		}
+	}
+
+	return server, nil
+}
+
+// splitHostForConfig splits `<host>:<rest>`, where `host` can be an IPv6 address in square brackets.
+func splitHostForConfig(spec string) (string, string, error) {
+	if strings.HasPrefix(spec, "[") {
+		end := strings.Index(spec, "]")
+		if end < 0 || !strings.HasPrefix(spec[end+1:], ensembleConfigPortSep) {
+			return "", "", fmt.Errorf("invalid IPv6 address")
+		}
+		return spec[1:end], spec[end+2:], nil
+	}
+
+	host, rest, found := strings.Cut(spec, ensembleConfigPortSep)
+	if !found {
+		return "", "", fmt.Errorf("missing ports")
+	}
+	return host, rest, nil
+}
+
+// joinHostForConfig wraps IPv6 addresses in square brackets, as expected by the Ensemble configuration.
+func joinHostForConfig(host string) string {
+	if strings.Contains(host, ensembleConfigPortSep) {
+		return "[" + host + "]"
+	}
+	return host
+}
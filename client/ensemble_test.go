@@ -0,0 +1,43 @@
+package client_test
+
+import (
+	"testing"
+
+	testifyAssert "github.com/stretchr/testify/assert"
+	"github.com/tfzk/terraform-provider-zookeeper/client"
+)
+
+func TestParseEnsembleConfig(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	config, err := client.ParseEnsembleConfig([]byte(
+		"server.1=zk1:2888:3888:participant;0.0.0.0:2181\n" +
+			"server.2=zk2:2888:3888;2181\n" +
+			"server.3=[2001:db8::3]:2888:3888:observer;[2001:db8::3]:2181\n" +
+			"version=100000000\n",
+	))
+	assert.NoError(err)
+	assert.Equal("100000000", config.Version)
+	assert.Equal([]client.EnsembleServer{
+		{ID: 1, Address: "zk1", QuorumPort: 2888, ElectionPort: 3888, Role: "participant", ClientAddress: "0.0.0.0", ClientPort: 2181},
+		{ID: 2, Address: "zk2", QuorumPort: 2888, ElectionPort: 3888, Role: "participant", ClientAddress: "", ClientPort: 2181},
+		{ID: 3, Address: "2001:db8::3", QuorumPort: 2888, ElectionPort: 3888, Role: "observer", ClientAddress: "2001:db8::3", ClientPort: 2181},
+	}, config.Servers)
+
+	assert.Equal("zk1:2888:3888:participant;0.0.0.0:2181", config.Servers[0].String())
+	assert.Equal("zk2:2888:3888:participant;2181", config.Servers[1].String())
+	assert.Equal("[2001:db8::3]:2888:3888:observer;[2001:db8::3]:2181", config.Servers[2].String())
+}
+
+func TestFailureWhenParsingInvalidEnsembleConfig(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	_, err := client.ParseEnsembleConfig([]byte("server.1=zk1:2888"))
+	assert.EqualError(err, "invalid Ensemble server '1' specification 'zk1:2888': expected quorum and election ports")
+
+	_, err = client.ParseEnsembleConfig([]byte("server.1=zk1:2888:3888:leader"))
+	assert.EqualError(err, "invalid Ensemble server '1' role 'leader'")
+
+	_, err = client.ParseEnsembleConfig([]byte("not a config"))
+	assert.EqualError(err, "invalid Ensemble configuration line 'not a config'")
+}
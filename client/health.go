@@ -0,0 +1,220 @@
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-zookeeper/zk"
+)
+
+// Four Letter Words supported by ServerHealthCheck.
+// See: https://zookeeper.apache.org/doc/current/zookeeperAdmin.html#sc_4lw.
+const (
+	flwRuok = "ruok"
+	flwSrvr = "srvr"
+	flwMntr = "mntr"
+
+	flwRuokResponse = "imok"
+
+	// flwNotWhitelisted is contained in the response of ZooKeeper 3.5+ when the command
+	// is not listed in the `4lw.commands.whitelist` configuration.
+	flwNotWhitelisted = "is not executed because it is not in the whitelist"
+
+	srvrModeKey        = "Mode"
+	srvrVersionKey     = "Zookeeper version"
+	srvrLatencyKey     = "Latency min/avg/max"
+	srvrOutstandingKey = "Outstanding"
+	srvrConnectionsKey = "Connections"
+	srvrNodeCountKey   = "Node count"
+)
+
+// ServerHealth is the result of checking the health of a ZooKeeper Server,
+// via the `ruok`, `srvr` and `mntr` Four Letter Words.
+type ServerHealth struct {
+	// Server is the 'host:port' of the ZooKeeper Server.
+	Server string
+
+	// OK is true if the Server replied `imok` to `ruok` or, in case `ruok` is not
+	// whitelisted, if the Server replied to `srvr`.
+	OK bool
+
+	// Mode is the role of the Server (ex. `leader`, `follower`, `observer`, `standalone`), as reported by `srvr`.
+	Mode string
+
+	Version     string
+	MinLatency  int64
+	AvgLatency  float64
+	MaxLatency  int64
+	Outstanding int64
+	Connections int64
+	NodeCount   int64
+
+	// Metrics are the key/value pairs reported by `mntr`. Empty if `mntr` is not whitelisted.
+	Metrics map[string]string
+
+	// Error is the first error encountered while checking the Server.
+	Error error
+}
+
+// CheckServersHealth runs ServerHealthCheck against all the Server(s) the Client was configured with.
+//
+// Connections are established like the ones of the ZooKeeper session (ex. over TLS, see WithTLS).
+func (c *Client) CheckServersHealth(timeout time.Duration) []*ServerHealth {
+	return checkServersHealth(c.dial, c.servers, timeout)
+}
+
+// CheckServersHealthOf is like CheckServersHealth, but against the given 'host:port' Server(s)
+// instead of the ones the Client was configured with.
+func (c *Client) CheckServersHealthOf(servers []string, timeout time.Duration) []*ServerHealth {
+	return checkServersHealth(c.dial, servers, timeout)
+}
+
+// CheckServersHealth runs ServerHealthCheck against all the given 'host:port' Server(s), concurrently.
+func CheckServersHealth(servers []string, timeout time.Duration) []*ServerHealth {
+	return checkServersHealth(net.DialTimeout, servers, timeout)
+}
+
+func checkServersHealth(dial zk.Dialer, servers []string, timeout time.Duration) []*ServerHealth {
+	servers = zk.FormatServers(servers)
+	health := make([]*ServerHealth, len(servers))
+
+	forEachConcurrently(len(servers), func(i int) {
+		health[i] = serverHealthCheck(dial, servers[i], timeout)
+	})
+
+	return health
+}
+
+// ServerHealthCheck checks the health of a ZooKeeper Server, via Four Letter Words.
+//
+// Each command is allowed up to `timeout` to complete.
+func ServerHealthCheck(server string, timeout time.Duration) *ServerHealth {
+	return serverHealthCheck(net.DialTimeout, server, timeout)
+}
+
+func serverHealthCheck(dial zk.Dialer, server string, timeout time.Duration) *ServerHealth {
+	health := &ServerHealth{Server: server, Metrics: map[string]string{}}
+
+	srvr, srvrErr := fourLetterWord(dial, server, flwSrvr, timeout)
+	if srvrErr == nil {
+		srvrErr = parseSrvr(srvr, health)
+	}
+
+	ruok, ruokErr := fourLetterWord(dial, server, flwRuok, timeout)
+	switch {
+	case ruokErr != nil:
+		health.OK = false
+	case strings.Contains(string(ruok), flwNotWhitelisted):
+		health.OK = srvrErr == nil
+	default:
+		health.OK = strings.TrimSpace(string(ruok)) == flwRuokResponse
+		if !health.OK {
+			ruokErr = fmt.Errorf("unexpected '%s' response from '%s': %q", flwRuok, server, ruok)
+		}
+	}
+
+	if mntr, mntrErr := fourLetterWord(dial, server, flwMntr, timeout); mntrErr == nil && !strings.Contains(string(mntr), flwNotWhitelisted) {
+		health.Metrics = parseMntr(mntr)
+	}
+
+	for _, err := range []error{ruokErr, srvrErr} {
+		if err != nil {
+			health.Error = err
+			break
+		}
+	}
+
+	return health
+}
+
+// parseSrvr parses the `Key: value` lines returned by `srvr` into the given ServerHealth.
+func parseSrvr(srvr []byte, health *ServerHealth) error {
+	if strings.Contains(string(srvr), flwNotWhitelisted) {
+		return fmt.Errorf("'%s' is not whitelisted on '%s'", flwSrvr, health.Server)
+	}
+
+	values := parseSrvrValues(srvr)
+
+	mode, ok := values[srvrModeKey]
+	if !ok {
+		return fmt.Errorf("unable to parse '%s' response from '%s': missing '%s'", flwSrvr, health.Server, srvrModeKey)
+	}
+	health.Mode = mode
+
+	// Version is reported as `<version>-<commit>, built on <date>`
+	health.Version, _, _ = strings.Cut(values[srvrVersionKey], ",")
+
+	// Latency is reported as `<min>/<avg>/<max>`
+	if latency := strings.Split(values[srvrLatencyKey], "/"); len(latency) == 3 {
+		health.MinLatency, _ = strconv.ParseInt(latency[0], 10, 64)
+		health.AvgLatency, _ = strconv.ParseFloat(latency[1], 64)
+		health.MaxLatency, _ = strconv.ParseInt(latency[2], 10, 64)
+	}
+
+	health.Outstanding, _ = strconv.ParseInt(values[srvrOutstandingKey], 10, 64)
+	health.Connections, _ = strconv.ParseInt(values[srvrConnectionsKey], 10, 64)
+	health.NodeCount, _ = strconv.ParseInt(values[srvrNodeCountKey], 10, 64)
+
+	return nil
+}
+
+// parseSrvrValues parses the `Key: value` lines returned by `srvr`.
+func parseSrvrValues(srvr []byte) map[string]string {
+	values := map[string]string{}
+
+	scanner := bufio.NewScanner(bytes.NewReader(srvr))
+	for scanner.Scan() {
+		if key, value, found := strings.Cut(scanner.Text(), ":"); found {
+			values[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+	}
+
+	return values
+}
+
+// parseMntr parses the `key<TAB>value` lines returned by `mntr`.
+func parseMntr(mntr []byte) map[string]string {
+	metrics := map[string]string{}
+
+	scanner := bufio.NewScanner(bytes.NewReader(mntr))
+	for scanner.Scan() {
+		if key, value, found := strings.Cut(scanner.Text(), "\t"); found {
+			metrics[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+	}
+
+	return metrics
+}
+
+// fourLetterWord sends the given command to the ZooKeeper Server, connecting via `dial`, and returns its response.
+//
+// The ZooKeeper library offers helpers for some Four Letter Words, but not for `mntr`,
+// and its `srvr` parsing doesn't recognise `observer` Servers.
+func fourLetterWord(dial zk.Dialer, server, command string, timeout time.Duration) ([]byte, error) {
+	conn, err := dial("tcp", server, timeout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to '%s': %w", server, err)
+	}
+	defer conn.Close()
+
+	if err := conn.SetDeadline(time.Now().Add(timeout)); err != nil {
+		return nil, fmt.Errorf("failed to set deadline for '%s' on '%s': %w", command, server, err)
+	}
+
+	if _, err := conn.Write([]byte(command)); err != nil {
+		return nil, fmt.Errorf("failed to send '%s' to '%s': %w", command, server, err)
+	}
+
+	response, err := io.ReadAll(conn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read '%s' response from '%s': %w", command, server, err)
+	}
+
+	return response, nil
+}
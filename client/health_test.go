@@ -0,0 +1,133 @@
+package client_test
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	testifyAssert "github.com/stretchr/testify/assert"
+	"github.com/tfzk/terraform-provider-zookeeper/client"
+)
+
+// serveFourLetterWords starts a fake ZooKeeper Server, that replies to Four Letter Words
+// with the given responses, and returns its 'host:port'.
+func serveFourLetterWords(t *testing.T, responses map[string]string) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			command := make([]byte, 4)
+			if _, err := conn.Read(command); err == nil {
+				_, _ = conn.Write([]byte(responses[string(command)]))
+			}
+			_ = conn.Close()
+		}
+	}()
+
+	return listener.Addr().String()
+}
+
+func TestServerHealthCheck(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	server := serveFourLetterWords(t, map[string]string{
+		"ruok": "imok",
+		"srvr": "Zookeeper version: 3.8.4-9316c2a7a97e1666d8f4593f34dd6fc36ecc436c, built on 2024-02-12 22:16 UTC\n" +
+			"Latency min/avg/max: 1/2.5/10\n" +
+			"Received: 100\n" +
+			"Sent: 99\n" +
+			"Connections: 3\n" +
+			"Outstanding: 7\n" +
+			"Zxid: 0x100000002\n" +
+			"Mode: observer\n" +
+			"Node count: 42\n",
+		"mntr": "zk_version\t3.8.4\nzk_server_state\tobserver\n",
+	})
+
+	health := client.ServerHealthCheck(server, time.Second)
+	assert.NoError(health.Error)
+	assert.True(health.OK)
+	assert.Equal("observer", health.Mode)
+	assert.Equal("3.8.4-9316c2a7a97e1666d8f4593f34dd6fc36ecc436c", health.Version)
+	assert.Equal(int64(1), health.MinLatency)
+	assert.InEpsilon(2.5, health.AvgLatency, 0.001)
+	assert.Equal(int64(10), health.MaxLatency)
+	assert.Equal(int64(7), health.Outstanding)
+	assert.Equal(int64(3), health.Connections)
+	assert.Equal(int64(42), health.NodeCount)
+	assert.Equal(map[string]string{"zk_version": "3.8.4", "zk_server_state": "observer"}, health.Metrics)
+}
+
+func TestServerHealthCheckWhenOnlySrvrIsWhitelisted(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	server := serveFourLetterWords(t, map[string]string{
+		"ruok": "ruok is not executed because it is not in the whitelist.\n",
+		"srvr": "Zookeeper version: 3.5.10, built on 05/31/2022 13:01 GMT\nMode: standalone\n",
+		"mntr": "mntr is not executed because it is not in the whitelist.\n",
+	})
+
+	health := client.ServerHealthCheck(server, time.Second)
+	assert.NoError(health.Error)
+	assert.True(health.OK)
+	assert.Equal("standalone", health.Mode)
+	assert.Empty(health.Metrics)
+}
+
+func TestFailureWhenCheckingHealthOfUnreachableServer(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	// Grab a free port, then close the listener so nothing is listening on it
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := listener.Addr().String()
+	_ = listener.Close()
+
+	health := client.CheckServersHealth([]string{server}, time.Second)
+	assert.Len(health, 1)
+	assert.False(health[0].OK)
+	assert.Error(health[0].Error)
+	assert.Empty(health[0].Mode)
+}
+
+func TestGetServerVersion(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	server := serveFourLetterWords(t, map[string]string{
+		"srvr": "Zookeeper version: 3.8.4-9316c2a7a97e1666d8f4593f34dd6fc36ecc436c, built on 2024-02-12 22:16 UTC\nMode: leader\n",
+	})
+
+	version, err := client.GetServerVersion(server, time.Second)
+	assert.NoError(err)
+	assert.Equal(&client.ServerVersion{
+		Server:  server,
+		Version: "3.8.4",
+		Major:   3,
+		Minor:   8,
+		Patch:   4,
+		Commit:  "9316c2a7a97e1666d8f4593f34dd6fc36ecc436c",
+		BuiltOn: "2024-02-12 22:16 UTC",
+	}, version)
+}
+
+func TestFailureWhenGettingServerVersionWithoutSrvr(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	server := serveFourLetterWords(t, map[string]string{
+		"srvr": "srvr is not executed because it is not in the whitelist.\n",
+	})
+
+	_, err := client.GetServerVersion(server, time.Second)
+	assert.ErrorContains(err, "'srvr' is not whitelisted")
+}
@@ -0,0 +1,128 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrorNoLeader is returned by operations writing ZNodes, when the Client is configured to check
+// that the Ensemble has an elected leader before its first write (see WithLeaderCheck), and it has none.
+var ErrorNoLeader = errors.New("no leader elected")
+
+const (
+	// Modes reported by a Server able to accept writes: the leader of the Ensemble, or a Server running on its own.
+	srvrModeLeader     = "leader"
+	srvrModeStandalone = "standalone"
+
+	// mntrServerStateKey is the metric reported by `mntr` with the mode of the Server.
+	mntrServerStateKey = "zk_server_state"
+
+	// leaderCheckTimeout is how long asking each Server for its mode can take (see WithLeaderCheck).
+	leaderCheckTimeout = 5 * time.Second
+)
+
+// WithLeaderCheck makes the Client check that the Ensemble has an elected leader before its first write
+// (i.e. create, update, delete), failing the write with ErrorNoLeader otherwise: during leader elections,
+// writes fail fast with an explanation, instead of timing out.
+//
+// Each server is asked for its mode via `srvr`, or `mntr` if `srvr` is not whitelisted.
+// Once a leader is found, the check is not repeated.
+func WithLeaderCheck() ClientOption {
+	return func(c *Client) error {
+		c.leaderCheck = &leaderCheck{}
+		return nil
+	}
+}
+
+// leaderCheck is the check a Client makes before its first write (see WithLeaderCheck).
+type leaderCheck struct {
+	mu sync.Mutex
+	// passed is true once a leader was found
+	passed bool
+}
+
+// checkLeader checks that the Ensemble has an elected leader, if configured and not already found.
+//
+// Concurrent writes wait for the first one to check.
+func (c *Client) checkLeader() error {
+	lc := c.leaderCheck
+	if lc == nil {
+		return nil
+	}
+
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	if lc.passed {
+		return nil
+	}
+
+	defer c.telemetry.record("CheckLeader", "", time.Now())
+
+	flw := func(server, command string) ([]byte, error) {
+		return fourLetterWord(c.dial, server, command, leaderCheckTimeout)
+	}
+	if err := checkServersLeader(c.servers, flw); err != nil {
+		return err
+	}
+
+	lc.passed = true
+	return nil
+}
+
+// checkServersLeader asks the given servers for their mode, concurrently, via the given Four Letter Word function,
+// returning an error listing the mode of each one (or why it's unknown) if none is the leader.
+func checkServersLeader(servers []string, flw func(server, command string) ([]byte, error)) error {
+	modes := make([]string, len(servers))
+	errs := make([]error, len(servers))
+	forEachConcurrently(len(servers), func(i int) {
+		modes[i], errs[i] = serverMode(servers[i], flw)
+	})
+
+	reports := make([]string, len(servers))
+	for i, server := range servers {
+		if modes[i] == srvrModeLeader || modes[i] == srvrModeStandalone {
+			return nil
+		}
+
+		if errs[i] != nil {
+			reports[i] = fmt.Sprintf("'%s' (%v)", server, errs[i])
+		} else {
+			reports[i] = fmt.Sprintf("'%s' (%s)", server, modes[i])
+		}
+	}
+
+	return fmt.Errorf("%w: none of the servers is the leader, or reachable as one: %s", ErrorNoLeader, strings.Join(reports, ", "))
+}
+
+// serverMode returns the mode of the given server (ex. `leader`, `follower`), as reported by `srvr`,
+// or by `mntr` if `srvr` is not whitelisted.
+func serverMode(server string, flw func(server, command string) ([]byte, error)) (string, error) {
+	srvr, err := flw(server, flwSrvr)
+	if err != nil {
+		return "", err
+	}
+	if !strings.Contains(string(srvr), flwNotWhitelisted) {
+		if mode, ok := parseSrvrValues(srvr)[srvrModeKey]; ok {
+			return mode, nil
+		}
+		// ex. "This ZooKeeper instance is not currently serving requests", while electing a leader
+		return "", fmt.Errorf("not serving requests: %q", strings.TrimSpace(string(srvr)))
+	}
+
+	mntr, err := flw(server, flwMntr)
+	if err != nil {
+		return "", err
+	}
+	if strings.Contains(string(mntr), flwNotWhitelisted) {
+		return "", fmt.Errorf("neither '%s' nor '%s' is whitelisted", flwSrvr, flwMntr)
+	}
+	if mode, ok := parseMntr(mntr)[mntrServerStateKey]; ok {
+		return mode, nil
+	}
+
+	return "", fmt.Errorf("not serving requests: %q", strings.TrimSpace(string(mntr)))
+}
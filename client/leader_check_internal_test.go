@@ -0,0 +1,53 @@
+package client
+
+import (
+	"fmt"
+	"testing"
+
+	testifyAssert "github.com/stretchr/testify/assert"
+)
+
+func TestCheckServersLeader(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	responses := map[string]string{
+		"zk1:2181/srvr": "Zookeeper version: 3.9.2\nMode: follower\n",
+		"zk2:2181/srvr": "This ZooKeeper instance is not currently serving requests\n",
+		"zk3:2181/srvr": "srvr " + flwNotWhitelisted + ".\n",
+		"zk3:2181/mntr": "zk_version\t3.9.2\nzk_server_state\tleader\n",
+		"zk4:2181/srvr": "srvr " + flwNotWhitelisted + ".\n",
+		"zk4:2181/mntr": "mntr " + flwNotWhitelisted + ".\n",
+		"zk5:2181/srvr": "Zookeeper version: 3.9.2\nMode: standalone\n",
+	}
+	flw := func(server, command string) ([]byte, error) {
+		if response, ok := responses[server+"/"+command]; ok {
+			return []byte(response), nil
+		}
+		return nil, fmt.Errorf("failed to connect to '%s'", server)
+	}
+
+	assert.NoError(checkServersLeader([]string{"zk1:2181", "zk3:2181"}, flw))
+	assert.NoError(checkServersLeader([]string{"zk5:2181"}, flw))
+
+	err := checkServersLeader([]string{"zk1:2181", "zk2:2181", "zk4:2181", "zk6:2181"}, flw)
+	assert.ErrorIs(err, ErrorNoLeader)
+	assert.ErrorContains(err, "'zk1:2181' (follower)")
+	assert.ErrorContains(err, "'zk2:2181' (not serving requests")
+	assert.ErrorContains(err, "'zk4:2181' (neither 'srvr' nor 'mntr' is whitelisted)")
+	assert.ErrorContains(err, "'zk6:2181' (failed to connect to 'zk6:2181')")
+}
+
+func TestWithLeaderCheck(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	// Without a leader check, there is nothing to check
+	assert.NoError((&Client{}).checkLeader())
+
+	c := &Client{}
+	assert.NoError(WithLeaderCheck()(c))
+	assert.NotNil(c.leaderCheck)
+
+	// Once passed, the check is not repeated
+	c.leaderCheck.passed = true
+	assert.NoError(c.checkLeader())
+}
@@ -0,0 +1,119 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrorPathNotAllowed is returned by operations on ZNodes outside the boundaries
+	// the Client is configured with (see WithAllowedPathPrefixes).
+	ErrorPathNotAllowed = errors.New("path not allowed")
+
+	// ErrorPathProtected is returned by mutating operations on ZNodes
+	// the Client is configured to protect (see WithDeniedPathPrefixes).
+	ErrorPathProtected = errors.New("path protected")
+)
+
+// WithAllowedPathPrefixes restricts the Client to operate only on ZNodes at, or under, the given paths:
+// any other operation fails with ErrorPathNotAllowed, without reaching ZooKeeper.
+//
+// Prefixes match whole path segments: `/team-a` allows `/team-a` and `/team-a/config`, but not `/team-ab`.
+func WithAllowedPathPrefixes(prefixes []string) ClientOption {
+	return func(c *Client) error {
+		allowed, err := normalizePathPrefixes("allowed", prefixes)
+		c.paths.allowed = append(c.paths.allowed, allowed...)
+
+		return err
+	}
+}
+
+// WithDeniedPathPrefixes protects the ZNodes at, or under, the given paths: creating, updating or deleting them
+// fails with ErrorPathProtected, without reaching ZooKeeper. Reading them is still possible.
+//
+// Deleting a ZNode fails also if any of the given paths is under it, as that would delete a protected ZNode too.
+// Prefixes match whole path segments, like for WithAllowedPathPrefixes.
+func WithDeniedPathPrefixes(prefixes []string) ClientOption {
+	return func(c *Client) error {
+		denied, err := normalizePathPrefixes("denied", prefixes)
+		c.paths.denied = append(c.paths.denied, denied...)
+
+		return err
+	}
+}
+
+// normalizePathPrefixes validates the given path prefixes, and trims their trailing separator.
+func normalizePathPrefixes(kind string, prefixes []string) ([]string, error) {
+	normalized := make([]string, 0, len(prefixes))
+	for _, prefix := range prefixes {
+		if !strings.HasPrefix(prefix, zNodeRootPath) {
+			return nil, fmt.Errorf("%s path prefix '%s' must be absolute", kind, prefix)
+		}
+		// The root prefix (`/`) is trimmed to the empty string: it matches any absolute path
+		normalized = append(normalized, strings.TrimSuffix(prefix, zNodeRootPath))
+	}
+
+	return normalized, nil
+}
+
+// hasPathPrefix returns true if the given path is at, or under, the given normalized prefix.
+func hasPathPrefix(path string, prefix string) bool {
+	return path == prefix || strings.HasPrefix(path, prefix+zNodeRootPath)
+}
+
+// pathGuard enforces the boundaries of the ZNodes a Client can operate on, and protects the ones it must not modify.
+// The zero value allows every path.
+type pathGuard struct {
+	allowed []string
+	denied  []string
+}
+
+// check returns an error wrapping ErrorPathNotAllowed, if the ZNode at the given path is out of boundaries.
+func (pg *pathGuard) check(path string) error {
+	if pg.allowed == nil {
+		return nil
+	}
+
+	for _, prefix := range pg.allowed {
+		if hasPathPrefix(path, prefix) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("ZNode '%s' is outside of the allowed path prefixes '%s': %w",
+		path, strings.Join(pg.allowed, "', '"), ErrorPathNotAllowed)
+}
+
+// checkWrite returns an error wrapping ErrorPathNotAllowed or ErrorPathProtected,
+// if the ZNode at the given path can't be modified. If `subtree`, its descendants are checked too.
+func (pg *pathGuard) checkWrite(path string, subtree bool) error {
+	if err := pg.check(path); err != nil {
+		return err
+	}
+
+	for _, prefix := range pg.denied {
+		if hasPathPrefix(path, prefix) {
+			return fmt.Errorf("ZNode '%s' is protected by the denied path prefix '%s': %w", path, prefix, ErrorPathProtected)
+		}
+		if subtree && hasPathPrefix(prefix, strings.TrimSuffix(path, zNodeRootPath)) {
+			return fmt.Errorf("ZNode '%s' has a descendant protected by the denied path prefix '%s': %w", path, prefix, ErrorPathProtected)
+		}
+	}
+
+	return nil
+}
+
+// CheckPath returns an error wrapping ErrorPathNotAllowed, if the Client is not allowed
+// to operate on the ZNode at the given path (see WithAllowedPathPrefixes).
+//
+// Every operation already does this check: this is to fail early (ex. when planning).
+func (c *Client) CheckPath(path string) error {
+	return c.paths.check(path)
+}
+
+// CheckWritePath is like CheckPath, but for creating or updating the ZNode at the given path:
+// it also returns an error wrapping ErrorPathProtected, if the ZNode is protected (see WithDeniedPathPrefixes).
+func (c *Client) CheckWritePath(path string) error {
+	return c.paths.checkWrite(path, false)
+}
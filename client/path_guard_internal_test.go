@@ -0,0 +1,67 @@
+package client
+
+import (
+	"testing"
+
+	testifyAssert "github.com/stretchr/testify/assert"
+)
+
+func TestPathGuard(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	c := &Client{}
+	assert.NoError(c.CheckPath("/anything"))
+
+	assert.Error(WithAllowedPathPrefixes([]string{"team-a"})(c))
+
+	c = &Client{}
+	assert.NoError(WithAllowedPathPrefixes([]string{"/team-a", "/shared/config/"})(c))
+	for _, path := range []string{"/team-a", "/team-a/", "/team-a/config", "/shared/config", "/shared/config/x"} {
+		assert.NoError(c.CheckPath(path), path)
+	}
+	for _, path := range []string{"/", "/team-ab", "/team-b/config", "/shared", "/shared/configs"} {
+		assert.ErrorIs(c.CheckPath(path), ErrorPathNotAllowed, path)
+	}
+
+	_, err := c.Read("/team-b")
+	assert.ErrorIs(err, ErrorPathNotAllowed)
+	assert.ErrorIs(c.Delete("/team-b"), ErrorPathNotAllowed)
+
+	c = &Client{}
+	assert.NoError(WithAllowedPathPrefixes([]string{"/"})(c))
+	assert.NoError(c.CheckPath("/team-b/config"))
+}
+
+func TestPathGuardDenied(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	c := &Client{}
+	assert.NoError(WithAllowedPathPrefixes([]string{"/team-a"})(c))
+	assert.NoError(WithDeniedPathPrefixes([]string{"/zookeeper", "/team-a/locked"})(c))
+
+	// Reading protected ZNodes is still possible
+	assert.NoError(c.CheckPath("/team-a/locked/x"))
+	for _, path := range []string{"/team-a/locked", "/team-a/locked/x"} {
+		assert.ErrorIs(c.CheckWritePath(path), ErrorPathProtected, path)
+	}
+	assert.ErrorIs(c.CheckWritePath("/zookeeper/quota"), ErrorPathNotAllowed)
+	assert.NoError(c.CheckWritePath("/team-a/lockedx"))
+	assert.NoError(c.CheckWritePath("/team-a"))
+
+	// Deleting an ancestor would delete the protected ZNodes too
+	assert.NoError(c.paths.checkWrite("/team-a/lockedx", true))
+	assert.ErrorIs(c.paths.checkWrite("/team-a", true), ErrorPathProtected)
+	assert.ErrorIs(c.Delete("/team-a"), ErrorPathProtected)
+
+	_, err := c.Create("/team-a/locked/x", nil, nil)
+	assert.ErrorIs(err, ErrorPathProtected)
+	_, err = c.CreateSequential("/team-a/locked/", nil, nil)
+	assert.ErrorIs(err, ErrorPathProtected)
+	_, err = c.Update("/team-a/locked", nil, nil)
+	assert.ErrorIs(err, ErrorPathProtected)
+
+	c = &Client{}
+	assert.NoError(WithDeniedPathPrefixes([]string{"/zookeeper"})(c))
+	assert.ErrorIs(c.Delete("/"), ErrorPathProtected)
+	assert.Error(WithDeniedPathPrefixes([]string{"zookeeper"})(c))
+}
@@ -0,0 +1,135 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-zookeeper/zk"
+)
+
+const (
+	// quotaRootPath is the ZNode under which ZooKeeper tracks quotas: the ones of `/<path>`
+	// are in `/zookeeper/quota/<path>`. See: https://zookeeper.apache.org/doc/current/zookeeperQuotas.html.
+	quotaRootPath = "/zookeeper/quota"
+
+	quotaLimitsNodeName = "zookeeper_limits"
+	quotaStatsNodeName  = "zookeeper_stats"
+
+	quotaCountKey          = "count"
+	quotaBytesKey          = "bytes"
+	quotaCountHardLimitKey = "countHardLimit"
+	quotaBytesHardLimitKey = "byteHardLimit"
+
+	// QuotaUnlimited is the value of the quota limits that are not set.
+	QuotaUnlimited = -1
+)
+
+// ErrorNoQuota is returned by ReadQuota, when no quota is set on the ZNode.
+var ErrorNoQuota = errors.New("no quota set")
+
+// Quota represents the quota set on a ZNode, limiting its subtree, and the current usage of it.
+type Quota struct {
+	// Path of the ZNode the quota is set on.
+	Path string
+	// Count is the number of ZNodes in the subtree, including its root.
+	Count int64
+	// Bytes is the total size of the content of the ZNodes in the subtree.
+	Bytes int64
+	// CountLimit and BytesLimit are soft limits: ZooKeeper only logs a warning when they are exceeded.
+	CountLimit int64
+	BytesLimit int64
+	// CountHardLimit and BytesHardLimit are hard limits: ZooKeeper 3.7+ rejects changes exceeding them,
+	// if `enforceQuota` is enabled.
+	CountHardLimit int64
+	BytesHardLimit int64
+}
+
+// QuotaPath returns the path of the ZNode where ZooKeeper tracks the quota of the ZNode at the given path.
+func QuotaPath(path string) string {
+	return JoinPaths(quotaRootPath, path)
+}
+
+// ReadQuota reads the quota set on the ZNode at the given path, and the current usage of it,
+// failing with ErrorNoQuota if it has none.
+func (c *Client) ReadQuota(path string) (*Quota, error) {
+	defer c.telemetry.record("ReadQuota", path, time.Now())
+
+	if err := c.paths.check(path); err != nil {
+		return nil, err
+	}
+
+	quotaPath := QuotaPath(path)
+	limits, _, err := c.zkConn.Get(JoinPath(quotaPath, quotaLimitsNodeName))
+	if errors.Is(err, zk.ErrNoNode) {
+		return nil, fmt.Errorf("failed to read quota of ZNode '%s': %w", path, ErrorNoQuota)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read quota limits of ZNode '%s': %w", path, err)
+	}
+
+	stats, _, err := c.zkConn.Get(JoinPath(quotaPath, quotaStatsNodeName))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read quota usage of ZNode '%s': %w", path, err)
+	}
+
+	return ParseQuota(path, limits, stats)
+}
+
+// ParseQuota parses the quota of the ZNode at the given path, from the content of its `zookeeper_limits`
+// and `zookeeper_stats` ZNodes. Limits not set are QuotaUnlimited.
+//
+// For example, as stored by ZooKeeper 3.7+:
+//
+//	count=-1,bytes=-1=;byteHardLimit=-1;countHardLimit=10
+func ParseQuota(path string, limits []byte, stats []byte) (*Quota, error) {
+	limitValues, err := parseQuotaValues(limits)
+	if err != nil {
+		return nil, fmt.Errorf("invalid quota limits of ZNode '%s': %w", path, err)
+	}
+	statValues, err := parseQuotaValues(stats)
+	if err != nil {
+		return nil, fmt.Errorf("invalid quota usage of ZNode '%s': %w", path, err)
+	}
+
+	valueOrUnlimited := func(values map[string]int64, key string) int64 {
+		if value, ok := values[key]; ok {
+			return value
+		}
+		return QuotaUnlimited
+	}
+
+	return &Quota{
+		Path:           path,
+		Count:          statValues[quotaCountKey],
+		Bytes:          statValues[quotaBytesKey],
+		CountLimit:     valueOrUnlimited(limitValues, quotaCountKey),
+		BytesLimit:     valueOrUnlimited(limitValues, quotaBytesKey),
+		CountHardLimit: valueOrUnlimited(limitValues, quotaCountHardLimitKey),
+		BytesHardLimit: valueOrUnlimited(limitValues, quotaBytesHardLimitKey),
+	}, nil
+}
+
+// parseQuotaValues parses the `key=value` pairs of the quota limits or usage, separated by `,` or `;`.
+//
+// Like ZooKeeper does, anything after a second `=` in a pair is ignored: ZooKeeper 3.7+ writes `bytes=-1=`
+// when hard limits are set.
+func parseQuotaValues(data []byte) (map[string]int64, error) {
+	values := map[string]int64{}
+	for _, pair := range strings.FieldsFunc(string(data), func(r rune) bool { return r == ',' || r == ';' }) {
+		keyValue := strings.Split(strings.TrimSpace(pair), "=")
+		if len(keyValue) < 2 {
+			return nil, fmt.Errorf("expected 'key=value', got '%s'", pair)
+		}
+
+		value, err := strconv.ParseInt(keyValue[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value of '%s': %w", keyValue[0], err)
+		}
+		values[keyValue[0]] = value
+	}
+
+	return values, nil
+}
@@ -0,0 +1,49 @@
+package client_test
+
+import (
+	"testing"
+
+	testifyAssert "github.com/stretchr/testify/assert"
+	"github.com/tfzk/terraform-provider-zookeeper/client"
+)
+
+func TestParseQuota(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	// As stored by ZooKeeper 3.4+, with soft limits only
+	quota, err := client.ParseQuota("/forza", []byte("count=10,bytes=-1"), []byte("count=3,bytes=42"))
+	assert.NoError(err)
+	assert.Equal(&client.Quota{
+		Path:           "/forza",
+		Count:          3,
+		Bytes:          42,
+		CountLimit:     10,
+		BytesLimit:     client.QuotaUnlimited,
+		CountHardLimit: client.QuotaUnlimited,
+		BytesHardLimit: client.QuotaUnlimited,
+	}, quota)
+
+	// As stored by ZooKeeper 3.7+, with hard limits
+	quota, err = client.ParseQuota("/forza", []byte("count=-1,bytes=-1=;byteHardLimit=1024;countHardLimit=10"), []byte("count=3,bytes=42"))
+	assert.NoError(err)
+	assert.Equal(int64(client.QuotaUnlimited), quota.CountLimit)
+	assert.Equal(int64(client.QuotaUnlimited), quota.BytesLimit)
+	assert.Equal(int64(10), quota.CountHardLimit)
+	assert.Equal(int64(1024), quota.BytesHardLimit)
+}
+
+func TestFailureWhenParsingInvalidQuota(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	_, err := client.ParseQuota("/forza", []byte("count"), []byte("count=3,bytes=42"))
+	assert.EqualError(err, "invalid quota limits of ZNode '/forza': expected 'key=value', got 'count'")
+
+	_, err = client.ParseQuota("/forza", []byte("count=10"), []byte("count=napoli"))
+	assert.ErrorContains(err, "invalid quota usage of ZNode '/forza': invalid value of 'count'")
+}
+
+func TestQuotaPath(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	assert.Equal("/zookeeper/quota/forza/napoli", client.QuotaPath("/forza/napoli"))
+}
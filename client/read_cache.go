@@ -0,0 +1,115 @@
+package client
+
+import (
+	"strings"
+	"sync"
+)
+
+// readCache deduplicates reads of the same ZNode, for the lifetime of a Client
+// (i.e. a single Terraform plan or apply), when many Data Sources and Resources
+// reference the same path.
+//
+// A cached ZNode is returned only after confirming, via its zk.Stat, that it's unchanged:
+// this replaces reading data and ACL with a single, lighter, request. Concurrent reads
+// of the same path are also collapsed into one.
+type readCache struct {
+	mu       sync.Mutex
+	znodes   map[string]*ZNode
+	inFlight map[string]*inFlightRead
+}
+
+// inFlightRead is a read in progress, whose result is shared by all concurrent readers of the same path.
+type inFlightRead struct {
+	done  chan struct{}
+	znode *ZNode
+	err   error
+}
+
+func newReadCache() *readCache {
+	return &readCache{
+		znodes:   map[string]*ZNode{},
+		inFlight: map[string]*inFlightRead{},
+	}
+}
+
+// read returns the ZNode at the given path, as returned by readFn.
+//
+// readFn is given the ZNode read last time, if any, and is not invoked
+// if a read of the same path is already in progress: its result is returned instead.
+func (rc *readCache) read(path string, readFn func(cached *ZNode) (*ZNode, error)) (*ZNode, error) {
+	if rc == nil {
+		return readFn(nil)
+	}
+
+	rc.mu.Lock()
+	if call, ok := rc.inFlight[path]; ok {
+		rc.mu.Unlock()
+		<-call.done
+		return call.znode, call.err
+	}
+	call := &inFlightRead{done: make(chan struct{})}
+	rc.inFlight[path] = call
+	cached := rc.znodes[path]
+	rc.mu.Unlock()
+
+	call.znode, call.err = readFn(cached)
+
+	rc.mu.Lock()
+	// Unless invalidated while in progress, as the result might predate a write
+	if rc.inFlight[path] == call {
+		delete(rc.inFlight, path)
+		if call.err == nil {
+			rc.znodes[path] = call.znode
+		} else {
+			delete(rc.znodes, path)
+		}
+	}
+	rc.mu.Unlock()
+	close(call.done)
+
+	return call.znode, call.err
+}
+
+// store remembers the given ZNode, just written, in place of the one read last time (if any).
+//
+// Like invalidate, reads in progress are not shared with readers arriving after this.
+func (rc *readCache) store(znode *ZNode) {
+	if rc == nil {
+		return
+	}
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	rc.znodes[znode.Path] = znode
+	delete(rc.inFlight, znode.Path)
+}
+
+// invalidate forgets the ZNode at the given path and, if `recursive`, all its descendants.
+//
+// Reads in progress are not shared with readers arriving after this.
+func (rc *readCache) invalidate(path string, recursive bool) {
+	if rc == nil {
+		return
+	}
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	delete(rc.znodes, path)
+	delete(rc.inFlight, path)
+
+	if recursive {
+		prefix := strings.TrimSuffix(path, string(zNodePathSeparator)) + string(zNodePathSeparator)
+		for cachedPath := range rc.znodes {
+			if strings.HasPrefix(cachedPath, prefix) {
+				delete(rc.znodes, cachedPath)
+			}
+		}
+		for inFlightPath := range rc.inFlight {
+			if strings.HasPrefix(inFlightPath, prefix) {
+				delete(rc.inFlight, inFlightPath)
+			}
+		}
+	}
+}
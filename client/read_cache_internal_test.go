@@ -0,0 +1,132 @@
+package client
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	testifyAssert "github.com/stretchr/testify/assert"
+)
+
+func TestReadCacheCollapsesConcurrentReads(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	rc := newReadCache()
+	release := make(chan struct{})
+	var reads atomic.Int32
+
+	const readers = 8
+	var wg sync.WaitGroup
+	read := func() {
+		defer wg.Done()
+		znode, err := rc.read("/a", func(_ *ZNode) (*ZNode, error) {
+			reads.Add(1)
+			<-release
+			return &ZNode{Path: "/a"}, nil
+		})
+		assert.NoError(err)
+		assert.Equal("/a", znode.Path)
+	}
+
+	// Start the first read, and wait for it to be in progress
+	wg.Add(1)
+	go read()
+	for inFlight := false; !inFlight; {
+		rc.mu.Lock()
+		_, inFlight = rc.inFlight["/a"]
+		rc.mu.Unlock()
+	}
+
+	// All the other readers share the result of the first
+	for i := 1; i < readers; i++ {
+		wg.Add(1)
+		go read()
+	}
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(int32(1), reads.Load())
+}
+
+func TestReadCachePassesPreviousRead(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	rc := newReadCache()
+	first := &ZNode{Path: "/a"}
+
+	_, err := rc.read("/a", func(cached *ZNode) (*ZNode, error) {
+		assert.Nil(cached)
+		return first, nil
+	})
+	assert.NoError(err)
+
+	_, err = rc.read("/a", func(cached *ZNode) (*ZNode, error) {
+		assert.Same(first, cached)
+		return cached, nil
+	})
+	assert.NoError(err)
+}
+
+func TestReadCacheForgetsFailedReads(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	rc := newReadCache()
+	_, _ = rc.read("/a", func(_ *ZNode) (*ZNode, error) { return &ZNode{Path: "/a"}, nil })
+	_, err := rc.read("/a", func(_ *ZNode) (*ZNode, error) { return nil, ErrorZNodeDoesNotExist })
+	assert.ErrorIs(err, ErrorZNodeDoesNotExist)
+
+	_, _ = rc.read("/a", func(cached *ZNode) (*ZNode, error) {
+		assert.Nil(cached)
+		return nil, errors.New("unused")
+	})
+}
+
+func TestReadCacheInvalidate(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	rc := newReadCache()
+	for _, path := range []string{"/a", "/a/b", "/a/b/c", "/ab"} {
+		_, _ = rc.read(path, func(_ *ZNode) (*ZNode, error) { return &ZNode{Path: path}, nil })
+	}
+
+	rc.invalidate("/a/b", false)
+	assert.NotContains(rc.znodes, "/a/b")
+	assert.Contains(rc.znodes, "/a/b/c")
+
+	rc.invalidate("/a", true)
+	assert.NotContains(rc.znodes, "/a")
+	assert.NotContains(rc.znodes, "/a/b/c")
+	assert.Contains(rc.znodes, "/ab")
+}
+
+func TestReadCacheStore(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	rc := newReadCache()
+	_, _ = rc.read("/a", func(_ *ZNode) (*ZNode, error) { return &ZNode{Path: "/a", Data: []byte("one")}, nil })
+
+	rc.store(&ZNode{Path: "/a", Data: []byte("two")})
+	_, err := rc.read("/a", func(cached *ZNode) (*ZNode, error) {
+		assert.Equal([]byte("two"), cached.Data)
+		return cached, nil
+	})
+	assert.NoError(err)
+}
+
+func TestNilReadCacheDoesNotCache(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	var rc *readCache
+	for i := 0; i < 2; i++ {
+		_, err := rc.read("/a", func(cached *ZNode) (*ZNode, error) {
+			assert.Nil(cached)
+			return &ZNode{Path: "/a"}, nil
+		})
+		assert.NoError(err)
+	}
+	rc.invalidate("/a", true)
+	rc.store(&ZNode{Path: "/a"})
+}
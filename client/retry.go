@@ -0,0 +1,157 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-zookeeper/zk"
+)
+
+// ErrorClass groups together errors that should be handled the same way,
+// for example when deciding whether to retry an operation.
+type ErrorClass string
+
+const (
+	// ErrorClassConnectionLoss are errors caused by a connectivity issue with the ZooKeeper Ensemble
+	// (see IsTransientError).
+	ErrorClassConnectionLoss ErrorClass = "connection_loss"
+
+	// ErrorClassNoNode are errors caused by a ZNode not existing.
+	ErrorClassNoNode ErrorClass = "no_node"
+
+	// ErrorClassNodeExists are errors caused by a ZNode already existing.
+	ErrorClassNodeExists ErrorClass = "node_exists"
+
+	// ErrorClassBadVersion are errors caused by a ZNode being concurrently modified.
+	ErrorClassBadVersion ErrorClass = "bad_version"
+
+	// ErrorClassNotEmpty are errors caused by a ZNode having children.
+	ErrorClassNotEmpty ErrorClass = "not_empty"
+
+	// ErrorClassNotAuthorized are errors caused by ACL not authorizing an operation.
+	ErrorClassNotAuthorized ErrorClass = "not_authorized"
+)
+
+// ErrorClasses returns all the ErrorClass(es) an error can be classified as by ClassifyError.
+func ErrorClasses() []ErrorClass {
+	return []ErrorClass{
+		ErrorClassConnectionLoss,
+		ErrorClassNoNode,
+		ErrorClassNodeExists,
+		ErrorClassBadVersion,
+		ErrorClassNotEmpty,
+		ErrorClassNotAuthorized,
+	}
+}
+
+// ClassifyError returns the ErrorClass of the given error, and `false` if it doesn't belong to any.
+func ClassifyError(err error) (ErrorClass, bool) {
+	switch {
+	case IsTransientError(err):
+		return ErrorClassConnectionLoss, true
+	case errors.Is(err, zk.ErrNoNode):
+		return ErrorClassNoNode, true
+	case errors.Is(err, zk.ErrNodeExists):
+		return ErrorClassNodeExists, true
+	case errors.Is(err, zk.ErrBadVersion):
+		return ErrorClassBadVersion, true
+	case errors.Is(err, zk.ErrNotEmpty):
+		return ErrorClassNotEmpty, true
+	case errors.Is(err, zk.ErrNoAuth):
+		return ErrorClassNotAuthorized, true
+	}
+
+	return "", false
+}
+
+// RetryPolicy describes how many times, and how often, an operation
+// that failed should be retried, depending on the ErrorClass of the error.
+//
+// The zero value means "no retries".
+type RetryPolicy struct {
+	// Retries is the maximum number of additional attempts, after the first one,
+	// for errors of ErrorClassConnectionLoss.
+	Retries int
+
+	// Interval is the time to wait between attempts.
+	Interval time.Duration
+
+	// ClassRetries is the maximum number of additional attempts, after the first one,
+	// for errors of the given ErrorClass(es). It overrides Retries for ErrorClassConnectionLoss.
+	//
+	// Errors of classes not listed here (other than ErrorClassConnectionLoss) are never retried.
+	ClassRetries map[ErrorClass]int
+
+	// onRetry, if set, is invoked before each retry (see Client.Retry).
+	onRetry func()
+}
+
+// IsTransientError returns true if the given error is caused by a connectivity
+// issue with the ZooKeeper Ensemble (ex. connection loss, session expiration),
+// and so the operation that caused it is worth retrying.
+func IsTransientError(err error) bool {
+	return errors.Is(err, zk.ErrConnectionClosed) ||
+		errors.Is(err, zk.ErrNoServer) ||
+		errors.Is(err, zk.ErrSessionExpired) ||
+		errors.Is(err, zk.ErrSessionMoved) ||
+		errors.Is(err, zk.ErrClosing)
+}
+
+// retriesFor returns the maximum number of retries for the given error.
+func (p RetryPolicy) retriesFor(err error) (ErrorClass, int) {
+	class, ok := ClassifyError(err)
+	if !ok {
+		return "", 0
+	}
+
+	if retries, ok := p.ClassRetries[class]; ok {
+		return class, retries
+	}
+
+	if class == ErrorClassConnectionLoss {
+		return class, p.Retries
+	}
+
+	return class, 0
+}
+
+// Do invokes the given operation, retrying it according to the RetryPolicy
+// for as long as it fails with an error of a retried ErrorClass.
+//
+// Each ErrorClass has its own budget of retries: for example, an operation can be
+// retried both for connection loss, and for the ZNode not existing yet.
+// Errors of any other class are returned immediately.
+func (p RetryPolicy) Do(ctx context.Context, operation func() error) error {
+	err := operation()
+
+	attempts := map[ErrorClass]int{}
+	for err != nil {
+		class, retries := p.retriesFor(err)
+		if attempts[class] >= retries {
+			break
+		}
+		attempts[class]++
+		if p.onRetry != nil {
+			p.onRetry()
+		}
+
+		select {
+		case <-time.After(p.Interval):
+		case <-ctx.Done():
+			return fmt.Errorf("interrupted while retrying on '%s' (attempt %d of %d): %w", class, attempts[class], retries, errors.Join(err, ctx.Err()))
+		}
+
+		err = operation()
+	}
+
+	return err
+}
+
+// Retry is like RetryPolicy.Do, but also counts the retries in the Telemetry of the Client.
+func (c *Client) Retry(ctx context.Context, policy RetryPolicy, operation func() error) error {
+	policy.onRetry = c.telemetry.recordRetry
+
+	return policy.Do(ctx, operation)
+}
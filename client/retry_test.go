@@ -0,0 +1,143 @@
+package client_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-zookeeper/zk"
+	testifyAssert "github.com/stretchr/testify/assert"
+	"github.com/tfzk/terraform-provider-zookeeper/client"
+)
+
+func TestRetryPolicyRetriesTransientErrors(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	attempts := 0
+	err := client.RetryPolicy{Retries: 3, Interval: time.Millisecond}.Do(context.Background(), func() error {
+		attempts++
+		if attempts < 3 {
+			return fmt.Errorf("failed to read ZNode '/test': %w", zk.ErrConnectionClosed)
+		}
+		return nil
+	})
+	assert.NoError(err)
+	assert.Equal(3, attempts)
+}
+
+func TestRetryPolicyGivesUpAfterRetries(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	attempts := 0
+	err := client.RetryPolicy{Retries: 2, Interval: time.Millisecond}.Do(context.Background(), func() error {
+		attempts++
+		return zk.ErrNoServer
+	})
+	assert.ErrorIs(err, zk.ErrNoServer)
+	assert.Equal(3, attempts)
+}
+
+func TestRetryPolicyDoesNotRetryOtherErrors(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	attempts := 0
+	err := client.RetryPolicy{Retries: 5, Interval: time.Millisecond}.Do(context.Background(), func() error {
+		attempts++
+		return zk.ErrNoNode
+	})
+	assert.ErrorIs(err, zk.ErrNoNode)
+	assert.Equal(1, attempts)
+}
+
+func TestRetryPolicyZeroValueNeverRetries(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	attempts := 0
+	err := client.RetryPolicy{}.Do(context.Background(), func() error {
+		attempts++
+		return zk.ErrConnectionClosed
+	})
+	assert.ErrorIs(err, zk.ErrConnectionClosed)
+	assert.Equal(1, attempts)
+}
+
+func TestRetryPolicyRetriesConfiguredErrorClasses(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	attempts := 0
+	policy := client.RetryPolicy{
+		Retries:      1,
+		Interval:     time.Millisecond,
+		ClassRetries: map[client.ErrorClass]int{client.ErrorClassNoNode: 2},
+	}
+	err := policy.Do(context.Background(), func() error {
+		attempts++
+		switch attempts {
+		case 1, 2:
+			return fmt.Errorf("failed to read ZNode '/test': %w", zk.ErrNoNode)
+		case 3:
+			return zk.ErrConnectionClosed
+		default:
+			return nil
+		}
+	})
+
+	// Each error class has its own budget of retries
+	assert.NoError(err)
+	assert.Equal(4, attempts)
+}
+
+func TestRetryPolicyClassRetriesOverrideRetries(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	attempts := 0
+	policy := client.RetryPolicy{
+		Retries:      5,
+		Interval:     time.Millisecond,
+		ClassRetries: map[client.ErrorClass]int{client.ErrorClassConnectionLoss: 1},
+	}
+	err := policy.Do(context.Background(), func() error {
+		attempts++
+		return zk.ErrSessionExpired
+	})
+	assert.ErrorIs(err, zk.ErrSessionExpired)
+	assert.Equal(2, attempts)
+}
+
+func TestRetryPolicyDoesNotRetryUnlistedErrorClasses(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	attempts := 0
+	policy := client.RetryPolicy{
+		Retries:      5,
+		Interval:     time.Millisecond,
+		ClassRetries: map[client.ErrorClass]int{client.ErrorClassNodeExists: 5},
+	}
+	err := policy.Do(context.Background(), func() error {
+		attempts++
+		return zk.ErrNoAuth
+	})
+	assert.ErrorIs(err, zk.ErrNoAuth)
+	assert.Equal(1, attempts)
+}
+
+func TestClassifyError(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	for err, expected := range map[error]client.ErrorClass{
+		zk.ErrClosing:    client.ErrorClassConnectionLoss,
+		zk.ErrNoNode:     client.ErrorClassNoNode,
+		zk.ErrNodeExists: client.ErrorClassNodeExists,
+		zk.ErrBadVersion: client.ErrorClassBadVersion,
+		zk.ErrNotEmpty:   client.ErrorClassNotEmpty,
+		zk.ErrNoAuth:     client.ErrorClassNotAuthorized,
+	} {
+		class, ok := client.ClassifyError(fmt.Errorf("wrapped: %w", err))
+		assert.True(ok)
+		assert.Equal(expected, class)
+	}
+
+	_, ok := client.ClassifyError(fmt.Errorf("something else"))
+	assert.False(ok)
+}
@@ -0,0 +1,153 @@
+package client
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ServerPreference is which ZooKeeper Servers a Client connects to first (see WithServerPreference).
+type ServerPreference string
+
+const (
+	// PreferParticipants connects to participants (i.e. leader and followers) first:
+	// observers forward writes to the leader, adding latency to write-heavy applies.
+	PreferParticipants ServerPreference = "participants"
+	// PreferObservers connects to observers first: ex. the ones in the local data center, to read without crossing it.
+	PreferObservers ServerPreference = "observers"
+
+	// srvrModeObserver is the `Mode` reported by `srvr` for observers.
+	srvrModeObserver = "observer"
+
+	// serverModeTimeout is how long detecting the mode of each server, via `srvr`, can take (see WithServerPreference).
+	serverModeTimeout = 2 * time.Second
+)
+
+// WithServerPreference makes the Client connect to the preferred servers first, falling back to the others
+// only if none of them is reachable. Like by default, the order is random among the preferred servers,
+// and among the others.
+//
+// Servers are classified by the given `observers`, each a server as listed in NewClient:
+// if empty, the mode of each server is detected via `srvr` before connecting (see ServerHealthCheck),
+// and servers not reporting it are considered participants.
+func WithServerPreference(preference ServerPreference, observers []string) ClientOption {
+	return func(c *Client) error {
+		if preference != PreferParticipants && preference != PreferObservers {
+			return fmt.Errorf("server preference must be '%s' or '%s', got '%s'", PreferParticipants, PreferObservers, preference)
+		}
+
+		c.serverPreference = &serverPreference{preference: preference, observers: observers}
+		return nil
+	}
+}
+
+// serverPreference is the order in which a Client connects to the servers (see WithServerPreference).
+type serverPreference struct {
+	preference ServerPreference
+	// observers are the observers among the servers, detected via `srvr` if empty
+	observers []string
+}
+
+// order returns the given servers, the preferred ones first, each group in random order.
+//
+// Servers are classified via the given function, if serverPreference.observers is empty.
+func (sp *serverPreference) order(servers []string, defaultPort int, isObserver func(server string) bool) ([]string, error) {
+	observer := make([]bool, len(servers))
+	if len(sp.observers) > 0 {
+		observers, err := normalizeServers(strings.Join(sp.observers, serversStringSeparator), defaultPort)
+		if err != nil {
+			return nil, fmt.Errorf("invalid observers: %w", err)
+		}
+		for i, server := range servers {
+			observer[i] = slices.Contains(observers, server)
+		}
+	} else {
+		forEachConcurrently(len(servers), func(i int) {
+			observer[i] = isObserver(servers[i])
+		})
+	}
+
+	var preferred, others []string
+	for i, server := range servers {
+		if observer[i] == (sp.preference == PreferObservers) {
+			preferred = append(preferred, server)
+		} else {
+			others = append(others, server)
+		}
+	}
+
+	// Not security sensitive: this only spreads sessions across servers, like zk.DNSHostProvider does
+	rand.Shuffle(len(preferred), func(i, j int) { preferred[i], preferred[j] = preferred[j], preferred[i] }) //nolint:gosec
+	rand.Shuffle(len(others), func(i, j int) { others[i], others[j] = others[j], others[i] })                //nolint:gosec
+
+	return append(preferred, others...), nil
+}
+
+// isObserver returns true if the given server reports, via `srvr`, to be an observer.
+func (c *Client) isObserver(server string) bool {
+	srvr, err := fourLetterWord(c.dial, server, flwSrvr, serverModeTimeout)
+	if err != nil {
+		return false
+	}
+
+	return parseSrvrValues(srvr)[srvrModeKey] == srvrModeObserver
+}
+
+// orderedHostProvider is a zk.HostProvider that tries the servers in the given order,
+// starting over from the first one whenever disconnected: unlike zk.DNSHostProvider, that continues with the next one.
+//
+// Servers are dialed by hostname: each time, they are resolved again.
+type orderedHostProvider struct {
+	mu      sync.Mutex
+	servers []string
+	// next is the index of the next server to try
+	next int
+	// tried is how many servers were tried since last connected
+	tried int
+}
+
+func (hp *orderedHostProvider) Init(servers []string) error {
+	hp.mu.Lock()
+	defer hp.mu.Unlock()
+
+	if len(servers) == 0 {
+		return fmt.Errorf("no servers to connect to")
+	}
+
+	hp.servers = servers
+	return nil
+}
+
+func (hp *orderedHostProvider) Len() int {
+	hp.mu.Lock()
+	defer hp.mu.Unlock()
+
+	return len(hp.servers)
+}
+
+func (hp *orderedHostProvider) Next() (string, bool) {
+	hp.mu.Lock()
+	defer hp.mu.Unlock()
+
+	// All the servers were tried without connecting: starting over
+	retryStart := hp.tried == len(hp.servers)
+	if retryStart {
+		hp.tried = 0
+	}
+
+	server := hp.servers[hp.next]
+	hp.next = (hp.next + 1) % len(hp.servers)
+	hp.tried++
+
+	return server, retryStart
+}
+
+func (hp *orderedHostProvider) Connected() {
+	hp.mu.Lock()
+	defer hp.mu.Unlock()
+
+	hp.next, hp.tried = 0, 0
+}
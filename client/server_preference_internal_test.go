@@ -0,0 +1,71 @@
+package client
+
+import (
+	"testing"
+
+	testifyAssert "github.com/stretchr/testify/assert"
+)
+
+func TestServerPreferenceOrder(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	servers := []string{"zk1:2181", "zk2:2181", "obs1:2181", "obs2:2181"}
+	detect := func(server string) bool { return server == "obs1:2181" || server == "obs2:2181" }
+
+	// Observers listed as in `servers`, without the default port
+	ordered, err := (&serverPreference{preference: PreferParticipants, observers: []string{"obs1", "obs2:2181"}}).order(servers, 2181, nil)
+	assert.NoError(err)
+	assert.ElementsMatch([]string{"zk1:2181", "zk2:2181"}, ordered[:2])
+	assert.ElementsMatch([]string{"obs1:2181", "obs2:2181"}, ordered[2:])
+
+	ordered, err = (&serverPreference{preference: PreferObservers}).order(servers, 2181, detect)
+	assert.NoError(err)
+	assert.ElementsMatch([]string{"obs1:2181", "obs2:2181"}, ordered[:2])
+	assert.ElementsMatch([]string{"zk1:2181", "zk2:2181"}, ordered[2:])
+
+	// No server of the preferred kind: the others are still connected to
+	ordered, err = (&serverPreference{preference: PreferObservers}).order(servers[:2], 2181, detect)
+	assert.NoError(err)
+	assert.ElementsMatch([]string{"zk1:2181", "zk2:2181"}, ordered)
+
+	_, err = (&serverPreference{preference: PreferObservers, observers: []string{"obs1:napoli"}}).order(servers, 2181, detect)
+	assert.ErrorContains(err, "invalid observers")
+}
+
+func TestWithServerPreferenceValidatesPreference(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	c := &Client{}
+	assert.NoError(WithServerPreference(PreferObservers, nil)(c))
+	assert.Equal(PreferObservers, c.serverPreference.preference)
+
+	assert.ErrorContains(WithServerPreference("leader", nil)(c), "server preference must be 'participants' or 'observers'")
+}
+
+func TestOrderedHostProvider(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	hp := &orderedHostProvider{}
+	assert.Error(hp.Init(nil))
+	assert.NoError(hp.Init([]string{"zk1:2181", "zk2:2181", "obs1:2181"}))
+	assert.Equal(3, hp.Len())
+
+	for _, expected := range []string{"zk1:2181", "zk2:2181", "obs1:2181"} {
+		server, retryStart := hp.Next()
+		assert.Equal(expected, server)
+		assert.False(retryStart)
+	}
+
+	// All tried: starting over, from the first
+	server, retryStart := hp.Next()
+	assert.Equal("zk1:2181", server)
+	assert.True(retryStart)
+
+	// Once connected, the next disconnection starts over from the first too
+	server, _ = hp.Next()
+	assert.Equal("zk2:2181", server)
+	hp.Connected()
+	server, retryStart = hp.Next()
+	assert.Equal("zk1:2181", server)
+	assert.False(retryStart)
+}
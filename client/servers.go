@@ -0,0 +1,119 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-zookeeper/zk"
+)
+
+const (
+	// DefaultClientPort is the port of the servers listed without one, when connecting in plaintext.
+	DefaultClientPort = zk.DefaultPort
+	// DefaultSecureClientPort is the port of the servers listed without one, when connecting over TLS (see WithTLS):
+	// ZooKeeper has no default `secureClientPort`, this is the one its documentation uses.
+	DefaultSecureClientPort = 2281
+)
+
+// ErrorServerNotResolved is returned by NewClient, when the Client is configured to check
+// that the hostnames of the servers resolve (see WithServersDNSCheck), and any doesn't.
+var ErrorServerNotResolved = errors.New("server hostname not resolved")
+
+// serverResolveTimeout is how long resolving the hostname of each server can take (see WithServersDNSCheck).
+const serverResolveTimeout = 5 * time.Second
+
+// WithDefaultPort sets the port of the servers listed without one,
+// instead of DefaultClientPort (or DefaultSecureClientPort, when connecting over TLS).
+func WithDefaultPort(port int) ClientOption {
+	return func(c *Client) error {
+		if port < 1 || port > 65535 {
+			return fmt.Errorf("default port %d must be between 1 and 65535", port)
+		}
+
+		c.defaultPort = port
+		return nil
+	}
+}
+
+// normalizeServers splits the given comma separated list of servers into 'host:port' addresses,
+// appending the given default port to the ones without, and ignoring blanks around each.
+//
+// IPv6 addresses are accepted both bare (ex. `::1`) and in brackets (ex. `[::1]` or `[::1]:2181`).
+func normalizeServers(servers string, defaultPort int) ([]string, error) {
+	var normalized []string
+	for _, server := range strings.Split(servers, serversStringSeparator) {
+		server = strings.TrimSpace(server)
+		if server == "" {
+			continue
+		}
+
+		host, port, err := net.SplitHostPort(server)
+		switch {
+		case err == nil:
+		case strings.Count(server, ":") > 1 && !strings.HasPrefix(server, "["):
+			// Bare IPv6 address, that can't have a port
+			host, port = server, strconv.Itoa(defaultPort)
+		case strings.HasPrefix(server, "[") && strings.HasSuffix(server, "]"):
+			host, port = server[1:len(server)-1], strconv.Itoa(defaultPort)
+		case !strings.Contains(server, ":"):
+			host, port = server, strconv.Itoa(defaultPort)
+		default:
+			return nil, fmt.Errorf("invalid server '%s': %w", server, err)
+		}
+
+		if portNum, err := strconv.Atoi(port); err != nil || portNum < 1 || portNum > 65535 {
+			return nil, fmt.Errorf("invalid server '%s': port must be a number between 1 and 65535", server)
+		}
+		if host == "" {
+			return nil, fmt.Errorf("invalid server '%s': host is missing", server)
+		}
+
+		normalized = append(normalized, net.JoinHostPort(host, port))
+	}
+
+	if len(normalized) == 0 {
+		return nil, fmt.Errorf("no server in '%s'", servers)
+	}
+
+	return normalized, nil
+}
+
+// WithServersDNSCheck makes NewClient resolve the hostnames of all the servers, each within 5 seconds, failing with
+// ErrorServerNotResolved listing all the ones that don't resolve. Otherwise, connecting fails at the first one,
+// with 3 seconds to resolve them all (see zk.DNSHostProvider).
+func WithServersDNSCheck() ClientOption {
+	return func(c *Client) error {
+		c.checkServersDNS = true
+		return nil
+	}
+}
+
+// checkServersResolve resolves the hostname of the given 'host:port' servers via the given lookup function,
+// returning an error listing all the ones that fail. IP addresses are not resolved.
+func checkServersResolve(servers []string, lookupHost func(ctx context.Context, host string) ([]string, error)) error {
+	var failures []string
+	for _, server := range servers {
+		host, _, err := net.SplitHostPort(server)
+		if err != nil || net.ParseIP(host) != nil {
+			continue
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), serverResolveTimeout)
+		_, err = lookupHost(ctx, host)
+		cancel()
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("'%s' (%v)", server, err))
+		}
+	}
+
+	if len(failures) > 0 {
+		return fmt.Errorf("%w: %s", ErrorServerNotResolved, strings.Join(failures, ", "))
+	}
+
+	return nil
+}
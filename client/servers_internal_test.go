@@ -0,0 +1,54 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	testifyAssert "github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeServers(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	servers, err := normalizeServers("zk1, zk2:2182 ,,10.0.0.1,::1,[::2],[::3]:2183", 2181)
+	assert.NoError(err)
+	assert.Equal([]string{"zk1:2181", "zk2:2182", "10.0.0.1:2181", "[::1]:2181", "[::2]:2181", "[::3]:2183"}, servers)
+
+	servers, err = normalizeServers("zk1", DefaultSecureClientPort)
+	assert.NoError(err)
+	assert.Equal([]string{"zk1:2281"}, servers)
+
+	for _, invalid := range []string{"", " , ", "zk1:", "zk1:napoli", "zk1:0", "zk1:65536", ":2181", "[::1"} {
+		_, err := normalizeServers(invalid, 2181)
+		assert.Error(err, invalid)
+	}
+}
+
+func TestWithDefaultPortValidatesPort(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	assert.NoError(WithDefaultPort(2182)(&Client{}))
+	assert.Error(WithDefaultPort(0)(&Client{}))
+	assert.Error(WithDefaultPort(65536)(&Client{}))
+}
+
+func TestCheckServersResolve(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	var looked []string
+	lookupHost := func(_ context.Context, host string) ([]string, error) {
+		looked = append(looked, host)
+		if host == "zk2" || host == "zk3" {
+			return nil, errors.New("no such host")
+		}
+		return []string{"10.0.0.1"}, nil
+	}
+
+	err := checkServersResolve([]string{"zk1:2181", "zk2:2181", "10.0.0.4:2181", "[::1]:2181", "zk3:2181"}, lookupHost)
+	assert.ErrorIs(err, ErrorServerNotResolved)
+	assert.ErrorContains(err, "'zk2:2181' (no such host), 'zk3:2181' (no such host)")
+	assert.Equal([]string{"zk1", "zk2", "zk3"}, looked)
+
+	assert.NoError(checkServersResolve([]string{"zk1:2181"}, lookupHost))
+}
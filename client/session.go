@@ -0,0 +1,78 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-zookeeper/zk"
+)
+
+// zkAuthenticatedLogFormat is the format of the message the ZooKeeper library logs once a session is established,
+// with the session ID and the negotiated session timeout (in milliseconds) as arguments.
+const zkAuthenticatedLogFormat = "authenticated: id=%d, timeout=%d"
+
+// Session describes the ZooKeeper session of a Client.
+type Session struct {
+	// ID is the session ID, as assigned by the ZooKeeper Ensemble.
+	ID int64
+	// Timeout is the session timeout negotiated with the ZooKeeper Ensemble: the one requested
+	// to NewClient, bounded by the `minSessionTimeout` and `maxSessionTimeout` of the Server.
+	Timeout time.Duration
+	// Server is the 'host:port' of the ZooKeeper Server the Client is connected to.
+	Server string
+}
+
+// Session returns the current Session of the Client, waiting for it to be established if it's not yet.
+func (c *Client) Session() (*Session, error) {
+	defer c.telemetry.record("Session", "", time.Now())
+
+	// Requests are queued until the session is established
+	if _, _, err := c.zkConn.Exists(string(zNodePathSeparator)); err != nil {
+		return nil, fmt.Errorf("failed to establish session: %w", err)
+	}
+
+	return &Session{
+		ID:      c.zkConn.SessionID(),
+		Timeout: time.Duration(c.sessionTimeoutMs.Load()) * time.Millisecond,
+		Server:  c.zkConn.Server(),
+	}, nil
+}
+
+// ConnectedServerMode returns the role of the ZooKeeper Server the Client is currently connected to
+// (ex. `leader`, `follower`, `observer`, `standalone`), via the `srvr` Four Letter Word.
+func (c *Client) ConnectedServerMode(timeout time.Duration) (string, error) {
+	server := c.zkConn.Server()
+
+	srvr, err := fourLetterWord(c.dial, server, flwSrvr, timeout)
+	if err != nil {
+		return "", err
+	}
+	if strings.Contains(string(srvr), flwNotWhitelisted) {
+		return "", fmt.Errorf("'%s' is not whitelisted on '%s'", flwSrvr, server)
+	}
+
+	mode, ok := parseSrvrValues(srvr)[srvrModeKey]
+	if !ok {
+		return "", fmt.Errorf("unable to parse '%s' response from '%s': missing '%s'", flwSrvr, server, srvrModeKey)
+	}
+
+	return mode, nil
+}
+
+// sessionLogger is the zk.Logger of a Client: it logs like zk.DefaultLogger, and records the negotiated
+// session timeout, that the ZooKeeper library doesn't expose otherwise.
+type sessionLogger struct {
+	sessionTimeoutMs *atomic.Int32
+}
+
+func (l sessionLogger) Printf(format string, args ...interface{}) {
+	if format == zkAuthenticatedLogFormat && len(args) == 2 {
+		if timeoutMs, ok := args[1].(int32); ok {
+			l.sessionTimeoutMs.Store(timeoutMs)
+		}
+	}
+
+	zk.DefaultLogger.Printf(format, args...)
+}
@@ -0,0 +1,26 @@
+package client
+
+import (
+	"testing"
+
+	testifyAssert "github.com/stretchr/testify/assert"
+)
+
+func TestSessionLoggerRecordsNegotiatedTimeout(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	c := &Client{}
+	logger := sessionLogger{sessionTimeoutMs: &c.sessionTimeoutMs}
+
+	// Other messages are only logged
+	logger.Printf("connected to %s", "127.0.0.1:2181")
+	assert.Equal(int32(0), c.sessionTimeoutMs.Load())
+
+	// As logged by the ZooKeeper library, once the session is established
+	logger.Printf(zkAuthenticatedLogFormat, int64(72057594037927936), int32(4000))
+	assert.Equal(int32(4000), c.sessionTimeoutMs.Load())
+
+	// Re-established sessions can negotiate a different timeout
+	logger.Printf(zkAuthenticatedLogFormat, int64(72057594037927937), int32(6000))
+	assert.Equal(int32(6000), c.sessionTimeoutMs.Load())
+}
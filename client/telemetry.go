@@ -0,0 +1,183 @@
+package client
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-zookeeper/zk"
+)
+
+// slowestOperationsTracked is how many of the slowest operations Telemetry reports.
+const slowestOperationsTracked = 5
+
+// Telemetry summarizes the operations performed by a Client since its creation,
+// to help understand and tune the performance of large configurations.
+type Telemetry struct {
+	// Operations is the number of invocations of each Client method (ex. `Read`).
+	Operations map[string]int64
+	// Durations is the total time spent in the invocations of each Client method.
+	Durations map[string]time.Duration
+	// BytesSent and BytesReceived are counted on the connection(s) to the ZooKeeper Ensemble.
+	BytesSent     int64
+	BytesReceived int64
+	// Retries is the number of retries performed via Client.Retry.
+	Retries int64
+	// Slowest are the slowest operations, slowest first.
+	Slowest []OperationTiming
+}
+
+// OperationTiming is how long it took a Client method to complete, for the ZNode at the given path.
+type OperationTiming struct {
+	Operation string
+	Path      string
+	Duration  time.Duration
+}
+
+// TotalOperations is the number of operations, of any kind.
+func (t Telemetry) TotalOperations() int64 {
+	var total int64
+	for _, count := range t.Operations {
+		total += count
+	}
+
+	return total
+}
+
+// String summarizes the Telemetry on a single line.
+func (t Telemetry) String() string {
+	operations := make([]string, 0, len(t.Operations))
+	for operation, count := range t.Operations {
+		operations = append(operations, fmt.Sprintf("%s: %d", operation, count))
+	}
+	sort.Strings(operations)
+
+	slowest := make([]string, 0, len(t.Slowest))
+	for _, timing := range t.Slowest {
+		slowest = append(slowest, fmt.Sprintf("%s '%s' (%s)", timing.Operation, timing.Path, timing.Duration.Round(time.Millisecond)))
+	}
+
+	return fmt.Sprintf("%d operations (%s), %d bytes sent, %d bytes received, %d retries; slowest: %s",
+		t.TotalOperations(), strings.Join(operations, ", "), t.BytesSent, t.BytesReceived, t.Retries, strings.Join(slowest, ", "))
+}
+
+// Telemetry returns the Telemetry of the Client, up to now.
+func (c *Client) Telemetry() Telemetry {
+	return c.telemetry.snapshot()
+}
+
+// HasSession returns true if the Client is connected to the ZooKeeper Ensemble, with an established session.
+func (c *Client) HasSession() bool {
+	return c.zkConn.State() == zk.StateHasSession
+}
+
+// telemetryRecorder collects the Telemetry of a Client. A `nil` telemetryRecorder records nothing.
+type telemetryRecorder struct {
+	mu         sync.Mutex
+	operations map[string]int64
+	durations  map[string]time.Duration
+	slowest    []OperationTiming
+	retries    int64
+
+	bytesSent     atomic.Int64
+	bytesReceived atomic.Int64
+}
+
+func newTelemetryRecorder() *telemetryRecorder {
+	return &telemetryRecorder{operations: map[string]int64{}, durations: map[string]time.Duration{}}
+}
+
+// record an invocation of the given operation, on the ZNode at the given path, started at `start`.
+//
+// Meant to be deferred, at the beginning of each Client method.
+func (tr *telemetryRecorder) record(operation string, path string, start time.Time) {
+	if tr == nil {
+		return
+	}
+	timing := OperationTiming{Operation: operation, Path: path, Duration: time.Since(start)}
+
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	tr.operations[operation]++
+	tr.durations[operation] += timing.Duration
+
+	// Keep the slowest, slowest first
+	i := sort.Search(len(tr.slowest), func(i int) bool { return tr.slowest[i].Duration < timing.Duration })
+	if i < slowestOperationsTracked {
+		tr.slowest = append(tr.slowest[:i], append([]OperationTiming{timing}, tr.slowest[i:]...)...)
+		if len(tr.slowest) > slowestOperationsTracked {
+			tr.slowest = tr.slowest[:slowestOperationsTracked]
+		}
+	}
+}
+
+// recordRetry records a retry of an operation (see Client.Retry).
+func (tr *telemetryRecorder) recordRetry() {
+	if tr == nil {
+		return
+	}
+
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	tr.retries++
+}
+
+func (tr *telemetryRecorder) snapshot() Telemetry {
+	if tr == nil {
+		return Telemetry{Operations: map[string]int64{}, Durations: map[string]time.Duration{}}
+	}
+
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	operations := make(map[string]int64, len(tr.operations))
+	durations := make(map[string]time.Duration, len(tr.durations))
+	for operation, count := range tr.operations {
+		operations[operation] = count
+		durations[operation] = tr.durations[operation]
+	}
+
+	return Telemetry{
+		Operations:    operations,
+		Durations:     durations,
+		BytesSent:     tr.bytesSent.Load(),
+		BytesReceived: tr.bytesReceived.Load(),
+		Retries:       tr.retries,
+		Slowest:       append([]OperationTiming{}, tr.slowest...),
+	}
+}
+
+// countBytes wraps the given connection, to count the bytes sent and received on it.
+func (tr *telemetryRecorder) countBytes(conn net.Conn) net.Conn {
+	if tr == nil {
+		return conn
+	}
+
+	return &countingConn{Conn: conn, telemetry: tr}
+}
+
+// countingConn is a net.Conn that counts the bytes sent and received, for telemetryRecorder.
+type countingConn struct {
+	net.Conn
+	telemetry *telemetryRecorder
+}
+
+func (cc *countingConn) Read(b []byte) (int, error) {
+	n, err := cc.Conn.Read(b)
+	cc.telemetry.bytesReceived.Add(int64(n))
+
+	return n, err //nolint:wrapcheck // Must behave exactly like the wrapped net.Conn
+}
+
+func (cc *countingConn) Write(b []byte) (int, error) {
+	n, err := cc.Conn.Write(b)
+	cc.telemetry.bytesSent.Add(int64(n))
+
+	return n, err //nolint:wrapcheck // Must behave exactly like the wrapped net.Conn
+}
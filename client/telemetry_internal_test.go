@@ -0,0 +1,83 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/go-zookeeper/zk"
+	testifyAssert "github.com/stretchr/testify/assert"
+)
+
+func TestTelemetryKeepsSlowestOperations(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	tr := newTelemetryRecorder()
+	for i := 1; i <= slowestOperationsTracked+2; i++ {
+		tr.record("Read", "/a", time.Now().Add(-time.Duration(i)*time.Second))
+	}
+	tr.record("Create", "/slowest", time.Now().Add(-time.Hour))
+
+	telemetry := tr.snapshot()
+	assert.Equal(map[string]int64{"Read": slowestOperationsTracked + 2, "Create": 1}, telemetry.Operations)
+	assert.Equal(int64(slowestOperationsTracked+3), telemetry.TotalOperations())
+	assert.Len(telemetry.Slowest, slowestOperationsTracked)
+	assert.Equal("/slowest", telemetry.Slowest[0].Path)
+	assert.GreaterOrEqual(telemetry.Durations["Create"], time.Hour)
+	assert.GreaterOrEqual(telemetry.Durations["Read"], 28*time.Second)
+	for i := 1; i < len(telemetry.Slowest); i++ {
+		assert.GreaterOrEqual(telemetry.Slowest[i-1].Duration, telemetry.Slowest[i].Duration)
+	}
+	assert.Contains(telemetry.String(), "8 operations (Create: 1, Read: 7)")
+	assert.Contains(telemetry.String(), "slowest: Create '/slowest' (1h0m0s)")
+}
+
+func TestTelemetryCountsBytes(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	tr := newTelemetryRecorder()
+	client, server := net.Pipe()
+	conn := &countingConn{Conn: client, telemetry: tr}
+
+	go func() {
+		buf := make([]byte, 5)
+		_, _ = io.ReadFull(server, buf)
+		_, _ = server.Write([]byte("pong"))
+		_ = server.Close()
+	}()
+
+	_, err := conn.Write([]byte("ping!"))
+	assert.NoError(err)
+	received, err := io.ReadAll(conn)
+	assert.NoError(err)
+	assert.Equal([]byte("pong"), received)
+
+	telemetry := tr.snapshot()
+	assert.Equal(int64(5), telemetry.BytesSent)
+	assert.Equal(int64(4), telemetry.BytesReceived)
+}
+
+func TestTelemetryCountsRetries(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	c := &Client{telemetry: newTelemetryRecorder()}
+	attempts := 0
+	err := c.Retry(context.Background(), RetryPolicy{Retries: 2}, func() error {
+		attempts++
+		return zk.ErrConnectionClosed
+	})
+	assert.ErrorIs(err, zk.ErrConnectionClosed)
+	assert.Equal(3, attempts)
+	assert.Equal(int64(2), c.Telemetry().Retries)
+}
+
+func TestNilTelemetryRecordsNothing(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	var tr *telemetryRecorder
+	tr.record("Read", "/a", time.Now())
+	tr.recordRetry()
+	assert.Equal(int64(0), tr.snapshot().TotalOperations())
+}
@@ -0,0 +1,184 @@
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"slices"
+	"sync"
+	"time"
+)
+
+// ErrorTLSRequired is returned when a plaintext connection would be established,
+// by a Client configured to require TLS (see WithTLSRequired).
+var ErrorTLSRequired = errors.New("TLS required")
+
+// WithTLS makes the Client connect to the ZooKeeper Server(s) over TLS (ex. their `secureClientPort`),
+// including for Four Letter Words (see CheckServersHealth).
+//
+// The certificates of the Servers are verified against the PEM encoded CA certificates in `caFile`,
+// or the system ones if empty. If `certFile` and `keyFile` are set, the Client presents
+// that certificate to the Servers (ex. to be authenticated via the `x509` scheme).
+//
+// The files are loaded again whenever they change, before (re-)connecting to a Server:
+// short-lived certificates can be rotated while the Client is in use.
+func WithTLS(caFile, certFile, keyFile string) ClientOption {
+	return func(c *Client) error {
+		if (certFile == "") != (keyFile == "") {
+			return fmt.Errorf("both TLS certificate and key files must be specified together")
+		}
+
+		files := &tlsFiles{caFile: caFile, certFile: certFile, keyFile: keyFile}
+		if _, err := files.config(); err != nil {
+			return err
+		}
+
+		c.tlsFiles = files
+		return nil
+	}
+}
+
+// tlsFiles is the TLS configuration of a Client, loaded from files and reloaded when they change (see WithTLS).
+type tlsFiles struct {
+	caFile, certFile, keyFile string
+
+	mu sync.Mutex
+	// loaded is the configuration last loaded, from files last modified at loadedModTimes
+	loaded         *tls.Config
+	loadedModTimes []time.Time
+}
+
+// config returns the TLS configuration, loading the files again if any was modified since last loaded.
+//
+// If loading fails after a modification (ex. the certificate was rotated, but the key not yet),
+// the configuration last loaded is returned: loading will be attempted again next time.
+func (tf *tlsFiles) config() (*tls.Config, error) {
+	tf.mu.Lock()
+	defer tf.mu.Unlock()
+
+	modTimes := make([]time.Time, 0, 3)
+	for _, file := range []string{tf.caFile, tf.certFile, tf.keyFile} {
+		if file == "" {
+			continue
+		}
+		info, err := os.Stat(file)
+		if err != nil {
+			if tf.loaded != nil {
+				return tf.loaded, nil
+			}
+			return nil, fmt.Errorf("failed to read TLS file '%s': %w", file, err)
+		}
+		modTimes = append(modTimes, info.ModTime())
+	}
+
+	if tf.loaded != nil && slices.EqualFunc(modTimes, tf.loadedModTimes, time.Time.Equal) {
+		return tf.loaded, nil
+	}
+
+	config, err := tf.load()
+	if err != nil {
+		if tf.loaded != nil {
+			return tf.loaded, nil
+		}
+		return nil, err
+	}
+
+	tf.loaded, tf.loadedModTimes = config, modTimes
+	return config, nil
+}
+
+func (tf *tlsFiles) load() (*tls.Config, error) {
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if tf.caFile != "" {
+		caPEM, err := os.ReadFile(tf.caFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read TLS CA file '%s': %w", tf.caFile, err)
+		}
+		config.RootCAs = x509.NewCertPool()
+		if !config.RootCAs.AppendCertsFromPEM(caPEM) {
+			return nil, fmt.Errorf("no PEM encoded certificate found in TLS CA file '%s'", tf.caFile)
+		}
+	}
+
+	if tf.certFile != "" {
+		cert, err := tls.LoadX509KeyPair(tf.certFile, tf.keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load TLS certificate '%s' and key '%s': %w", tf.certFile, tf.keyFile, err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	return config, nil
+}
+
+// WithTLSRequired makes the Client refuse to establish any plaintext connection:
+// NewClient fails unless the Client is also configured via WithTLS.
+func WithTLSRequired() ClientOption {
+	return func(c *Client) error {
+		c.requireTLS = true
+		return nil
+	}
+}
+
+// RequiresTLS returns true if the Client must not establish plaintext connections (see WithTLSRequired).
+//
+// Every connection the Client establishes is already over TLS: this is for connections
+// established outside of it (ex. RunAdminCommand).
+func (c *Client) RequiresTLS() bool {
+	return c != nil && c.requireTLS
+}
+
+// dial is the zk.Dialer of the Client, also used for Four Letter Words.
+//
+// It counts the bytes sent and received (see Telemetry) and, if configured via WithTLS,
+// completes the TLS handshake with the Server at the given address before returning,
+// using the TLS files as they are at that moment.
+func (c *Client) dial(network, address string, timeout time.Duration) (net.Conn, error) {
+	if c.tlsFiles == nil && c.requireTLS {
+		return nil, fmt.Errorf("refusing plaintext connection to '%s': %w", address, ErrorTLSRequired)
+	}
+
+	var tlsConfig *tls.Config
+	if c.tlsFiles != nil {
+		var err error
+		if tlsConfig, err = c.tlsFiles.config(); err != nil {
+			return nil, err
+		}
+	}
+
+	conn, err := net.DialTimeout(network, address, timeout)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // Returned as is, like the default zk.Dialer does
+	}
+	conn = c.telemetry.countBytes(conn)
+
+	if tlsConfig == nil {
+		return conn, nil
+	}
+
+	config := tlsConfig.Clone()
+	if config.ServerName, _, err = net.SplitHostPort(address); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to parse ZooKeeper server '%s': %w", address, err)
+	}
+
+	tlsConn := tls.Client(conn, config)
+	if err := tlsConn.SetDeadline(time.Now().Add(timeout)); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to set TLS handshake deadline for '%s': %w", address, err)
+	}
+	if err := tlsConn.Handshake(); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("TLS handshake with '%s' failed: %w", address, err)
+	}
+	if err := tlsConn.SetDeadline(time.Time{}); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to reset deadline for '%s': %w", address, err)
+	}
+
+	return tlsConn, nil
+}
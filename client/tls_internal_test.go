@@ -0,0 +1,180 @@
+package client
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	testifyAssert "github.com/stretchr/testify/assert"
+)
+
+// writeTestCertificate writes a self-signed certificate for `127.0.0.1`, and its key, as PEM files in the given directory.
+func writeTestCertificate(t *testing.T, dir string, name string) (certFile string, keyFile string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certFile, keyFile = filepath.Join(dir, name+".crt"), filepath.Join(dir, name+".key")
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	return certFile, keyFile
+}
+
+// serveTLSRuok accepts TLS connections with the given certificate, replying `imok` to any request.
+func serveTLSRuok(t *testing.T, certFile, keyFile string) string {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{Certificates: []tls.Certificate{cert}, MinVersion: tls.VersionTLS12})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				if _, err := conn.Read(make([]byte, 4)); err == nil {
+					_, _ = io.WriteString(conn, flwRuokResponse)
+				}
+			}()
+		}
+	}()
+
+	return listener.Addr().String()
+}
+
+func TestDialOverTLS(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	dir := t.TempDir()
+	serverCert, serverKey := writeTestCertificate(t, dir, "server")
+	server := serveTLSRuok(t, serverCert, serverKey)
+
+	c := &Client{telemetry: newTelemetryRecorder()}
+	assert.NoError(WithTLS(serverCert, "", "")(c))
+	assert.NoError(WithTLSRequired()(c))
+
+	response, err := fourLetterWord(c.dial, server, flwRuok, time.Second)
+	assert.NoError(err)
+	assert.Equal(flwRuokResponse, string(response))
+	assert.Positive(c.Telemetry().BytesReceived)
+
+	// Servers not signed by the CA are refused
+	otherCert, _ := writeTestCertificate(t, dir, "other")
+	c = &Client{}
+	assert.NoError(WithTLS(otherCert, "", "")(c))
+	_, err = fourLetterWord(c.dial, server, flwRuok, time.Second)
+	assert.ErrorContains(err, "TLS handshake")
+}
+
+func TestWithTLSValidatesFiles(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	dir := t.TempDir()
+	certFile, keyFile := writeTestCertificate(t, dir, "client")
+
+	c := &Client{}
+	assert.NoError(WithTLS("", certFile, keyFile)(c))
+	assert.Len(c.tlsFiles.loaded.Certificates, 1)
+
+	assert.ErrorContains(WithTLS("", certFile, "")(c), "must be specified together")
+	assert.ErrorContains(WithTLS(keyFile, "", "")(c), "no PEM encoded certificate")
+	assert.Error(WithTLS(filepath.Join(dir, "missing.crt"), "", "")(c))
+}
+
+func TestTLSRequired(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	c := &Client{}
+	assert.NoError(WithTLSRequired()(c))
+	assert.True(c.RequiresTLS())
+	_, err := c.dial("tcp", "127.0.0.1:1", time.Second)
+	assert.ErrorIs(err, ErrorTLSRequired)
+
+	// Connecting happens in the background: no ZooKeeper Server is necessary
+	_, err = NewClient("127.0.0.1:1", 1, "", "", WithTLSRequired())
+	assert.ErrorIs(err, ErrorTLSRequired)
+	c, err = NewClient("127.0.0.1:1", 1, "", "", WithTLSRequired(), WithTLS("", "", ""))
+	assert.NoError(err)
+	assert.True(c.RequiresTLS())
+}
+
+func TestTLSFilesReloadedWhenChanged(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	dir := t.TempDir()
+	serverCert, serverKey := writeTestCertificate(t, dir, "server")
+	server := serveTLSRuok(t, serverCert, serverKey)
+
+	caFile := filepath.Join(dir, "ca.crt")
+	otherCert, _ := writeTestCertificate(t, dir, "other")
+	caPEM, err := os.ReadFile(otherCert)
+	assert.NoError(err)
+	assert.NoError(os.WriteFile(caFile, caPEM, 0o600))
+
+	c := &Client{}
+	assert.NoError(WithTLS(caFile, "", "")(c))
+	_, err = fourLetterWord(c.dial, server, flwRuok, time.Second)
+	assert.ErrorContains(err, "TLS handshake")
+
+	// Rotating the CA makes the next connection use it
+	caPEM, err = os.ReadFile(serverCert)
+	assert.NoError(err)
+	assert.NoError(os.WriteFile(caFile, caPEM, 0o600))
+	assert.NoError(os.Chtimes(caFile, time.Now(), time.Now().Add(time.Minute)))
+	_, err = fourLetterWord(c.dial, server, flwRuok, time.Second)
+	assert.NoError(err)
+
+	// An invalid file, ex. while being rotated, is ignored until fixed
+	loaded := c.tlsFiles.loaded
+	assert.NoError(os.WriteFile(caFile, []byte("rotating"), 0o600))
+	assert.NoError(os.Chtimes(caFile, time.Now(), time.Now().Add(2*time.Minute)))
+	config, err := c.tlsFiles.config()
+	assert.NoError(err)
+	assert.Same(loaded, config)
+}
@@ -0,0 +1,80 @@
+package client
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-zookeeper/zk"
+)
+
+// serverVersionRegexp matches the version reported by `srvr`, ex. `3.8.4-9316c2a7a97e1666d8f4593f34dd6fc36ecc436c, built on 2024-02-12 22:16 UTC`.
+var serverVersionRegexp = regexp.MustCompile(`^(\d+)\.(\d+)\.(\d+)([^-,]*)(?:-([0-9a-fA-F]+))?(?:.*, built on (.+))?$`)
+
+// ServerVersion is the version and build information of a ZooKeeper Server.
+type ServerVersion struct {
+	// Server is the 'host:port' of the ZooKeeper Server.
+	Server string
+
+	// Version is the semantic version of the Server (ex. `3.8.4`).
+	Version string
+	Major   int
+	Minor   int
+	Patch   int
+
+	// Commit is the hash of the commit the Server was built from. Empty if not reported.
+	Commit string
+	// BuiltOn is the build date of the Server, as reported by it.
+	BuiltOn string
+}
+
+// ConnectedServerVersion returns the ServerVersion of the ZooKeeper Server the Client is currently connected to.
+func (c *Client) ConnectedServerVersion(timeout time.Duration) (*ServerVersion, error) {
+	return c.ServerVersion(c.zkConn.Server(), timeout)
+}
+
+// ServerVersion returns the ServerVersion of the given 'host:port' ZooKeeper Server, like GetServerVersion,
+// connecting to it like the Client does to the ZooKeeper Ensemble (ex. over TLS, see WithTLS).
+func (c *Client) ServerVersion(server string, timeout time.Duration) (*ServerVersion, error) {
+	return getServerVersion(c.dial, server, timeout)
+}
+
+// GetServerVersion returns the ServerVersion of the given 'host:port' ZooKeeper Server, via the `srvr` Four Letter Word.
+func GetServerVersion(server string, timeout time.Duration) (*ServerVersion, error) {
+	return getServerVersion(net.DialTimeout, server, timeout)
+}
+
+func getServerVersion(dial zk.Dialer, server string, timeout time.Duration) (*ServerVersion, error) {
+	srvr, err := fourLetterWord(dial, server, flwSrvr, timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.Contains(string(srvr), flwNotWhitelisted) {
+		return nil, fmt.Errorf("'%s' is not whitelisted on '%s'", flwSrvr, server)
+	}
+
+	rawVersion := parseSrvrValues(srvr)[srvrVersionKey]
+	match := serverVersionRegexp.FindStringSubmatch(rawVersion)
+	if match == nil {
+		return nil, fmt.Errorf("unable to parse version '%s' reported by '%s'", rawVersion, server)
+	}
+
+	// Within the regular expression above, these values must be numerical
+	major, _ := strconv.Atoi(match[1])
+	minor, _ := strconv.Atoi(match[2])
+	patch, _ := strconv.Atoi(match[3])
+
+	return &ServerVersion{
+		Server:  server,
+		Version: fmt.Sprintf("%d.%d.%d%s", major, minor, patch, match[4]),
+		Major:   major,
+		Minor:   minor,
+		Patch:   patch,
+		Commit:  match[5],
+		BuiltOn: match[6],
+	}, nil
+}
@@ -0,0 +1,182 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// WalkOptions configures WalkConcurrently.
+type WalkOptions struct {
+	// MaxDepth is the depth of the deepest ZNodes visited: see Walk. Negative means no limit.
+	MaxDepth int
+	// Concurrency is the maximum number of ZNodes read concurrently. Values below 1 mean 1.
+	Concurrency int
+	// IncludeACL populates the ACL of the ZNodes passed to the WalkFunc, unless the Client is configured via WithoutACLReads.
+	IncludeACL bool
+	// SkipUnauthorized skips the ZNodes below `path` that the Client is not authorized to read (and their subtree),
+	// instead of failing the walk.
+	SkipUnauthorized bool
+}
+
+// WalkConcurrently is like Walk, but the subtree is read by a pool of at most `opts.Concurrency`
+// concurrent requests, pipelined over the same ZooKeeper session.
+//
+// ZNodes are still passed to `walkFn` one at a time, in the same depth-first lexicographic order of Walk:
+// reads run ahead of `walkFn`, that is never invoked concurrently.
+func (c *Client) WalkConcurrently(path string, opts WalkOptions, walkFn WalkFunc) error {
+	defer c.telemetry.record("WalkConcurrently", path, time.Now())
+
+	if err := c.paths.check(path); err != nil {
+		return err
+	}
+
+	w := newWalker(opts, func(path string, listChildren bool) (*ZNode, []string, error) {
+		return c.walkRead(path, listChildren, opts.IncludeACL)
+	})
+
+	err := w.walk(path, walkFn)
+	if errors.Is(err, ErrorStopWalk) {
+		return nil
+	}
+
+	return err
+}
+
+// walkRead reads the ZNode at the given path, for WalkConcurrently,
+// and also its children if `listChildren`.
+func (c *Client) walkRead(path string, listChildren bool, includeACL bool) (*ZNode, []string, error) {
+	data, stat, err := c.zkConn.Get(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read ZNode '%s': %w", path, err)
+	}
+	znode := &ZNode{Path: path, Stat: stat, Data: data}
+
+	if includeACL && !c.skipACLReads {
+		znode.ACL, _, err = c.zkConn.GetACL(path)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to fetch ACLs for ZNode '%s': %w", path, err)
+		}
+	}
+
+	if !listChildren || stat.NumChildren == 0 {
+		return znode, nil, nil
+	}
+
+	children, _, err := c.zkConn.Children(path)
+	if err != nil {
+		if errors.Is(err, ErrorZNodeDoesNotExist) {
+			return znode, nil, nil
+		}
+		return nil, nil, fmt.Errorf("failed to list children for ZNode '%s': %w", path, err)
+	}
+	sort.Strings(children)
+
+	return znode, children, nil
+}
+
+// walker reads a subtree ahead of the WalkFunc, with bounded concurrency, for WalkConcurrently.
+type walker struct {
+	maxDepth         int
+	skipUnauthorized bool
+	read             func(path string, listChildren bool) (*ZNode, []string, error)
+	semaphore        chan struct{}
+	stop             chan struct{}
+	wg               sync.WaitGroup
+}
+
+// walkerNode is a ZNode being read by the walker: its fields are set once `done` is closed.
+type walkerNode struct {
+	path     string
+	depth    int
+	done     chan struct{}
+	znode    *ZNode
+	children []*walkerNode
+	err      error
+}
+
+func newWalker(opts WalkOptions, read func(path string, listChildren bool) (*ZNode, []string, error)) *walker {
+	concurrency := opts.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	return &walker{
+		maxDepth:         opts.MaxDepth,
+		skipUnauthorized: opts.SkipUnauthorized,
+		read:             read,
+		semaphore:        make(chan struct{}, concurrency),
+		stop:             make(chan struct{}),
+	}
+}
+
+// walk reads the subtree at the given path and passes its ZNodes to walkFn, in order.
+//
+// Once done, reads still pending are abandoned, and walk waits for the ones in progress.
+func (w *walker) walk(path string, walkFn WalkFunc) error {
+	defer w.wg.Wait()
+	defer close(w.stop)
+
+	return w.visit(w.schedule(path, 0), walkFn)
+}
+
+// schedule starts reading the ZNode at the given path, once the concurrency allows it.
+//
+// Children are scheduled as soon as they are listed, so that reads run ahead of the visit.
+func (w *walker) schedule(path string, depth int) *walkerNode {
+	node := &walkerNode{path: path, depth: depth, done: make(chan struct{})}
+
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+		defer close(node.done)
+
+		select {
+		case w.semaphore <- struct{}{}:
+		case <-w.stop:
+			node.err = ErrorStopWalk
+			return
+		}
+		listChildren := w.maxDepth < 0 || depth < w.maxDepth
+		znode, children, err := w.read(path, listChildren)
+		<-w.semaphore
+
+		node.znode, node.err = znode, err
+		for _, child := range children {
+			node.children = append(node.children, w.schedule(JoinPath(path, child), depth+1))
+		}
+	}()
+
+	return node
+}
+
+// visit waits for the given node to be read, and passes it and then its descendants to walkFn.
+func (w *walker) visit(node *walkerNode, walkFn WalkFunc) error {
+	<-node.done
+
+	if node.err != nil {
+		// Deleted while the walk is in progress
+		if node.depth > 0 && errors.Is(node.err, ErrorZNodeDoesNotExist) {
+			return nil
+		}
+		// Not readable according to its ACL
+		if node.depth > 0 && w.skipUnauthorized && errors.Is(node.err, ErrorNotAuthorized) {
+			return nil
+		}
+		return node.err
+	}
+
+	if err := walkFn(node.znode, node.depth); err != nil {
+		return err
+	}
+
+	for _, child := range node.children {
+		if err := w.visit(child, walkFn); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
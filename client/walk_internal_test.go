@@ -0,0 +1,159 @@
+package client
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-zookeeper/zk"
+	testifyAssert "github.com/stretchr/testify/assert"
+)
+
+// fakeTree returns a walker read function over a tree of the given paths,
+// recording the maximum number of concurrent reads.
+func fakeTree(paths []string, inProgress, maxInProgress *atomic.Int32) func(string, bool) (*ZNode, []string, error) {
+	exists := map[string]bool{}
+	for _, path := range paths {
+		exists[path] = true
+	}
+
+	return func(path string, listChildren bool) (*ZNode, []string, error) {
+		current := inProgress.Add(1)
+		defer inProgress.Add(-1)
+		for peak := maxInProgress.Load(); current > peak && !maxInProgress.CompareAndSwap(peak, current); {
+			peak = maxInProgress.Load()
+		}
+		time.Sleep(time.Millisecond)
+
+		if !exists[path] {
+			return nil, nil, fmt.Errorf("failed to read ZNode '%s': %w", path, ErrorZNodeDoesNotExist)
+		}
+
+		children := []string{}
+		if listChildren {
+			prefix := strings.TrimSuffix(path, "/") + "/"
+			for _, candidate := range paths {
+				if name, ok := strings.CutPrefix(candidate, prefix); ok && !strings.Contains(name, "/") {
+					children = append(children, name)
+				}
+			}
+			sort.Strings(children)
+		}
+
+		return &ZNode{Path: path, Stat: &zk.Stat{}}, children, nil
+	}
+}
+
+func TestWalkerVisitsInOrderWithBoundedConcurrency(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	paths := []string{"/r"}
+	for i := 0; i < 10; i++ {
+		paths = append(paths, fmt.Sprintf("/r/%d", i))
+		for j := 0; j < 10; j++ {
+			paths = append(paths, fmt.Sprintf("/r/%d/%d", i, j))
+		}
+	}
+	// A child listed, but deleted before being read
+	paths = append(paths, "/r/5/gone")
+
+	var inProgress, maxInProgress atomic.Int32
+	read := fakeTree(paths, &inProgress, &maxInProgress)
+	w := newWalker(WalkOptions{MaxDepth: -1, Concurrency: 4}, func(path string, listChildren bool) (*ZNode, []string, error) {
+		if path == "/r/5/gone" {
+			return nil, nil, fmt.Errorf("failed to read ZNode '%s': %w", path, ErrorZNodeDoesNotExist)
+		}
+		return read(path, listChildren)
+	})
+
+	visited := []string{}
+	err := w.walk("/r", func(znode *ZNode, _ int) error {
+		visited = append(visited, znode.Path)
+		return nil
+	})
+	assert.NoError(err)
+
+	expected := append([]string{}, paths[:len(paths)-1]...)
+	assert.Equal(expected, visited)
+	assert.LessOrEqual(maxInProgress.Load(), int32(4))
+	assert.Greater(maxInProgress.Load(), int32(1))
+}
+
+func TestWalkerMaxDepthAndStop(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	paths := []string{"/r", "/r/a", "/r/a/deep", "/r/b", "/r/c"}
+	var inProgress, maxInProgress atomic.Int32
+
+	visited := []string{}
+	err := newWalker(WalkOptions{MaxDepth: 1, Concurrency: 2}, fakeTree(paths, &inProgress, &maxInProgress)).
+		walk("/r", func(znode *ZNode, _ int) error {
+			visited = append(visited, znode.Path)
+			return nil
+		})
+	assert.NoError(err)
+	assert.Equal([]string{"/r", "/r/a", "/r/b", "/r/c"}, visited)
+
+	visited = []string{}
+	err = newWalker(WalkOptions{MaxDepth: -1, Concurrency: 2}, fakeTree(paths, &inProgress, &maxInProgress)).
+		walk("/r", func(znode *ZNode, _ int) error {
+			if len(visited) == 2 {
+				return ErrorStopWalk
+			}
+			visited = append(visited, znode.Path)
+			return nil
+		})
+	assert.ErrorIs(err, ErrorStopWalk)
+	assert.Equal([]string{"/r", "/r/a"}, visited)
+	assert.Equal(int32(0), inProgress.Load())
+}
+
+func TestWalkerReportsErrors(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	var inProgress, maxInProgress atomic.Int32
+	err := newWalker(WalkOptions{MaxDepth: -1}, fakeTree([]string{"/r"}, &inProgress, &maxInProgress)).
+		walk("/missing", func(_ *ZNode, _ int) error {
+			return nil
+		})
+	assert.ErrorIs(err, ErrorZNodeDoesNotExist)
+}
+
+func TestWalkerSkipUnauthorized(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	paths := []string{"/r", "/r/a", "/r/locked", "/r/locked/child", "/r/z"}
+	var inProgress, maxInProgress atomic.Int32
+	read := fakeTree(paths, &inProgress, &maxInProgress)
+	readWithACL := func(path string, listChildren bool) (*ZNode, []string, error) {
+		if strings.HasPrefix(path, "/r/locked") {
+			return nil, nil, fmt.Errorf("failed to read ZNode '%s': %w", path, ErrorNotAuthorized)
+		}
+		return read(path, listChildren)
+	}
+
+	visited := []string{}
+	err := newWalker(WalkOptions{MaxDepth: -1, SkipUnauthorized: true}, readWithACL).
+		walk("/r", func(znode *ZNode, _ int) error {
+			visited = append(visited, znode.Path)
+			return nil
+		})
+	assert.NoError(err)
+	assert.Equal([]string{"/r", "/r/a", "/r/z"}, visited)
+
+	// The root is never skipped
+	err = newWalker(WalkOptions{MaxDepth: -1, SkipUnauthorized: true}, readWithACL).
+		walk("/r/locked", func(_ *ZNode, _ int) error {
+			return nil
+		})
+	assert.ErrorIs(err, ErrorNotAuthorized)
+
+	err = newWalker(WalkOptions{MaxDepth: -1}, readWithACL).
+		walk("/r", func(_ *ZNode, _ int) error {
+			return nil
+		})
+	assert.ErrorIs(err, ErrorNotAuthorized)
+}
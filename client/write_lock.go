@@ -0,0 +1,177 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	pathpkg "path"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-zookeeper/zk"
+)
+
+// ErrorWriteLockTimeout is returned by operations writing ZNodes, when the Client is configured to hold
+// a lock while writing (see WithWriteLock) and another one held it for longer than the configured timeout.
+var ErrorWriteLockTimeout = errors.New("timed out waiting for the write lock")
+
+// writeLockPrefix is the prefix of the Ephemeral Sequential ZNodes held by Clients waiting for, or holding, a write lock.
+const writeLockPrefix = "lock-"
+
+// WithWriteLock makes the Client acquire the lock at the given path before its first write (i.e. create, update, delete),
+// and hold it until its session ends (see Close): Clients configured with the same lock path
+// (ex. Terraform runs of different pipelines, against the same subtree) perform their writes one after the other.
+//
+// The lock follows the ZooKeeper lock recipe: each Client creates an Ephemeral Sequential ZNode under `path`,
+// and the lowest sequence number holds it. Writes waiting for the lock longer than `timeout` fail with ErrorWriteLockTimeout.
+//
+// The lock ZNodes are subject to the same restrictions as any other ZNode the Client creates (see WithAllowedPathPrefixes,
+// WithDeniedPathPrefixes): their ACL grants all permissions to anyone, or only to the identity of the Client
+// if world-open ACLs are denied (see WithWorldOpenACLsDenied). If the session expires, the lock is lost along with it:
+// the next write acquires it again.
+func WithWriteLock(path string, timeout time.Duration) ClientOption {
+	return func(c *Client) error {
+		if !strings.HasPrefix(path, zNodeRootPath) || path == zNodeRootPath || strings.HasSuffix(path, string(zNodePathSeparator)) {
+			return fmt.Errorf("write lock path '%s' must be absolute, and not '/' or end in '/'", path)
+		}
+
+		c.writeLock = &writeLock{path: path, timeout: timeout}
+		return nil
+	}
+}
+
+// writeLock is the lock a Client holds while writing (see WithWriteLock).
+type writeLock struct {
+	path    string
+	timeout time.Duration
+
+	mu sync.Mutex
+	// held is the path of the ZNode holding the lock, empty until acquired
+	held string
+	// lost is set when the session expires, deleting the ZNode holding the lock (see expireWriteLock)
+	lost atomic.Bool
+}
+
+// expireWriteLock is called on every zk.Event of the Client: once its session expires, the write lock,
+// if held, is marked as lost.
+//
+// It doesn't wait for writeLock.mu: the ZooKeeper library calls it while processing responses,
+// that a write waiting for the lock, holding the mutex, might be waiting for too.
+func (c *Client) expireWriteLock(event zk.Event) {
+	if c.writeLock == nil || event.Type != zk.EventSession || event.State != zk.StateExpired {
+		return
+	}
+
+	c.writeLock.lost.Store(true)
+}
+
+// writeLockACL returns the ACL of the lock ZNodes: open to anyone, so that Clients authenticated as different identities
+// can share the lock, unless world-open ACLs are denied (see WithWorldOpenACLsDenied).
+func (c *Client) writeLockACL() []zk.ACL {
+	if c.denyWorldOpenACLs {
+		return zk.AuthACL(zk.PermAll)
+	}
+
+	return zk.WorldACL(zk.PermAll)
+}
+
+// acquireWriteLock acquires the write lock of the Client, if configured and not already held.
+//
+// Concurrent writes wait for the first one to acquire it.
+func (c *Client) acquireWriteLock() error {
+	wl := c.writeLock
+	if wl == nil {
+		return nil
+	}
+
+	wl.mu.Lock()
+	defer wl.mu.Unlock()
+
+	// Marked as lost before checking if held: a session expiring from now on is noticed by the next write
+	if lost := wl.lost.Swap(false); wl.held != "" && !lost {
+		return nil
+	}
+	wl.held = ""
+
+	defer c.telemetry.record("AcquireWriteLock", wl.path, time.Now())
+
+	if err := c.paths.checkWrite(wl.path, true); err != nil {
+		return fmt.Errorf("failed to acquire write lock '%s': %w", wl.path, err)
+	}
+	acl := c.writeLockACL()
+	if err := c.CheckACL(acl); err != nil {
+		return fmt.Errorf("failed to acquire write lock '%s': %w", wl.path, err)
+	}
+
+	for _, path := range append(listParentsInOrder(wl.path), wl.path) {
+		// Parents outside of the allowed path prefixes, or protected, are left alone: they must exist already
+		if path != wl.path && c.paths.checkWrite(path, false) != nil {
+			continue
+		}
+		if _, err := c.zkConn.Create(path, nil, 0, acl); err != nil && !errors.Is(err, zk.ErrNodeExists) {
+			return fmt.Errorf("failed to create write lock ZNode '%s': %w", path, err)
+		}
+	}
+
+	ownPath, err := c.zkConn.CreateProtectedEphemeralSequential(JoinPath(wl.path, writeLockPrefix), nil, acl)
+	if err != nil {
+		return fmt.Errorf("failed to create write lock ZNode under '%s': %w", wl.path, err)
+	}
+
+	if err := c.awaitWriteLock(ownPath); err != nil {
+		// Best effort: the ZNode is deleted anyway when the session ends
+		_ = c.zkConn.Delete(ownPath, matchAnyVersion)
+		return err
+	}
+
+	wl.held = ownPath
+	return nil
+}
+
+// awaitWriteLock waits until the given ZNode has the lowest sequence number among the ones under the write lock path.
+func (c *Client) awaitWriteLock(ownPath string) error {
+	wl := c.writeLock
+	ownSeq, err := SequenceNumber(ownPath)
+	if err != nil {
+		return fmt.Errorf("failed to parse write lock ZNode '%s': %w", ownPath, err)
+	}
+
+	timeout := time.After(wl.timeout)
+	for {
+		children, _, err := c.zkConn.Children(wl.path)
+		if err != nil {
+			return fmt.Errorf("failed to list write lock ZNodes under '%s': %w", wl.path, err)
+		}
+
+		// The ZNode right before this one, if any, is the next to hold the lock
+		predecessor, predecessorSeq := "", int64(-1)
+		for _, child := range children {
+			seq, err := SequenceNumber(child)
+			if err != nil || !strings.Contains(child, writeLockPrefix) {
+				continue
+			}
+			if seq < ownSeq && seq > predecessorSeq {
+				predecessor, predecessorSeq = child, seq
+			}
+		}
+		if predecessor == "" {
+			return nil
+		}
+
+		exists, _, watch, err := c.zkConn.ExistsW(JoinPath(wl.path, predecessor))
+		if err != nil {
+			return fmt.Errorf("failed to watch write lock ZNode '%s': %w", predecessor, err)
+		}
+		if !exists {
+			continue
+		}
+
+		select {
+		case <-watch:
+		case <-timeout:
+			return fmt.Errorf("write lock '%s' not acquired after %s, still waiting for '%s': %w",
+				wl.path, wl.timeout, pathpkg.Base(predecessor), ErrorWriteLockTimeout)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-zookeeper/zk"
+	testifyAssert "github.com/stretchr/testify/assert"
+)
+
+func TestWithWriteLockValidatesPath(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	c := &Client{}
+	assert.NoError(WithWriteLock("/locks/team-a", time.Minute)(c))
+	assert.Equal("/locks/team-a", c.writeLock.path)
+
+	for _, path := range []string{"", "locks", "/", "/locks/"} {
+		assert.ErrorContains(WithWriteLock(path, time.Minute)(&Client{}), "must be absolute", path)
+	}
+
+	// Without a write lock, there is nothing to acquire
+	assert.NoError((&Client{}).acquireWriteLock())
+}
+
+func TestWriteLockGuarded(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	// Rejected before reaching ZooKeeper, like any other ZNode created by the Client
+	c := &Client{telemetry: newTelemetryRecorder()}
+	assert.NoError(WithWriteLock("/locks/team-a", time.Minute)(c))
+	assert.NoError(WithAllowedPathPrefixes([]string{"/team-a"})(c))
+	assert.ErrorIs(c.acquireWriteLock(), ErrorPathNotAllowed)
+
+	c = &Client{telemetry: newTelemetryRecorder()}
+	assert.NoError(WithWriteLock("/locks", time.Minute)(c))
+	assert.NoError(WithDeniedPathPrefixes([]string{"/locks/team-a"})(c))
+	assert.ErrorIs(c.acquireWriteLock(), ErrorPathProtected)
+
+	// Without world-open ACLs, lock ZNodes are restricted to the identity of the Client
+	c = &Client{}
+	assert.Equal(zk.WorldACL(zk.PermAll), c.writeLockACL())
+	assert.NoError(WithWorldOpenACLsDenied()(c))
+	assert.Equal(zk.AuthACL(zk.PermAll), c.writeLockACL())
+	assert.NoError(c.CheckACL(c.writeLockACL()))
+}
+
+func TestWriteLockLostWhenSessionExpires(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	c := &Client{}
+	assert.NoError(WithWriteLock("/locks/team-a", time.Minute)(c))
+	c.writeLock.held = "/locks/team-a/_c_0123-lock-0000000042"
+
+	// Only the expiration of the session loses the lock
+	c.expireWriteLock(zk.Event{Type: zk.EventSession, State: zk.StateDisconnected})
+	c.expireWriteLock(zk.Event{Type: zk.EventNodeDeleted, State: zk.StateExpired})
+	assert.False(c.writeLock.lost.Load())
+
+	c.expireWriteLock(zk.Event{Type: zk.EventSession, State: zk.StateExpired})
+	assert.True(c.writeLock.lost.Load())
+
+	// Without a write lock, there is nothing to lose
+	(&Client{}).expireWriteLock(zk.Event{Type: zk.EventSession, State: zk.StateExpired})
+}
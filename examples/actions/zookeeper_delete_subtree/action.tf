@@ -0,0 +1,19 @@
+action "zookeeper_delete_subtree" "legacy_registrations" {
+  config {
+    path      = "/services/legacy"
+    keep_root = true
+  }
+}
+
+# Invoked on demand via `terraform apply -invoke=action.zookeeper_delete_subtree.legacy_registrations`,
+# or every time the decommission is (re)applied:
+resource "terraform_data" "decommission_legacy" {
+  input = "2024-09-01"
+
+  lifecycle {
+    action_trigger {
+      events  = [after_create, after_update]
+      actions = [action.zookeeper_delete_subtree.legacy_registrations]
+    }
+  }
+}
@@ -0,0 +1,16 @@
+data "zookeeper_admin_command" "watches" {
+  url     = "http://zk1.example.com:8080"
+  command = "watch_summary"
+}
+
+output "zookeeper_watched_paths" {
+  value = tonumber(data.zookeeper_admin_command.watches.result["num_paths"])
+}
+
+data "zookeeper_admin_command" "connections" {
+  command = "connections"
+}
+
+output "zookeeper_connections" {
+  value = jsondecode(data.zookeeper_admin_command.connections.json).connections
+}
@@ -0,0 +1,5 @@
+data "zookeeper_connection" "current" {}
+
+output "zookeeper_session" {
+  value = "${data.zookeeper_connection.current.session_id} on ${data.zookeeper_connection.current.server} (${data.zookeeper_connection.current.role}), timeout ${data.zookeeper_connection.current.session_timeout_ms}ms"
+}
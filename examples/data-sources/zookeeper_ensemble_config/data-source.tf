@@ -0,0 +1,8 @@
+data "zookeeper_ensemble_config" "ensemble" {}
+
+output "zookeeper_connect_string" {
+  value = join(",", [
+    for server in data.zookeeper_ensemble_config.ensemble.participants :
+    "${server.address}:${server.client_port}"
+  ])
+}
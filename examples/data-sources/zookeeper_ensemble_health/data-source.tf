@@ -0,0 +1,15 @@
+# Fails if any of the servers the provider is configured with is unhealthy
+data "zookeeper_ensemble_health" "ensemble" {
+  require_healthy = true
+}
+
+resource "zookeeper_znode" "feature_flag" {
+  depends_on = [data.zookeeper_ensemble_health.ensemble]
+
+  path = "/flags/new-checkout"
+  data = "enabled"
+}
+
+output "zookeeper_leader" {
+  value = data.zookeeper_ensemble_health.ensemble.leader
+}
@@ -0,0 +1,11 @@
+data "zookeeper_hbase_cluster" "main" {
+  znode_parent = "/hbase"
+}
+
+output "hbase_active_master" {
+  value = one(data.zookeeper_hbase_cluster.main.master[*].host)
+}
+
+output "hbase_region_servers" {
+  value = data.zookeeper_hbase_cluster.main.region_server_addresses
+}
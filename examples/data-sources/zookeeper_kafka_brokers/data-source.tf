@@ -0,0 +1,13 @@
+data "zookeeper_kafka_brokers" "main" {
+  # Kafka configured with `zookeeper.connect=zk-server-01:2181/kafka`
+  chroot   = "/kafka"
+  listener = "SSL"
+}
+
+output "kafka_bootstrap_servers" {
+  value = data.zookeeper_kafka_brokers.main.bootstrap_servers
+}
+
+output "kafka_broker_racks" {
+  value = { for b in data.zookeeper_kafka_brokers.main.brokers : b.id => b.rack }
+}
@@ -0,0 +1,8 @@
+data "zookeeper_patroni_leader" "orders_db" {
+  namespace = "/service"
+  scope     = "orders-db"
+}
+
+output "orders_db_primary" {
+  value = "${data.zookeeper_patroni_leader.orders_db.host}:${data.zookeeper_patroni_leader.orders_db.port}"
+}
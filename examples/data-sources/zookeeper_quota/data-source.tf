@@ -0,0 +1,14 @@
+data "zookeeper_quota" "tenant" {
+  path = "/tenants/acme"
+
+  lifecycle {
+    postcondition {
+      condition     = !self.znodes_exceeded && !self.bytes_exceeded
+      error_message = "The ZNodes of tenant 'acme' exceed their quota."
+    }
+  }
+}
+
+output "tenant_znodes_usage" {
+  value = "${data.zookeeper_quota.tenant.znodes} of ${data.zookeeper_quota.tenant.znodes_hard_limit}"
+}
@@ -0,0 +1,12 @@
+data "zookeeper_server_version" "current" {}
+
+locals {
+  # Persistent recursive watches and TTL nodes are available since ZooKeeper 3.6
+  zookeeper_3_6_plus = data.zookeeper_server_version.current.major > 3 || (
+    data.zookeeper_server_version.current.major == 3 && data.zookeeper_server_version.current.minor >= 6
+  )
+}
+
+output "zookeeper_version" {
+  value = data.zookeeper_server_version.current.version
+}
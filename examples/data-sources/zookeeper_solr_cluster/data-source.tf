@@ -0,0 +1,16 @@
+data "zookeeper_solr_cluster" "search" {
+  # Solr configured with `zkHost=zk-server-01:2181/solr`
+  chroot = "/solr"
+}
+
+output "solr_live_nodes" {
+  value = data.zookeeper_solr_cluster.search.live_node_addresses
+}
+
+output "solr_shard_leaders" {
+  value = {
+    for c in data.zookeeper_solr_cluster.search.collections : c.name => flatten([
+      for s in c.shards : [for r in s.replicas : r.base_url if r.leader]
+    ])
+  }
+}
@@ -0,0 +1,18 @@
+data "zookeeper_znode_children" "workers" {
+  path = "/services/workers"
+}
+
+locals {
+  workers_stat = {
+    for child in data.zookeeper_znode_children.workers.children : child.name => child.stat[0]
+  }
+
+  # Registration ZNodes bigger than 1KiB
+  oversized_workers = [
+    for name, stat in local.workers_stat : name if stat.data_length > 1024
+  ]
+}
+
+output "workers" {
+  value = data.zookeeper_znode_children.workers.names
+}
@@ -0,0 +1,10 @@
+data "zookeeper_znode_export" "config" {
+  path        = "/config/critical"
+  stat_fields = ["version", "mtime"]
+}
+
+resource "aws_s3_object" "config_backup" {
+  bucket  = "zookeeper-backups"
+  key     = "config-critical.json"
+  content = data.zookeeper_znode_export.config.json
+}
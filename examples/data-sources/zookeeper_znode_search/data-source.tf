@@ -0,0 +1,11 @@
+# Find all the ZNodes, under `/legacy`, that still point at the old database
+data "zookeeper_znode_search" "old_database" {
+  path          = "/legacy"
+  data_contains = "db-old.example.com"
+  max_depth     = 5
+  max_results   = 50
+}
+
+output "znodes_to_migrate" {
+  value = data.zookeeper_znode_search.old_database.paths
+}
@@ -0,0 +1,15 @@
+data "zookeeper_znodes" "services" {
+  paths = [
+    "/services/frontend/config",
+    "/services/backend/config",
+    "/services/worker/config",
+  ]
+}
+
+output "frontend_config" {
+  value = data.zookeeper_znodes.services.data["/services/frontend/config"]
+}
+
+output "backend_config_version" {
+  value = data.zookeeper_znodes.services.znodes[1].stat[0].version
+}
@@ -0,0 +1,8 @@
+variable "endpoint" {
+  default = "https://api.example.com/v1"
+}
+
+resource "zookeeper_znode" "endpoint_registration" {
+  # Results in `/endpoints/https:%2F%2Fapi.example.com%2Fv1`
+  path = "/endpoints/${provider::zookeeper::path_escape(var.endpoint)}"
+}
@@ -0,0 +1,9 @@
+locals {
+  service = "orders"
+}
+
+resource "zookeeper_znode" "service_config" {
+  # Results in `/config/orders/settings`
+  path = provider::zookeeper::path_join("/config/", local.service, "settings")
+  data = "..."
+}
@@ -0,0 +1,8 @@
+data "zookeeper_znode_children" "endpoints" {
+  path = "/endpoints"
+}
+
+output "endpoints" {
+  # Results in `["https://api.example.com/v1"]` for `/endpoints/https:%2F%2Fapi.example.com%2Fv1`
+  value = [for name in data.zookeeper_znode_children.endpoints.names : provider::zookeeper::path_unescape(name)]
+}
@@ -0,0 +1,9 @@
+resource "zookeeper_sequential_znode" "job" {
+  path_prefix = "/jobs/job-"
+  data        = "..."
+}
+
+output "job_number" {
+  # Ex. `42` for `/jobs/job-0000000042`
+  value = provider::zookeeper::sequence_number(zookeeper_sequential_znode.job.path)
+}
@@ -0,0 +1,11 @@
+# Enumerate all the ZNodes under `/forza`, up to 2 levels deep.
+# Use `terraform query -generate-config-out=znodes.tf` to generate
+# the `import` blocks and `zookeeper_znode` resources to adopt them.
+list "zookeeper_znode" "forza" {
+  provider = zookeeper
+
+  config {
+    path      = "/forza"
+    max_depth = 2
+  }
+}
@@ -0,0 +1 @@
+$ terraform import zookeeper_ensemble_member.example 4
@@ -0,0 +1,23 @@
+# Scaling the Ensemble out to 5 servers, one incremental reconfiguration each:
+# servers 4 and 5 must be running, and configured to join the Ensemble, before they are added
+resource "zookeeper_ensemble_member" "server" {
+  for_each = {
+    4 = "zk4.example.com"
+    5 = "zk5.example.com"
+  }
+
+  server_id     = each.key
+  address       = each.value
+  quorum_port   = 2888
+  election_port = 3888
+  client_port   = 2181
+}
+
+resource "zookeeper_ensemble_member" "observer" {
+  server_id     = 6
+  address       = "zk6.dc2.example.com"
+  quorum_port   = 2888
+  election_port = 3888
+  role          = "observer"
+  client_port   = 2181
+}
@@ -0,0 +1,17 @@
+# Deletes, on each apply, the registrations of jobs finished more than 3 days ago
+resource "zookeeper_gc" "finished_jobs" {
+  path        = "/batch/jobs"
+  max_age     = "72h"
+  max_deletes = 500
+}
+
+# Reports the stale registrations, without deleting them
+resource "zookeeper_gc" "legacy_jobs" {
+  path    = "/legacy/jobs"
+  max_age = "720h"
+  dry_run = true
+}
+
+output "legacy_jobs_to_collect" {
+  value = zookeeper_gc.legacy_jobs.pending
+}
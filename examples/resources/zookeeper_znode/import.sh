@@ -1 +1,4 @@
 $ terraform import zookeeper_znode.example /zookeeper/path/to/znode
+
+# ZNodes with special characters in their names are imported by their path as is, unescaped
+$ terraform import zookeeper_znode.example '/endpoints/100% café'
@@ -4,8 +4,51 @@ resource "zookeeper_znode" "napoli" {
   data = "Sempre!"
 }
 
+# Declared under its parent: created after it, without `depends_on`
+resource "zookeeper_znode" "napoli_captain" {
+  parent_path = zookeeper_znode.napoli.path
+  name        = "captain"
+  data        = "Di Lorenzo"
+}
+
 # Base64 encoded content
 resource "zookeeper_znode" "napoli_logo" {
   path        = "/forza/napoli/logo"
   data_base64 = filebase64("logo.png")
 }
+
+# Hexadecimal encoded content (ex. a magic number)
+resource "zookeeper_znode" "napoli_magic" {
+  path     = "/forza/napoli/magic"
+  data_hex = "cafebabe"
+}
+
+# Retry operations on a ZNode critical to bootstrap, while the Ensemble settles
+resource "zookeeper_znode" "napoli_bootstrap" {
+  path = "/forza/napoli/bootstrap"
+  data = "ready"
+
+  retry {
+    retries        = 10
+    retry_interval = "2s"
+  }
+}
+
+# Restart the consumers of a configuration exactly when its content changes
+resource "zookeeper_znode" "napoli_config" {
+  path = "/forza/napoli/config"
+  data = jsonencode({ formation = "4-3-3" })
+}
+
+resource "terraform_data" "napoli_restart" {
+  lifecycle {
+    replace_triggered_by = [zookeeper_znode.napoli_config.content_version]
+  }
+}
+
+# Applications watch a single ZNode, written after any of the configurations changes
+resource "zookeeper_znode" "napoli_lineup" {
+  path        = "/forza/napoli/lineup"
+  data        = jsonencode({ captain = "Di Lorenzo" })
+  notify_path = "/forza/napoli/version"
+}
@@ -0,0 +1,126 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/tfzk/terraform-provider-zookeeper/client"
+)
+
+// deleteSubtreeAction is the zookeeper_delete_subtree action (Terraform 1.14+).
+type deleteSubtreeAction struct {
+	zkClient *client.Client
+}
+
+type deleteSubtreeActionModel struct {
+	Path     types.String `tfsdk:"path"`
+	KeepRoot types.Bool   `tfsdk:"keep_root"`
+}
+
+var (
+	_ action.ActionWithConfigure      = &deleteSubtreeAction{}
+	_ action.ActionWithValidateConfig = &deleteSubtreeAction{}
+)
+
+func newDeleteSubtreeAction() action.Action {
+	return &deleteSubtreeAction{}
+}
+
+func (a *deleteSubtreeAction) Metadata(_ context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_delete_subtree"
+}
+
+func (a *deleteSubtreeAction) Configure(_ context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	configureActionClient(req, resp, &a.zkClient)
+}
+
+func (a *deleteSubtreeAction) Schema(_ context.Context, _ action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"path": schema.StringAttribute{
+				Required: true,
+				Description: "Absolute path to the ZNode at the root of the subtree to delete. " +
+					"Can't be `/`, nor `" + systemZNodesPath + "` or any ZNode under it.",
+			},
+			"keep_root": schema.BoolAttribute{
+				Optional: true,
+				Description: "If `true`, only the descendants of `path` are deleted, and the ZNode at `path` is kept. " +
+					"Defaults to `false`.",
+			},
+		},
+		Description: "Deletes an entire subtree of " + zNodeLinkForDesc + "s, " +
+			"without modeling it as a stateful resource (ex. to clean up the registrations of a decommissioned service). " +
+			"Succeeds without changes if `path` doesn't exist. " +
+			"The ability to delete ZNodes is determined by ZooKeeper ACL.",
+	}
+}
+
+func (a *deleteSubtreeAction) ValidateConfig(ctx context.Context, req action.ValidateConfigRequest, resp *action.ValidateConfigResponse) {
+	var model deleteSubtreeActionModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() || model.Path.IsUnknown() || model.Path.IsNull() {
+		return
+	}
+
+	znodePath := model.Path.ValueString()
+	if znodePath == "/" || isSystemZNodePath(znodePath) {
+		resp.Diagnostics.AddAttributeError(path.Root("path"), "Invalid 'path'",
+			fmt.Sprintf("Deleting the subtree of ZNode '%s' is not allowed", znodePath))
+	}
+}
+
+func (a *deleteSubtreeAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var model deleteSubtreeActionModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	znodePath := model.Path.ValueString()
+
+	exists, err := a.zkClient.Exists(znodePath)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to delete subtree", withHint(
+			fmt.Sprintf("Unable to delete subtree of ZNode '%s': %v", znodePath, err),
+			zkErrorHint(a.zkClient, zNodeOperationRead, znodePath, err),
+		))
+		return
+	}
+	if !exists {
+		resp.SendProgress(action.InvokeProgressEvent{Message: fmt.Sprintf("ZNode '%s' does not exist: nothing to delete", znodePath)})
+		return
+	}
+
+	toDelete := []string{znodePath}
+	if model.KeepRoot.ValueBool() {
+		children, err := a.zkClient.ReadChildrenStats(znodePath)
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to delete subtree", withHint(
+				fmt.Sprintf("Unable to delete subtree of ZNode '%s': %v", znodePath, err),
+				zkErrorHint(a.zkClient, zNodeOperationRead, znodePath, err),
+			))
+			return
+		}
+
+		toDelete = make([]string, 0, len(children))
+		for _, child := range children {
+			toDelete = append(toDelete, child.Path)
+		}
+	}
+
+	for _, deletePath := range toDelete {
+		resp.SendProgress(action.InvokeProgressEvent{Message: fmt.Sprintf("Deleting ZNode '%s' and its descendants", deletePath)})
+
+		if err := a.zkClient.Delete(deletePath); err != nil {
+			resp.Diagnostics.AddError("Unable to delete subtree", withHint(
+				fmt.Sprintf("Unable to delete subtree of ZNode '%s': %v", znodePath, err),
+				zkErrorHint(a.zkClient, zNodeOperationDelete, deletePath, err),
+			))
+			return
+		}
+	}
+}
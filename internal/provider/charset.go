@@ -0,0 +1,99 @@
+package provider
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/ianaindex"
+	"golang.org/x/text/encoding/unicode"
+)
+
+// charsetSchema provides the *schema.Schema of the `charset` attribute (see encodeData and decodeData).
+func charsetSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		ValidateFunc: validateCharset,
+		Description: "Character set of the content of the ZNode, as an [IANA name](https://www.iana.org/assignments/character-sets/character-sets.xhtml) " +
+			"(ex. `ISO-8859-1`, `Shift_JIS`): `data` is converted from it when reading, and to it when writing. " +
+			"Useful for legacy ZNodes, not encoded in UTF-8. If not set, `data` is UTF-8. `data_base64` and `data_hex` are never converted.",
+	}
+}
+
+// charsetEncoding returns the encoding.Encoding of the given IANA character set name:
+// unicode.UTF8 if empty, meaning no conversion is needed.
+func charsetEncoding(charset string) (encoding.Encoding, error) {
+	if charset == "" {
+		return unicode.UTF8, nil
+	}
+
+	enc, err := ianaindex.IANA.Encoding(charset)
+	if err != nil {
+		return nil, fmt.Errorf("unknown charset '%s': %w", charset, err)
+	}
+	if enc == nil {
+		return nil, fmt.Errorf("charset '%s' is not supported", charset)
+	}
+
+	return enc, nil
+}
+
+// validateCharset is a schema.SchemaValidateFunc that confirms the value is a supported character set (see charsetEncoding).
+func validateCharset(value interface{}, key string) ([]string, []error) {
+	charset, ok := value.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of '%s' to be string", key)}
+	}
+
+	if _, err := charsetEncoding(charset); err != nil {
+		return nil, []error{fmt.Errorf("expected '%s' to be a supported IANA character set name (ex. 'ISO-8859-1'): %w", key, err)}
+	}
+
+	return nil, nil
+}
+
+// getCharsetFromResourceData returns the `charset` of the given resourceDataGetter,
+// or an empty string if it has none (i.e. the content is UTF-8).
+func getCharsetFromResourceData(rscData resourceDataGetter) string {
+	charset, _ := rscData.Get("charset").(string)
+	return charset
+}
+
+// encodeData converts the given `data` from UTF-8 to the given charset, failing if it contains characters
+// the charset can't represent.
+func encodeData(data string, charset string) ([]byte, error) {
+	enc, err := charsetEncoding(charset)
+	if err != nil {
+		return nil, err
+	}
+	if enc == unicode.UTF8 {
+		return []byte(data), nil
+	}
+
+	dataBytes, err := enc.NewEncoder().Bytes([]byte(data))
+	if err != nil {
+		return nil, fmt.Errorf("converting 'data' to charset '%s' failed: %w", charset, err)
+	}
+
+	return dataBytes, nil
+}
+
+// decodeData converts the given content of a ZNode from the given charset to UTF-8, as `data`.
+func decodeData(dataBytes []byte, charset string) (string, error) {
+	enc, err := charsetEncoding(charset)
+	if err != nil {
+		return "", err
+	}
+	// Converting from UTF-8 would replace invalid bytes: the content is kept as it is instead
+	if enc == unicode.UTF8 {
+		return string(dataBytes), nil
+	}
+
+	data, err := enc.NewDecoder().Bytes(dataBytes)
+	if err != nil {
+		return "", fmt.Errorf("converting content from charset '%s' failed: %w", charset, err)
+	}
+
+	return string(data), nil
+}
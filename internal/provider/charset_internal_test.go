@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"testing"
+
+	testifyAssert "github.com/stretchr/testify/assert"
+)
+
+func TestEncodeDecodeData(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	for charset, expected := range map[string][]byte{
+		"":           []byte("Città"),
+		"UTF-8":      []byte("Città"),
+		"ISO-8859-1": {'C', 'i', 't', 't', 0xe0},
+		"latin1":     {'C', 'i', 't', 't', 0xe0},
+	} {
+		dataBytes, err := encodeData("Città", charset)
+		assert.NoError(err, charset)
+		assert.Equal(expected, dataBytes, charset)
+
+		data, err := decodeData(dataBytes, charset)
+		assert.NoError(err, charset)
+		assert.Equal("Città", data, charset)
+	}
+
+	dataBytes, err := encodeData("ナポリ", "Shift_JIS")
+	assert.NoError(err)
+	assert.Equal([]byte{0x83, 0x69, 0x83, 0x7c, 0x83, 0x8a}, dataBytes)
+	data, err := decodeData(dataBytes, "Shift_JIS")
+	assert.NoError(err)
+	assert.Equal("ナポリ", data)
+
+	// Not representable in the charset
+	_, err = encodeData("ナポリ", "ISO-8859-1")
+	assert.ErrorContains(err, "converting 'data' to charset 'ISO-8859-1' failed")
+
+	// Content not valid UTF-8 is kept as it is
+	data, err = decodeData([]byte{0xff, 0x00}, "")
+	assert.NoError(err)
+	assert.Equal("\xff\x00", data)
+}
+
+func TestValidateCharset(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	for _, valid := range []string{"", "UTF-8", "utf-8", "ISO-8859-1", "Shift_JIS", "windows-1252"} {
+		_, errs := validateCharset(valid, "charset")
+		assert.Empty(errs, valid)
+	}
+
+	for _, invalid := range []string{"klingon", "UTF-9"} {
+		_, errs := validateCharset(invalid, "charset")
+		assert.Len(errs, 1, invalid)
+	}
+}
@@ -1,27 +1,241 @@
 package provider
 
 import (
+	"context"
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"math"
+	pathpkg "path"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/go-zookeeper/zk"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/tfzk/terraform-provider-zookeeper/internal/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/tfzk/terraform-provider-zookeeper/client"
 )
 
 const (
-	zNodeLinkForDesc = "[ZooKeeper ZNode](https://zookeeper.apache.org/doc/current/zookeeperProgrammers.html#sc_zkDataModel_znodes)"
+	zNodeLinkForDesc    = "[ZooKeeper ZNode](https://zookeeper.apache.org/doc/current/zookeeperProgrammers.html#sc_zkDataModel_znodes)"
+	durationLinkForDesc = "[Go duration string](https://pkg.go.dev/time#ParseDuration)"
+
+	// systemZNodesPath is the root of the ZNodes managed by ZooKeeper itself (ex. `/zookeeper/config`).
+	systemZNodesPath = "/zookeeper"
 )
 
+// isSystemZNodePath returns true if the given path is, or is under, systemZNodesPath.
+func isSystemZNodePath(znodePath string) bool {
+	return znodePath == systemZNodesPath || strings.HasPrefix(znodePath, systemZNodesPath+"/")
+}
+
+// checkPathWritable returns a schema.CustomizeDiffFunc that fails the plan if the ZNode path in the given field
+// is outside of the provider `allowed_path_prefixes`, or protected (see `denied_paths`).
+// Paths not known yet are checked when applying.
+func checkPathWritable(field string) schema.CustomizeDiffFunc {
+	return func(_ context.Context, diff *schema.ResourceDiff, prvClient interface{}) error {
+		zkClient, ok := prvClient.(*client.Client)
+		if !ok || !diff.NewValueKnown(field) {
+			return nil
+		}
+
+		// Optional fields (ex. `notify_path`) might not be set
+		path := diff.Get(field).(string)
+		if path == "" {
+			return nil
+		}
+
+		if err := zkClient.CheckWritePath(path); err != nil {
+			return fmt.Errorf("invalid '%s': %w", field, err)
+		}
+
+		return nil
+	}
+}
+
+// setPathFromPartsWhenKnown returns a schema.CustomizeDiffFunc that sets the computed `path` of a ZNode
+// declared via `parent_path` and `name`, so that it's known when planning, if they are.
+//
+// It must run before the other schema.CustomizeDiffFunc(s) reading `path`.
+func setPathFromPartsWhenKnown() schema.CustomizeDiffFunc {
+	return func(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+		rawConfig := diff.GetRawConfig()
+		if !rawConfig.IsKnown() || rawConfig.IsNull() || !rawConfig.GetAttr("path").IsNull() {
+			return nil
+		}
+
+		if !diff.NewValueKnown("parent_path") || !diff.NewValueKnown("name") {
+			if err := diff.SetNewComputed("path"); err != nil {
+				return fmt.Errorf("failed to set 'path' as computed: %w", err)
+			}
+			return nil
+		}
+
+		znodePath := client.JoinPath(diff.Get("parent_path").(string), diff.Get("name").(string))
+		if diff.Id() != "" && diff.Get("path").(string) == znodePath {
+			return nil
+		}
+		if err := diff.SetNew("path", znodePath); err != nil {
+			return fmt.Errorf("failed to set 'path': %w", err)
+		}
+
+		return nil
+	}
+}
+
+// getPathFromResourceData returns the `path` of a ZNode, or the one composed of its `parent_path` and `name`,
+// if not known when planning (see setPathFromPartsWhenKnown).
+func getPathFromResourceData(rscData *schema.ResourceData) string {
+	if znodePath := rscData.Get("path").(string); znodePath != "" {
+		return znodePath
+	}
+
+	return client.JoinPath(rscData.Get("parent_path").(string), rscData.Get("name").(string))
+}
+
+// setPathPartsWhenKnown returns a schema.CustomizeDiffFunc that sets the computed `parent_path` and `name`
+// of a ZNode being created (or replaced), from the path in the given field, so that they are known when planning.
+// Paths not known yet are split when applying (see setPathPartsFromZNode).
+func setPathPartsWhenKnown(field string) schema.CustomizeDiffFunc {
+	return func(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+		if !diff.NewValueKnown(field) || (diff.Id() != "" && !diff.HasChange(field)) {
+			return nil
+		}
+
+		parentPath, name := splitZNodePath(diff.Get(field).(string))
+		if err := diff.SetNew("parent_path", parentPath); err != nil {
+			return fmt.Errorf("failed to set 'parent_path': %w", err)
+		}
+		if err := diff.SetNew("name", name); err != nil {
+			return fmt.Errorf("failed to set 'name': %w", err)
+		}
+
+		return nil
+	}
+}
+
+// checkACLAllowed returns a schema.CustomizeDiffFunc that fails the plan if the ZNode is being created or updated
+// with a world-open `acl`, and the provider is configured with `deny_world_open_acls`.
+// ACLs not known yet are checked when applying.
+func checkACLAllowed() schema.CustomizeDiffFunc {
+	return func(_ context.Context, diff *schema.ResourceDiff, prvClient interface{}) error {
+		zkClient, ok := prvClient.(*client.Client)
+		if !ok || (diff.Id() != "" && !diff.HasChanges("data", "data_base64", "data_hex", "charset", "acl")) {
+			return nil
+		}
+
+		// When creating a ZNode without `acl`, the computed value is not known yet: it will be the default one
+		aclConfigs := diff.Get("acl").([]interface{})
+		if rawACL := diff.GetRawConfig().GetAttr("acl"); diff.Id() == "" && rawACL.IsKnown() && (rawACL.IsNull() || rawACL.LengthInt() == 0) {
+			aclConfigs = nil
+		} else if !diff.NewValueKnown("acl") {
+			return nil
+		}
+
+		acls, err := parseACLs(aclConfigs)
+		if err != nil {
+			// Reported when applying
+			return nil
+		}
+		if err := zkClient.CheckACL(acls); err != nil {
+			return fmt.Errorf("invalid 'acl': %w", err)
+		}
+
+		return nil
+	}
+}
+
+// warnWorldOpenACL is a schema.ValidateRawResourceConfigFunc that warns if the configured `acl`
+// grants `world:anyone` the permission to modify the ZNode (see client.WorldOpenACLEntries),
+// including when `acl` is not configured, as ZooKeeper's default ACL does.
+func warnWorldOpenACL(_ context.Context, req schema.ValidateResourceConfigFuncRequest, resp *schema.ValidateResourceConfigFuncResponse) {
+	rawACL := req.RawConfig.GetAttr("acl")
+	if !rawACL.IsWhollyKnown() {
+		return
+	}
+
+	var acls []zk.ACL
+	if !rawACL.IsNull() {
+		for it := rawACL.ElementIterator(); it.Next(); {
+			_, entry := it.Element()
+			scheme, id, perms := entry.GetAttr("scheme"), entry.GetAttr("id"), entry.GetAttr("permissions")
+			if scheme.IsNull() || id.IsNull() || perms.IsNull() {
+				continue
+			}
+
+			permsValue, _ := perms.AsBigFloat().Int64()
+			acls = append(acls, zk.ACL{Scheme: scheme.AsString(), ID: id.AsString(), Perms: int32(permsValue)})
+		}
+	}
+
+	detail := "The `acl` grants `world:anyone` the permission to modify the ZNode: anyone able to connect to ZooKeeper can change, " +
+		"or delete, it."
+	if len(acls) == 0 {
+		acls = zk.WorldACL(zk.PermAll)
+		detail = "No `acl` is configured: the ZNode gets ZooKeeper's default ACL, granting `world:anyone` all permissions. " +
+			"Anyone able to connect to ZooKeeper can change, or delete, it."
+	}
+
+	if len(client.WorldOpenACLEntries(acls)) > 0 {
+		resp.Diagnostics = append(resp.Diagnostics, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "World-open ACL",
+			Detail: detail + " Restrict it (ex. `world:anyone` with only READ permission, and `digest` or `auth` for writers). " +
+				"Set the provider `deny_world_open_acls` to fail instead.",
+			AttributePath: cty.GetAttrPath("acl"),
+		})
+	}
+}
+
+// warnSequentialSuffix is a schema.ValidateRawResourceConfigFunc that warns if the configured `path`
+// (or the one composed of `parent_path` and `name`) ends with a sequential suffix (see client.SequenceNumber): most likely, it was copied from a Sequential ZNode
+// that is then managed by two Resources, corrupting the state of both.
+func warnSequentialSuffix(_ context.Context, req schema.ValidateResourceConfigFuncRequest, resp *schema.ValidateResourceConfigFuncResponse) {
+	rawPath, attribute := req.RawConfig.GetAttr("path"), "path"
+	if rawPath.IsNull() {
+		// Declared via `parent_path` and `name`, instead
+		rawParentPath, rawName := req.RawConfig.GetAttr("parent_path"), req.RawConfig.GetAttr("name")
+		if !rawParentPath.IsKnown() || rawParentPath.IsNull() || !rawName.IsKnown() || rawName.IsNull() {
+			return
+		}
+		rawPath, attribute = cty.StringVal(client.JoinPath(rawParentPath.AsString(), rawName.AsString())), "name"
+	}
+	if !rawPath.IsKnown() {
+		return
+	}
+
+	path := rawPath.AsString()
+	if _, err := client.SequenceNumber(path); err != nil {
+		return
+	}
+
+	resp.Diagnostics = append(resp.Diagnostics, diag.Diagnostic{
+		Severity: diag.Warning,
+		Summary:  "Path with sequential suffix",
+		Detail: fmt.Sprintf("The `path` '%[1]s' ends with a 10-digit counter, like the ones ZooKeeper appends to Sequential ZNodes. "+
+			"If it was created by a `zookeeper_sequential_znode`, manage it via that Resource instead "+
+			"(ex. `terraform import zookeeper_sequential_znode.<name> %[1]s`): managing it via both corrupts their state.", path),
+		AttributePath: cty.GetAttrPath(attribute),
+	})
+}
+
 // setAttributesFromZNode takes a *client.ZNode and populates the *schema.ResourceData with its content.
 func setAttributesFromZNode(rscData *schema.ResourceData, znode *client.ZNode, diags diag.Diagnostics) diag.Diagnostics {
 	if err := rscData.Set("path", znode.Path); err != nil {
 		diags = append(diags, diag.FromErr(err)...)
 	}
 
-	if err := rscData.Set("data", string(znode.Data)); err != nil {
+	data, err := decodeData(znode.Data, getCharsetFromResourceData(rscData))
+	if err != nil {
+		diags = append(diags, diag.FromErr(err)...)
+	}
+	if err := rscData.Set("data", data); err != nil {
 		diags = append(diags, diag.FromErr(err)...)
 	}
 
@@ -29,10 +243,35 @@ func setAttributesFromZNode(rscData *schema.ResourceData, znode *client.ZNode, d
 		diags = append(diags, diag.FromErr(err)...)
 	}
 
-	if err := rscData.Set("stat", []interface{}{zNodeStatToMap(znode)}); err != nil {
+	if err := rscData.Set("data_hex", hex.EncodeToString(znode.Data)); err != nil {
+		diags = append(diags, diag.FromErr(err)...)
+	}
+
+	if err := rscData.Set("content_version", contentVersion(znode.Data)); err != nil {
+		diags = append(diags, diag.FromErr(err)...)
+	}
+
+	stat := []interface{}{}
+	if tracksStat(rscData) {
+		stat = append(stat, zNodeStatToMap(znode))
+	}
+	if err := rscData.Set("stat", stat); err != nil {
+		diags = append(diags, diag.FromErr(err)...)
+	}
+
+	if err := rscData.Set("is_ephemeral", znode.Stat.EphemeralOwner != 0); err != nil {
 		diags = append(diags, diag.FromErr(err)...)
 	}
 
+	if err := rscData.Set("ephemeral_owner", ephemeralOwnerToString(znode.Stat.EphemeralOwner)); err != nil {
+		diags = append(diags, diag.FromErr(err)...)
+	}
+
+	// ACL not read (see provider `skip_acl_read`): leave it as it is
+	if znode.ACL == nil {
+		return diags
+	}
+
 	// Convert ACLs from []zk.ACL to []map[string]interface{}
 	aclConfigs := make([]map[string]interface{}, 0, len(znode.ACL))
 	for _, acl := range znode.ACL {
@@ -51,6 +290,93 @@ func setAttributesFromZNode(rscData *schema.ResourceData, znode *client.ZNode, d
 	return diags
 }
 
+// setPathPartsFromZNode sets the `parent_path` and `name` attributes of a Resource, from the path of the given ZNode.
+func setPathPartsFromZNode(rscData *schema.ResourceData, znode *client.ZNode, diags diag.Diagnostics) diag.Diagnostics {
+	parentPath, name := splitZNodePath(znode.Path)
+
+	if err := rscData.Set("parent_path", parentPath); err != nil {
+		diags = append(diags, diag.FromErr(err)...)
+	}
+
+	if err := rscData.Set("name", name); err != nil {
+		diags = append(diags, diag.FromErr(err)...)
+	}
+
+	return diags
+}
+
+// splitZNodePath splits the given absolute ZNode path into the path of its parent and its name:
+// ex. `/forza/napoli` into `/forza` and `napoli`, and `/forza` into `/` and `forza`.
+func splitZNodePath(znodePath string) (string, string) {
+	return pathpkg.Dir(znodePath), pathpkg.Base(znodePath)
+}
+
+// splitSequentialPathPrefix splits the given `path_prefix` of a Sequential ZNode into the path of its parent,
+// and the prefix of its name: ex. `/forza/napoli-` into `/forza` and `napoli-`.
+// If it ends with `/`, the prefix of the name is empty (i.e. the name is just the sequential suffix).
+func splitSequentialPathPrefix(pathPrefix string) (string, string) {
+	idx := strings.LastIndexByte(pathPrefix, '/')
+	if idx <= 0 {
+		return "/", pathPrefix[idx+1:]
+	}
+
+	return pathPrefix[:idx], pathPrefix[idx+1:]
+}
+
+// validateSequentialPathPrefix is a schema.SchemaValidateFunc that confirms the value
+// is a valid `path_prefix` for a Sequential ZNode: absolute, and without empty or relative (i.e. `.` and `..`)
+// segments in the path of its parent.
+func validateSequentialPathPrefix(value interface{}, key string) ([]string, []error) {
+	pathPrefix, ok := value.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of '%s' to be string", key)}
+	}
+
+	if !strings.HasPrefix(pathPrefix, "/") {
+		return nil, []error{fmt.Errorf("expected '%s' to be an absolute path (ex. '/forza/napoli-'), got '%s'", key, pathPrefix)}
+	}
+
+	// The path of the created ZNode must be valid: the sequential suffix never makes it so
+	if createdPath := pathPrefix + "0000000000"; createdPath != pathpkg.Clean(createdPath) {
+		return nil, []error{fmt.Errorf("expected '%s' to not contain empty or relative segments, got '%s'", key, pathPrefix)}
+	}
+
+	return nil, nil
+}
+
+// validatePath is a schema.SchemaValidateFunc that confirms the value is a valid ZNode path (see client.ValidatePath),
+// so that names ZooKeeper would reject fail at plan time.
+func validatePath(value interface{}, key string) ([]string, []error) {
+	path, ok := value.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of '%s' to be string", key)}
+	}
+
+	if err := client.ValidatePath(path); err != nil {
+		return nil, []error{fmt.Errorf("expected '%s' to be a valid ZNode path (see the `path_escape` function): %w", key, err)}
+	}
+
+	return nil, nil
+}
+
+// validateName is a schema.SchemaValidateFunc that confirms the value is a valid ZNode name,
+// i.e. a single segment of a valid ZNode path (see validatePath).
+func validateName(value interface{}, key string) ([]string, []error) {
+	name, ok := value.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of '%s' to be string", key)}
+	}
+
+	if name == "" || strings.ContainsRune(name, '/') {
+		return nil, []error{fmt.Errorf("expected '%s' to be a non-empty ZNode name, without '/' (see the `path_escape` function)", key)}
+	}
+	if err := client.ValidatePath("/" + name); err != nil {
+		return nil, []error{fmt.Errorf("expected '%s' to be a valid ZNode name (see the `path_escape` function): %w", key, err)}
+	}
+
+	return nil, nil
+}
+
 // statSchema provides the *schema.Schema to represent the ZNode Stat Structure.
 // For more info: https://zookeeper.apache.org/doc/r3.5.9/zookeeperProgrammers.html#sc_zkStatStructure.
 func statSchema() *schema.Schema {
@@ -121,6 +447,144 @@ func statSchema() *schema.Schema {
 	}
 }
 
+// lightRefreshSchema provides the *schema.Schema of the `light_refresh` attribute (see readZNodeForRefresh).
+func lightRefreshSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+		Description: "If `true`, refreshing compares the `stat` of the ZNode with the one in the state first: " +
+			"data and ACL are downloaded only if changed since (ex. a different `mzxid`), " +
+			"instead of on every plan. Useful for large ZNodes that rarely change.",
+	}
+}
+
+// trackStatSchema provides the *schema.Schema of the `track_stat` attribute (see tracksStat).
+func trackStatSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  true,
+		Description: "If `false`, the `stat` of the ZNode is not stored in the state, and stays empty: " +
+			"for frequently modified ZNodes, whose `stat` (ex. `mtime`, `mzxid`) changes on every refresh, " +
+			"updating the state and causing noise in tools inspecting it. " +
+			"`light_refresh` has no effect without the `stat`. Defaults to `true`.",
+	}
+}
+
+// tracksStat returns false if the Resource is configured with `track_stat = false` (see trackStatSchema).
+//
+// The configuration is preferred, as known when creating or updating. When refreshing, only the state is:
+// a `null` one, as when importing or written before `track_stat` existed, counts as its default.
+func tracksStat(rscData *schema.ResourceData) bool {
+	for _, raw := range []cty.Value{rscData.GetRawConfig(), rscData.GetRawState()} {
+		if !raw.IsKnown() || raw.IsNull() || !raw.Type().HasAttribute("track_stat") {
+			continue
+		}
+		if trackStat := raw.GetAttr("track_stat"); trackStat.IsKnown() && !trackStat.IsNull() {
+			return trackStat.True()
+		}
+	}
+
+	return true
+}
+
+// Values of the `on_missing` attribute (see onMissingSchema).
+const (
+	onMissingRecreate = "recreate"
+	onMissingFail     = "fail"
+)
+
+// onMissingSchema provides the *schema.Schema of the `on_missing` attribute (see readZNodeOfResource).
+func onMissingSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		ValidateFunc: validation.StringInSlice([]string{onMissingRecreate, onMissingFail}, false),
+		Description: "What refreshing does if the ZNode no longer exists (ex. deleted outside of Terraform): " +
+			"`" + onMissingRecreate + "` (default) removes it from the state, with a warning, so that the next apply creates it again; " +
+			"`" + onMissingFail + "` fails instead, so that accidental deletions of critical ZNodes are caught rather than silently recreated. " +
+			"To proceed, restore the ZNode, or remove the resource from the state (ex. via `terraform state rm`) for the next apply to create it.",
+	}
+}
+
+// readZNodeForRefresh reads the ZNode of a Resource, to refresh its state.
+//
+// With `light_refresh`, the ZNode as in the state is read again only if changed since (see client.ReadIfChanged).
+func readZNodeForRefresh(zkClient *client.Client, rscData *schema.ResourceData) (*client.ZNode, error) {
+	if rscData.Get("light_refresh").(bool) {
+		if known := zNodeFromState(rscData); known != nil {
+			return zkClient.ReadIfChanged(known)
+		}
+	}
+
+	return zkClient.Read(rscData.Id())
+}
+
+// zNodeFromState returns the ZNode of a Resource as in the state, or `nil` if its `stat` is not known (ex. when importing).
+//
+// Only the fields of the zk.Stat compared by client.ReadIfChanged are populated.
+func zNodeFromState(rscData *schema.ResourceData) *client.ZNode {
+	stats := rscData.Get("stat").([]interface{})
+	if len(stats) == 0 || stats[0] == nil {
+		return nil
+	}
+	stat := stats[0].(map[string]interface{})
+
+	data, err := base64.StdEncoding.DecodeString(rscData.Get("data_base64").(string))
+	if err != nil {
+		return nil
+	}
+
+	znode := &client.ZNode{
+		Path: rscData.Id(),
+		Stat: &zk.Stat{
+			Czxid:    int64(stat["czxid"].(int)),
+			Mzxid:    int64(stat["mzxid"].(int)),
+			Aversion: int32(stat["aversion"].(int)), //nolint:gosec // Read from a zk.Stat in the first place
+		},
+		Data: data,
+	}
+	if aclConfigs := rscData.Get("acl").([]interface{}); len(aclConfigs) > 0 {
+		if znode.ACL, err = parseACLs(aclConfigs); err != nil {
+			return nil
+		}
+	}
+
+	return znode
+}
+
+// isEphemeralSchema provides the *schema.Schema of the `is_ephemeral` attribute.
+func isEphemeralSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeBool,
+		Computed: true,
+		Description: "Whether the ZNode is ephemeral, i.e. it's bound to the session of a client (ex. the registration of an application), " +
+			"and will be deleted when that session ends.",
+	}
+}
+
+// ephemeralOwnerSchema provides the *schema.Schema of the `ephemeral_owner` attribute.
+func ephemeralOwnerSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+		Description: "The ID of the session owning the ZNode, as hexadecimal string (ex. `0x100000a2b3c0001`), if the ZNode is ephemeral. " +
+			"Empty otherwise.",
+	}
+}
+
+// ephemeralOwnerToString formats the ID of the session owning an ephemeral ZNode,
+// the same way ZooKeeper does (ex. `0x100000a2b3c0001`).
+// It returns an empty string for persistent ZNodes (i.e. `ephemeralOwner == 0`).
+func ephemeralOwnerToString(ephemeralOwner int64) string {
+	if ephemeralOwner == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("0x%x", ephemeralOwner)
+}
+
 // zNodeStatToMap is a helper that returns the zk.Stat contained to in client.ZNode,
 // in the form of Terraform Schema compliant map.
 func zNodeStatToMap(z *client.ZNode) map[string]interface{} {
@@ -139,28 +603,273 @@ func zNodeStatToMap(z *client.ZNode) map[string]interface{} {
 	}
 }
 
-// getDataBytesFromResourceData reads the `data` or `data_base64` fields from the given *schema.ResourceData.
+// statFieldNames returns the names of the fields of the ZNode Stat Structure, as defined by statSchema.
+func statFieldNames() []string {
+	return sortedKeys(statSchema().Elem.(*schema.Resource).Schema)
+}
+
+// resourceDataGetter reads the attributes of a Resource: both *schema.ResourceData and,
+// when planning, *schema.ResourceDiff implement it.
+type resourceDataGetter interface {
+	Get(key string) interface{}
+	GetOk(key string) (interface{}, bool)
+	GetRawConfig() cty.Value
+}
+
+// dataAttributes returns the attributes holding the content of a ZNode, mutually exclusive in the configuration.
+func dataAttributes() []string {
+	return []string{"data", "data_base64", "data_hex"}
+}
+
+// configuredDataAttribute returns which of dataAttributes is set in the given raw configuration, if any:
+// unlike schema.ResourceData.GetOk, it tells an empty content (ex. `data = ""`) apart from an unset one.
+func configuredDataAttribute(rawConfig cty.Value) (string, bool) {
+	if !rawConfig.IsKnown() || rawConfig.IsNull() {
+		return "", false
+	}
+
+	for _, attribute := range dataAttributes() {
+		if rawConfig.Type().HasAttribute(attribute) && !rawConfig.GetAttr(attribute).IsNull() {
+			return attribute, true
+		}
+	}
+
+	return "", false
+}
+
+// getDataBytesFromResourceData reads the `data`, `data_base64` or `data_hex` fields from the given resourceDataGetter:
+// `data` is converted to its `charset`, if any.
 //
-// If both fields are not set, it returns `nil` bytes, meaning the ZNode related to this resource/data-source
+// The one set in the configuration is preferred, even if empty: the others are computed, and might still hold the previous content.
+// If none is set, it returns `nil` bytes, meaning the ZNode related to this resource/data-source
 // has no content.
-func getDataBytesFromResourceData(rscData *schema.ResourceData) ([]byte, error) {
-	if dataRaw, exists := rscData.GetOk("data"); exists {
-		return []byte(dataRaw.(string)), nil
+func getDataBytesFromResourceData(rscData resourceDataGetter) ([]byte, error) {
+	fields := dataAttributes()
+	configured, _ := configuredDataAttribute(rscData.GetRawConfig())
+	for i, field := range fields {
+		if field == configured {
+			fields[0], fields[i] = fields[i], fields[0]
+			break
+		}
+	}
+
+	for _, field := range fields {
+		dataRaw, exists := rscData.GetOk(field)
+		// An empty content counts only if explicitly set (ex. `data = ""`)
+		if !exists && field != configured {
+			continue
+		}
+
+		switch field {
+		case "data_base64":
+			dataBytes, err := base64.StdEncoding.DecodeString(dataRaw.(string))
+			if err != nil {
+				return nil, fmt.Errorf("decoding 'data_base64' from Base64 failed: %w", err)
+			}
+			return dataBytes, nil
+		case "data_hex":
+			dataBytes, err := hex.DecodeString(dataRaw.(string))
+			if err != nil {
+				return nil, fmt.Errorf("decoding 'data_hex' from hexadecimal failed: %w", err)
+			}
+			return dataBytes, nil
+		default:
+			return encodeData(dataRaw.(string), getCharsetFromResourceData(rscData))
+		}
+	}
+
+	return nil, nil
+}
+
+// setEmptyDataWhenConfigured returns a schema.CustomizeDiffFunc that plans to empty the content of a ZNode,
+// when any of dataAttributes is set to an empty string in the configuration.
+//
+// As they are Computed, SDKv2 treats an empty string like an unset attribute: the content would be left as in the state,
+// and emptying it (or changes made outside of Terraform, once empty) never planned.
+func setEmptyDataWhenConfigured() schema.CustomizeDiffFunc {
+	return func(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+		configured, ok := configuredDataAttribute(diff.GetRawConfig())
+		if !ok || diff.Id() == "" {
+			return nil
+		}
+		if value := diff.GetRawConfig().GetAttr(configured); !value.IsKnown() || value.AsString() != "" {
+			return nil
+		}
+
+		for _, attribute := range dataAttributes() {
+			if diff.Get(attribute).(string) == "" {
+				continue
+			}
+			if err := diff.SetNew(attribute, ""); err != nil {
+				return fmt.Errorf("failed to set '%s': %w", attribute, err)
+			}
+		}
+
+		return nil
 	}
+}
+
+// contentVersionSchema provides the *schema.Schema of the `content_version` attribute (see contentVersion).
+func contentVersionSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+		Description: "SHA-256 of the content of the ZNode, as hexadecimal: it changes exactly when the content does " +
+			"(unlike `stat.version`, that changes on every write, even of the same content). " +
+			"Meant to be referenced by `replace_triggered_by`, or the `triggers` of other resources, to replace them when the content changes.",
+	}
+}
+
+// contentVersion returns the `content_version` of the given content of a ZNode.
+func contentVersion(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// setContentVersionWhenKnown returns a schema.CustomizeDiffFunc that sets the computed `content_version`
+// of a ZNode whose content is set in the configuration, so that it is known when planning:
+// resources replaced via `replace_triggered_by` are replaced in the same apply.
+//
+// It must run after setEmptyDataWhenConfigured.
+func setContentVersionWhenKnown() schema.CustomizeDiffFunc {
+	return func(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+		configured, ok := configuredDataAttribute(diff.GetRawConfig())
+		if !ok {
+			return nil
+		}
+		if !diff.NewValueKnown(configured) || !diff.NewValueKnown("charset") {
+			if diff.Id() != "" && diff.HasChanges(configured, "charset") {
+				if err := diff.SetNewComputed("content_version"); err != nil {
+					return fmt.Errorf("failed to set 'content_version': %w", err)
+				}
+			}
+			return nil
+		}
 
-	if dataRawBase64, exists := rscData.GetOk("data_base64"); exists {
-		dataBytes, err := base64.StdEncoding.DecodeString(dataRawBase64.(string))
+		dataBytes, err := getDataBytesFromResourceData(diff)
 		if err != nil {
-			return nil, fmt.Errorf("decoding 'data_base64' from Base64 failed: %w", err)
+			return nil //nolint:nilerr // Invalid content fails when applying, with a more specific error
+		}
+
+		if version := contentVersion(dataBytes); diff.Get("content_version").(string) != version {
+			if err := diff.SetNew("content_version", version); err != nil {
+				return fmt.Errorf("failed to set 'content_version': %w", err)
+			}
+		}
+
+		return nil
+	}
+}
+
+// replaceOnDataChangeSchema provides the *schema.Schema of the `replace_on_data_change` attribute (see forceNewOnDataChange).
+func replaceOnDataChangeSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+		Description: "If `true`, changing the content of the ZNode deletes and creates it again, instead of updating it: " +
+			"for consumers that only watch for ZNodes being created or deleted (i.e. `NodeCreated` and `NodeDeleted` events), " +
+			"that would miss updates (i.e. `NodeDataChanged`). Its children, if any, are deleted along with it. Defaults to `false`.",
+	}
+}
+
+// forceNewOnDataChange returns a schema.CustomizeDiffFunc that, with `replace_on_data_change`,
+// replaces the ZNode when its content changes (i.e. its `content_version`).
+//
+// It must run after setContentVersionWhenKnown.
+func forceNewOnDataChange() schema.CustomizeDiffFunc {
+	return func(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+		if diff.Id() == "" || !diff.Get("replace_on_data_change").(bool) || !diff.HasChange("content_version") {
+			return nil
 		}
-		return dataBytes, nil
+
+		if err := diff.ForceNew("content_version"); err != nil {
+			return fmt.Errorf("failed to replace on change of 'content_version': %w", err)
+		}
+
+		return nil
+	}
+}
+
+// notifyPathSchema provides the *schema.Schema of the `notify_path` attribute (see notifyZNodeChange).
+func notifyPathSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		ValidateFunc: validatePath,
+		Description: "Absolute path to a \"notification\" ZNode, to write the current time to (ex. `2024-05-04T18:30:00.123Z`) " +
+			"after the ZNode is created, its content changes, or it's deleted: applications can watch it, instead of every ZNode " +
+			"they read. It's created if missing, with the same `acl` as the ZNode.",
+	}
+}
+
+// notifyZNodeChange writes the current time to the ZNode at `notify_path`, if set, after the content
+// of the ZNode managed by the Resource changed. The notification ZNode is created, if missing.
+func notifyZNodeChange(ctx context.Context, rscData *schema.ResourceData, zkClient *client.Client, diags diag.Diagnostics) diag.Diagnostics {
+	// Not all Resources have `notify_path` (ex. zookeeper_sequential_znode)
+	notifyPath, _ := rscData.Get("notify_path").(string)
+	if notifyPath == "" {
+		return diags
+	}
+
+	acls, err := parseACLsFromResourceData(rscData)
+	if err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+
+	retryPolicy, err := getRetryPolicyFromRetryBlock(rscData)
+	if err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+
+	data := []byte(time.Now().UTC().Format(time.RFC3339Nano))
+	err = zkClient.Retry(ctx, retryPolicy, func() error {
+		_, notifyErr := zkClient.Update(notifyPath, data, nil)
+		if errors.Is(notifyErr, client.ErrorZNodeDoesNotExist) {
+			_, notifyErr = zkClient.Create(notifyPath, data, acls)
+		}
+		return notifyErr
+	})
+	if err != nil {
+		hint := zkErrorHint(zkClient, zNodeOperationUpdate, notifyPath, err) +
+			" The ZNode '" + rscData.Id() + "' was changed anyway: the applications watching the notification ZNode " +
+			"might need to be notified manually."
+		return append(diags, zkErrorf(strings.TrimSpace(hint),
+			"Failed to notify change via ZNode '%s': %v", notifyPath, err)...)
+	}
+
+	return diags
+}
+
+// validateHex is a schema.SchemaValidateFunc that confirms the value is a sequence of bytes,
+// each as 2 hexadecimal digits (ex. `cafe00`).
+func validateHex(value interface{}, key string) ([]string, []error) {
+	hexStr, ok := value.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of '%s' to be string", key)}
+	}
+
+	if _, err := hex.DecodeString(hexStr); err != nil {
+		return nil, []error{fmt.Errorf("expected '%s' to be hexadecimal, 2 digits per byte (ex. 'cafe00'): %w", key, err)}
 	}
 
 	return nil, nil
 }
 
+// suppressHexCaseDiff is a schema.SchemaDiffSuppressFunc ignoring changes of case of hexadecimal digits:
+// `data_hex` is always read in lowercase.
+func suppressHexCaseDiff(_, oldValue, newValue string, _ *schema.ResourceData) bool {
+	return strings.EqualFold(oldValue, newValue)
+}
+
 func parseACLsFromResourceData(rscData *schema.ResourceData) ([]zk.ACL, error) {
-	aclConfigs := rscData.Get("acl").([]interface{})
+	return parseACLs(rscData.Get("acl").([]interface{}))
+}
+
+// parseACLs converts the entries of the `acl` field into []zk.ACL.
+//
+// If there are no entries, it returns ZooKeeper's default ACL, granting all permissions to `world:anyone`.
+func parseACLs(aclConfigs []interface{}) ([]zk.ACL, error) {
 	acls := make([]zk.ACL, 0, len(aclConfigs))
 
 	for _, aclConfig := range aclConfigs {
@@ -189,3 +898,163 @@ func parseACLsFromResourceData(rscData *schema.ResourceData) ([]zk.ACL, error) {
 
 	return acls, nil
 }
+
+// validateDuration is a schema.SchemaValidateFunc that confirms the value
+// can be parsed by time.ParseDuration.
+func validateDuration(value interface{}, key string) ([]string, []error) {
+	durationStr, ok := value.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of '%s' to be string", key)}
+	}
+
+	if _, err := time.ParseDuration(durationStr); err != nil {
+		return nil, []error{fmt.Errorf("expected '%s' to be a valid duration (ex. '30s', '5m'): %w", key, err)}
+	}
+
+	return nil, nil
+}
+
+// retriesSchema provides the *schema.Schema to configure how many times a read should be retried,
+// when failing because of a transient error (ex. connection loss).
+func retriesSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:             schema.TypeInt,
+		Optional:         true,
+		Default:          0,
+		ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
+		Description: "How many times to retry reading, if it fails because of a transient error " +
+			"(ex. connection loss, session expiration). Other errors are only retried if listed in `retry_error_classes`.",
+	}
+}
+
+// retryIntervalSchema provides the *schema.Schema to configure how long to wait between retries.
+func retryIntervalSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:             schema.TypeString,
+		Optional:         true,
+		Default:          "1s",
+		ValidateDiagFunc: validation.ToDiagFunc(validateDuration),
+		Description:      "How long to wait between `retries`. Expressed as a " + durationLinkForDesc + " (ex. `500ms`, `2s`).",
+	}
+}
+
+// retryErrorClassesSchema provides the *schema.Schema to configure how many times a read should be retried,
+// for each class of error (see client.ErrorClass).
+func retryErrorClassesSchema() *schema.Schema {
+	classes := make([]string, 0, len(client.ErrorClasses()))
+	for _, class := range client.ErrorClasses() {
+		classes = append(classes, string(class))
+	}
+
+	return &schema.Schema{
+		Type:     schema.TypeMap,
+		Optional: true,
+		Elem: &schema.Schema{
+			Type: schema.TypeInt,
+		},
+		ValidateDiagFunc: validation.MapKeyMatch(regexp.MustCompile("^("+strings.Join(classes, "|")+")$"),
+			"expected one of: "+strings.Join(classes, ", ")),
+		Description: "How many times to retry reading, by class of error: " +
+			"`" + strings.Join(classes, "`, `") + "` " +
+			"(ex. `{ no_node = 5 }` to wait for a ZNode to be created). " +
+			"Each class has its own budget of retries, all separated by `retry_interval`. " +
+			"For `connection_loss`, it overrides `retries`. Classes not listed are never retried.",
+	}
+}
+
+// defaultWalkConcurrency is the default value of the `concurrency` field (see walkConcurrencySchema).
+const defaultWalkConcurrency = 16
+
+// walkConcurrencySchema provides the *schema.Schema to configure how many ZNodes of a subtree
+// are read concurrently (see client.WalkConcurrently).
+func walkConcurrencySchema() *schema.Schema {
+	return &schema.Schema{
+		Type:             schema.TypeInt,
+		Optional:         true,
+		Default:          defaultWalkConcurrency,
+		ValidateDiagFunc: validation.ToDiagFunc(validation.IntBetween(1, 256)),
+		Description: fmt.Sprintf("Maximum number of ZNodes of the subtree read concurrently (default: `%d`). "+
+			"Results don't depend on it: `1` reads one ZNode at a time.", defaultWalkConcurrency),
+	}
+}
+
+// retryBlockSchema provides the *schema.Schema of the `retry` block, to configure how a Resource
+// should retry the operations on its ZNode.
+func retryBlockSchema() *schema.Schema {
+	retries := retriesSchema()
+	retries.Description = strings.Replace(retries.Description, "retry reading", "retry an operation", 1)
+
+	errorClasses := retryErrorClassesSchema()
+	errorClasses.Description = strings.Replace(errorClasses.Description, "retry reading", "retry an operation", 1)
+
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"retries":             retries,
+				"retry_interval":      retryIntervalSchema(),
+				"retry_error_classes": errorClasses,
+			},
+		},
+		Description: "How to retry the operations on the ZNode (create, read, update, delete), when they fail " +
+			"(ex. more patient retries for a ZNode critical to bootstrap). Defaults to no retries. " +
+			"Note that a write retried after a connection loss might find out it was applied already " +
+			"(ex. failing with `node_exists`).",
+	}
+}
+
+// getRetryPolicyFromResourceData reads the `retries`, `retry_interval` and `retry_error_classes`
+// fields from the given *schema.ResourceData.
+func getRetryPolicyFromResourceData(rscData *schema.ResourceData) (client.RetryPolicy, error) {
+	return newRetryPolicy(map[string]interface{}{
+		"retries":             rscData.Get("retries"),
+		"retry_interval":      rscData.Get("retry_interval"),
+		"retry_error_classes": rscData.Get("retry_error_classes"),
+	})
+}
+
+// getRetryPolicyFromRetryBlock reads the `retry` block (see retryBlockSchema) from the given *schema.ResourceData.
+//
+// If the block is absent, the returned client.RetryPolicy doesn't retry.
+func getRetryPolicyFromRetryBlock(rscData *schema.ResourceData) (client.RetryPolicy, error) {
+	// Not all Resources have a `retry` block (ex. zookeeper_sequential_znode)
+	retryBlock, _ := rscData.Get("retry").([]interface{})
+	if len(retryBlock) == 0 || retryBlock[0] == nil {
+		return client.RetryPolicy{}, nil
+	}
+
+	return newRetryPolicy(retryBlock[0].(map[string]interface{}))
+}
+
+// newRetryPolicy creates a client.RetryPolicy from the values of
+// the `retries`, `retry_interval` and `retry_error_classes` fields.
+func newRetryPolicy(fields map[string]interface{}) (client.RetryPolicy, error) {
+	interval, err := time.ParseDuration(fields["retry_interval"].(string))
+	if err != nil {
+		return client.RetryPolicy{}, fmt.Errorf("parsing 'retry_interval' failed: %w", err)
+	}
+
+	classRetries := map[client.ErrorClass]int{}
+	for class, retries := range fields["retry_error_classes"].(map[string]interface{}) {
+		classRetries[client.ErrorClass(class)] = retries.(int)
+	}
+
+	return client.RetryPolicy{
+		Retries:      fields["retries"].(int),
+		Interval:     interval,
+		ClassRetries: classRetries,
+	}, nil
+}
+
+// sortedKeys returns the keys of the given map, sorted.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,85 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/tfzk/terraform-provider-zookeeper/client"
+)
+
+// durationValidator is the terraform-plugin-framework equivalent of validateDuration.
+type durationValidator struct{}
+
+var _ validator.String = durationValidator{}
+
+func (v durationValidator) Description(_ context.Context) string {
+	return "value must be a Go duration string (ex. `30s`, `5m`)"
+}
+
+func (v durationValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v durationValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := time.ParseDuration(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid duration",
+			fmt.Sprintf("Expected %q to be a valid duration: %v", req.ConfigValue.ValueString(), err))
+	}
+}
+
+// clientFromProviderData extracts the *client.Client configured by frameworkProvider,
+// for terraform-plugin-framework Data Sources and Resources.
+//
+// It returns `nil` if the provider is not configured yet.
+func clientFromProviderData(providerData any) (*client.Client, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if providerData == nil {
+		return nil, diags
+	}
+
+	zkClient, ok := providerData.(*client.Client)
+	if !ok {
+		diags.AddError("Unexpected provider data", fmt.Sprintf("Expected *client.Client, got: %T", providerData))
+	}
+
+	return zkClient, diags
+}
+
+// configureDataSourceClient is a helper to implement datasource.DataSourceWithConfigure.
+func configureDataSourceClient(req datasource.ConfigureRequest, resp *datasource.ConfigureResponse, zkClient **client.Client) {
+	c, diags := clientFromProviderData(req.ProviderData)
+	resp.Diagnostics.Append(diags...)
+	if c != nil {
+		*zkClient = c
+	}
+}
+
+// configureResourceClient is a helper to implement resource.ResourceWithConfigure
+// and list.ListResourceWithConfigure.
+func configureResourceClient(req resource.ConfigureRequest, resp *resource.ConfigureResponse, zkClient **client.Client) {
+	c, diags := clientFromProviderData(req.ProviderData)
+	resp.Diagnostics.Append(diags...)
+	if c != nil {
+		*zkClient = c
+	}
+}
+
+// configureActionClient is a helper to implement action.ActionWithConfigure.
+func configureActionClient(req action.ConfigureRequest, resp *action.ConfigureResponse, zkClient **client.Client) {
+	c, diags := clientFromProviderData(req.ProviderData)
+	resp.Diagnostics.Append(diags...)
+	if c != nil {
+		*zkClient = c
+	}
+}
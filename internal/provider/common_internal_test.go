@@ -0,0 +1,259 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	ctyjson "github.com/hashicorp/go-cty/cty/json"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	testifyAssert "github.com/stretchr/testify/assert"
+	"github.com/tfzk/terraform-provider-zookeeper/client"
+)
+
+func TestWarnSequentialSuffix(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	warningsOf := func(path, parentPath, name cty.Value) int {
+		resp := &schema.ValidateResourceConfigFuncResponse{}
+		req := schema.ValidateResourceConfigFuncRequest{RawConfig: cty.ObjectVal(map[string]cty.Value{
+			"path":        path,
+			"parent_path": parentPath,
+			"name":        name,
+		})}
+		warnSequentialSuffix(context.Background(), req, resp)
+		return len(resp.Diagnostics)
+	}
+	warnings := func(path cty.Value) int {
+		return warningsOf(path, cty.NullVal(cty.String), cty.NullVal(cty.String))
+	}
+
+	assert.Equal(1, warnings(cty.StringVal("/forza/napoli-0000000042")))
+	assert.Equal(1, warnings(cty.StringVal("/forza/0000000042")))
+	assert.Equal(1, warnings(cty.StringVal("/forza/napoli--000000001")))
+	assert.Equal(0, warnings(cty.StringVal("/forza/napoli")))
+	assert.Equal(0, warnings(cty.StringVal("/forza/napoli-42")))
+	assert.Equal(0, warnings(cty.UnknownVal(cty.String)))
+	assert.Equal(0, warnings(cty.NullVal(cty.String)))
+
+	// Declared via `parent_path` and `name`
+	assert.Equal(1, warningsOf(cty.NullVal(cty.String), cty.StringVal("/forza"), cty.StringVal("napoli-0000000042")))
+	assert.Equal(0, warningsOf(cty.NullVal(cty.String), cty.StringVal("/forza"), cty.StringVal("napoli")))
+	assert.Equal(0, warningsOf(cty.NullVal(cty.String), cty.UnknownVal(cty.String), cty.StringVal("napoli-0000000042")))
+}
+
+func TestSplitSequentialPathPrefix(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	parentPath, namePrefix := splitSequentialPathPrefix("/forza/napoli-")
+	assert.Equal("/forza", parentPath)
+	assert.Equal("napoli-", namePrefix)
+
+	parentPath, namePrefix = splitSequentialPathPrefix("/forza/")
+	assert.Equal("/forza", parentPath)
+	assert.Equal("", namePrefix)
+
+	parentPath, namePrefix = splitSequentialPathPrefix("/napoli-")
+	assert.Equal("/", parentPath)
+	assert.Equal("napoli-", namePrefix)
+
+	parentPath, namePrefix = splitSequentialPathPrefix("/")
+	assert.Equal("/", parentPath)
+	assert.Equal("", namePrefix)
+}
+
+func TestValidateSequentialPathPrefix(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	for _, valid := range []string{"/", "/napoli-", "/forza/", "/forza/napoli-", "/forza/napoli/."} {
+		_, errs := validateSequentialPathPrefix(valid, "path_prefix")
+		assert.Empty(errs, valid)
+	}
+
+	for _, invalid := range []string{"", "napoli-", "forza/napoli-", "//napoli-", "/forza//", "/forza/./napoli-", "/forza/../napoli-"} {
+		_, errs := validateSequentialPathPrefix(invalid, "path_prefix")
+		assert.Len(errs, 1, invalid)
+	}
+}
+
+func TestValidatePath(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	for _, valid := range []string{"/", "/forza/napoli", "/forza napoli/100%", "/città"} {
+		_, errs := validatePath(valid, "path")
+		assert.Empty(errs, valid)
+	}
+
+	for _, invalid := range []string{"", "forza", "/forza/", "/forza/../napoli", "/tab\there"} {
+		_, errs := validatePath(invalid, "path")
+		assert.Len(errs, 1, invalid)
+	}
+}
+
+func TestValidateName(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	for _, valid := range []string{"napoli", "forza napoli", "100%", "città", "napoli-0000000042"} {
+		_, errs := validateName(valid, "name")
+		assert.Empty(errs, valid)
+	}
+
+	for _, invalid := range []string{"", ".", "..", "forza/napoli", "/napoli", "tab\there"} {
+		_, errs := validateName(invalid, "name")
+		assert.Len(errs, 1, invalid)
+	}
+}
+
+func TestGetRetryPolicyWithoutRetryBlock(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	// zookeeper_sequential_znode shares the CRUD of zookeeper_znode, but has no `retry` block
+	rscData := schema.TestResourceDataRaw(t, resourceSeqZNode().Schema, map[string]interface{}{"path_prefix": "/forza/napoli-"})
+	policy, err := getRetryPolicyFromRetryBlock(rscData)
+	assert.NoError(err)
+	assert.Equal(client.RetryPolicy{}, policy)
+}
+
+func TestGetDataBytesFromResourceData(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	for config, expected := range map[string][]byte{
+		"data":        []byte("Forza Napoli!"),
+		"data_base64": []byte("Forza Napoli!"),
+		"data_hex":    {0xca, 0xfe, 0x00},
+	} {
+		value := map[string]string{"data": "Forza Napoli!", "data_base64": "Rm9yemEgTmFwb2xpIQ==", "data_hex": "CAFE00"}[config]
+		rscData := schema.TestResourceDataRaw(t, resourceZNode().Schema, map[string]interface{}{"path": "/forza", config: value})
+
+		data, err := getDataBytesFromResourceData(rscData)
+		assert.NoError(err, config)
+		assert.Equal(expected, data, config)
+	}
+
+	rscData := schema.TestResourceDataRaw(t, resourceZNode().Schema, map[string]interface{}{"path": "/forza", "data_hex": "napoli"})
+	_, err := getDataBytesFromResourceData(rscData)
+	assert.ErrorContains(err, "decoding 'data_hex' from hexadecimal failed")
+}
+
+func TestConfiguredDataAttribute(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	config := func(data, dataBase64 cty.Value) cty.Value {
+		return cty.ObjectVal(map[string]cty.Value{"data": data, "data_base64": dataBase64, "data_hex": cty.NullVal(cty.String)})
+	}
+
+	attribute, ok := configuredDataAttribute(config(cty.StringVal(""), cty.NullVal(cty.String)))
+	assert.True(ok)
+	assert.Equal("data", attribute)
+
+	attribute, ok = configuredDataAttribute(config(cty.NullVal(cty.String), cty.StringVal("Rm9yemEgTmFwb2xpIQ==")))
+	assert.True(ok)
+	assert.Equal("data_base64", attribute)
+
+	_, ok = configuredDataAttribute(config(cty.NullVal(cty.String), cty.NullVal(cty.String)))
+	assert.False(ok)
+
+	_, ok = configuredDataAttribute(cty.NullVal(cty.EmptyObject))
+	assert.False(ok)
+}
+
+func TestValidateHex(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	for _, valid := range []string{"", "00", "cafe00", "CAFE00"} {
+		_, errs := validateHex(valid, "data_hex")
+		assert.Empty(errs, valid)
+	}
+
+	for _, invalid := range []string{"0", "cafe0", "0xcafe", "ca fe", "napoli"} {
+		_, errs := validateHex(invalid, "data_hex")
+		assert.Len(errs, 1, invalid)
+	}
+
+	assert.True(suppressHexCaseDiff("data_hex", "cafe00", "CAFE00", nil))
+	assert.False(suppressHexCaseDiff("data_hex", "cafe00", "cafe01", nil))
+}
+
+func TestContentVersion(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	assert.Equal("0ed2b2b83fd3277c64862c41ec52f8daa55edad231075b20560ff2998695b0fe", contentVersion([]byte("Forza Napoli!")))
+	// No content is the same as empty content
+	assert.Equal(contentVersion([]byte{}), contentVersion(nil))
+}
+
+func TestForceNewOnDataChange(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	// Plans the update of the zookeeper_znode `/forza`, containing `Forza`, to the given configuration
+	requiresNew := func(config string) bool {
+		rsc := resourceZNode()
+		configVal, err := ctyjson.Unmarshal([]byte(config), rsc.CoreConfigSchema().ImpliedType())
+		if err != nil {
+			t.Fatal(err)
+		}
+		state := &terraform.InstanceState{
+			ID: "/forza",
+			Attributes: map[string]string{
+				"id":                     "/forza",
+				"path":                   "/forza",
+				"data":                   "Forza",
+				"content_version":        contentVersion([]byte("Forza")),
+				"replace_on_data_change": "true",
+			},
+			RawConfig: configVal,
+		}
+
+		diff, err := rsc.Diff(context.Background(), state, terraform.NewResourceConfigShimmed(configVal, rsc.CoreConfigSchema()), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return diff.RequiresNew()
+	}
+
+	assert.True(requiresNew(`{"path": "/forza", "data": "Napoli", "replace_on_data_change": true}`))
+	assert.False(requiresNew(`{"path": "/forza", "data": "Napoli", "replace_on_data_change": false}`))
+	// Same content, just configured differently
+	assert.False(requiresNew(`{"path": "/forza", "data_base64": "Rm9yemE=", "replace_on_data_change": true}`))
+}
+
+func TestSetPathFromPartsWhenKnown(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	// Plans the given configuration of a zookeeper_znode, with the given attributes in the state (if any)
+	plan := func(config string, attributes map[string]string) *terraform.InstanceDiff {
+		rsc := resourceZNode()
+		configVal, err := ctyjson.Unmarshal([]byte(config), rsc.CoreConfigSchema().ImpliedType())
+		if err != nil {
+			t.Fatal(err)
+		}
+		state := &terraform.InstanceState{ID: attributes["id"], Attributes: attributes, RawConfig: configVal}
+
+		diff, err := rsc.Diff(context.Background(), state, terraform.NewResourceConfigShimmed(configVal, rsc.CoreConfigSchema()), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return diff
+	}
+
+	// Creating a ZNode declared via `parent_path` and `name`
+	diff := plan(`{"parent_path": "/forza", "name": "napoli"}`, nil)
+	assert.Equal("/forza/napoli", diff.Attributes["path"].New)
+	assert.False(diff.Attributes["path"].NewComputed)
+
+	diff = plan(`{"parent_path": "/", "name": "forza"}`, nil)
+	assert.Equal("/forza", diff.Attributes["path"].New)
+
+	existing := map[string]string{"id": "/forza/napoli", "path": "/forza/napoli", "parent_path": "/forza", "name": "napoli"}
+
+	// Unchanged
+	diff = plan(`{"parent_path": "/forza", "name": "napoli"}`, existing)
+	assert.False(diff.RequiresNew())
+	assert.Nil(diff.Attributes["path"])
+
+	// Renaming it replaces it
+	diff = plan(`{"parent_path": "/forza", "name": "sempre"}`, existing)
+	assert.True(diff.RequiresNew())
+	assert.Equal("/forza/sempre", diff.Attributes["path"].New)
+}
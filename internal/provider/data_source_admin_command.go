@@ -0,0 +1,128 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/tfzk/terraform-provider-zookeeper/client"
+)
+
+const adminServerLinkForDesc = "[AdminServer](https://zookeeper.apache.org/doc/current/zookeeperAdmin.html#sc_adminserver)"
+
+func datasourceAdminCommand() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceAdminCommandRead,
+		Schema: map[string]*schema.Schema{
+			"command": {
+				Type:     schema.TypeString,
+				Required: true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringMatch(
+					regexp.MustCompile(`^[a-z_]+$`),
+					"must be an AdminServer command name (ex. `monitor`, `connections`)",
+				)),
+				Description: "Name of the AdminServer command to run (ex. `monitor`, `connections`, `watch_summary`, `dirs`). " +
+					"The command is requested from `<url>/commands/<command>`.",
+			},
+			"url": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IsURLWithScheme([]string{"http", "https"})),
+				Description: "Base URL of the AdminServer (ex. `http://zk1:8080`). " +
+					"Defaults to port `" + client.DefaultAdminServerPort + "` of the first of the `servers` the provider is configured with.",
+			},
+			"timeout": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "10s",
+				ValidateDiagFunc: validation.ToDiagFunc(validateDuration),
+				Description: "How long to wait for the AdminServer to reply. " +
+					"Expressed as a " + durationLinkForDesc + " (ex. `10s`, `1m`).",
+			},
+			"json": {
+				Type:     schema.TypeString,
+				Computed: true,
+				Description: "Response of the command, as JSON document. " +
+					"Use `jsondecode()` to access nested fields.",
+			},
+			"result": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Description: "Top-level fields of the response of the command. " +
+					"Scalar values are converted to strings, while objects and arrays are encoded as JSON.",
+			},
+		},
+		Description: "Runs a command against the ZooKeeper " + adminServerLinkForDesc + " via HTTP, and provides its parsed JSON response. " +
+			"Useful for monitoring and conditional logic (ex. based on connections, watches, data directories size). " +
+			"Requires ZooKeeper 3.5+ with the AdminServer enabled.",
+	}
+}
+
+func dataSourceAdminCommandRead(ctx context.Context, rscData *schema.ResourceData, prvClient interface{}) diag.Diagnostics {
+	zkClient := prvClient.(*client.Client)
+
+	timeout, err := time.ParseDuration(rscData.Get("timeout").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	adminURL := rscData.Get("url").(string)
+	if adminURL == "" {
+		if adminURL, err = zkClient.DefaultAdminServerURL(); err != nil {
+			return diag.Errorf("Unable to determine AdminServer URL: %v", err)
+		}
+	}
+
+	if zkClient.RequiresTLS() && !strings.HasPrefix(adminURL, "https://") {
+		return diag.Errorf("Refusing to run AdminServer command over plaintext '%s': the provider is configured with `require_tls`, "+
+			"set `url` to the HTTPS address of the AdminServer", adminURL)
+	}
+
+	command := rscData.Get("command").(string)
+	cmdResult, err := client.RunAdminCommand(ctx, adminURL, command, timeout)
+	if err != nil {
+		return diag.Errorf("Unable to run AdminServer command '%s': %v", command, err)
+	}
+
+	result := make(map[string]interface{}, len(cmdResult.Fields))
+	for field, value := range cmdResult.Fields {
+		switch v := value.(type) {
+		case nil:
+			result[field] = ""
+		case string:
+			result[field] = v
+		case map[string]interface{}, []interface{}:
+			encoded, err := json.Marshal(v)
+			if err != nil {
+				return diag.Errorf("Unable to encode field '%s' of AdminServer command '%s': %v", field, command, err)
+			}
+			result[field] = string(encoded)
+		default:
+			result[field] = fmt.Sprint(v)
+		}
+	}
+
+	// Terraform will use the URL of the command as unique identifier for this Data Source
+	rscData.SetId(cmdResult.URL)
+
+	diags := diag.Diagnostics{}
+	for attribute, value := range map[string]interface{}{
+		"url":    adminURL,
+		"json":   string(cmdResult.Raw),
+		"result": result,
+	} {
+		if err := rscData.Set(attribute, value); err != nil {
+			diags = append(diags, diag.FromErr(err)...)
+		}
+	}
+
+	return diags
+}
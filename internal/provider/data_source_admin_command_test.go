@@ -0,0 +1,29 @@
+package provider_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceAdminCommand(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { checkPreconditions(t) },
+		ProtoV6ProviderFactories: providerFactoriesMap(),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					data "zookeeper_admin_command" "dst" {
+						url     = "http://localhost:8081"
+						command = "ruok"
+					}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.zookeeper_admin_command.dst", "result.command", "ruok"),
+					resource.TestCheckResourceAttr("data.zookeeper_admin_command.dst", "result.error", ""),
+					resource.TestCheckResourceAttrSet("data.zookeeper_admin_command.dst", "json"),
+					resource.TestCheckResourceAttr("data.zookeeper_admin_command.dst", "id", "http://localhost:8081/commands/ruok"),
+				),
+			},
+		},
+	})
+}
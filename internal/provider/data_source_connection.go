@@ -0,0 +1,123 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/tfzk/terraform-provider-zookeeper/client"
+)
+
+const connectionDefaultTimeout = "5s"
+
+// connectionDataSource is implemented with terraform-plugin-framework.
+type connectionDataSource struct {
+	zkClient *client.Client
+}
+
+type connectionDataSourceModel struct {
+	ID               types.String `tfsdk:"id"`
+	Timeout          types.String `tfsdk:"timeout"`
+	SessionID        types.String `tfsdk:"session_id"`
+	SessionTimeoutMs types.Int64  `tfsdk:"session_timeout_ms"`
+	Server           types.String `tfsdk:"server"`
+	Role             types.String `tfsdk:"role"`
+}
+
+var _ datasource.DataSourceWithConfigure = &connectionDataSource{}
+
+func newConnectionDataSource() datasource.DataSource {
+	return &connectionDataSource{}
+}
+
+func (d *connectionDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_connection"
+}
+
+func (d *connectionDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	configureDataSourceClient(req, resp, &d.zkClient)
+}
+
+func (d *connectionDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Same as `session_id`.",
+			},
+			"timeout": schema.StringAttribute{
+				Optional:   true,
+				Validators: []validator.String{durationValidator{}},
+				Description: "How long to wait for the server to report its `role`. " +
+					"Expressed as a " + durationLinkForDesc + " (ex. `5s`, `1m`). " +
+					"Defaults to `" + connectionDefaultTimeout + "`.",
+			},
+			"session_id": schema.StringAttribute{
+				Computed: true,
+				Description: "ID of the session of the provider, in hexadecimal (ex. `0x100008d3e4c0000`), " +
+					"as ZooKeeper reports it in its logs and in the `cons` " + fourLetterWordsLinkForDesc + ".",
+			},
+			"session_timeout_ms": schema.Int64Attribute{
+				Computed: true,
+				Description: "Session timeout negotiated with the ZooKeeper Ensemble, in milliseconds: " +
+					"the provider `session_timeout`, bounded by the `minSessionTimeout` and `maxSessionTimeout` of the server.",
+			},
+			"server": schema.StringAttribute{
+				Computed:    true,
+				Description: "The `host:port` of the ZooKeeper Server the provider is connected to.",
+			},
+			"role": schema.StringAttribute{
+				Computed: true,
+				Description: "Role of the server (ex. `leader`, `follower`, `observer`, `standalone`), " +
+					"using the `srvr` " + fourLetterWordsLinkForDesc + ". Empty, with a warning, if it can't be determined " +
+					"(ex. `srvr` is not whitelisted).",
+			},
+		},
+		Description: "Provides information about the session of the provider with the ZooKeeper Ensemble: " +
+			"the negotiated session timeout, the session ID, and the server connected to. " +
+			"Useful to debug connectivity issues, and to correlate the operations of Terraform with the ZooKeeper logs.",
+	}
+}
+
+func (d *connectionDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var model connectionDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeoutStr := connectionDefaultTimeout
+	if !model.Timeout.IsNull() {
+		timeoutStr = model.Timeout.ValueString()
+	}
+	timeout, err := time.ParseDuration(timeoutStr)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("timeout"), "Invalid duration", err.Error())
+		return
+	}
+
+	session, err := d.zkClient.Session()
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read ZooKeeper session", err.Error())
+		return
+	}
+
+	role, err := d.zkClient.ConnectedServerMode(timeout)
+	if err != nil {
+		resp.Diagnostics.AddAttributeWarning(path.Root("role"), "Unable to read ZooKeeper Server role", err.Error())
+	}
+
+	// Terraform will use the session ID as unique identifier for this Data Source
+	model.ID = types.StringValue(fmt.Sprintf("0x%x", session.ID))
+	model.SessionID = model.ID
+	model.SessionTimeoutMs = types.Int64Value(session.Timeout.Milliseconds())
+	model.Server = types.StringValue(session.Server)
+	model.Role = types.StringValue(role)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
@@ -0,0 +1,26 @@
+package provider_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceConnection(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { checkPreconditions(t) },
+		ProtoV6ProviderFactories: providerFactoriesMap(),
+		Steps: []resource.TestStep{
+			{
+				Config: `data "zookeeper_connection" "dst" {}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr("data.zookeeper_connection.dst", "session_id", regexp.MustCompile(`^0x[0-9a-f]+$`)),
+					resource.TestMatchResourceAttr("data.zookeeper_connection.dst", "session_timeout_ms", regexp.MustCompile(`^[1-9]\d*$`)),
+					resource.TestCheckResourceAttrSet("data.zookeeper_connection.dst", "server"),
+					resource.TestCheckResourceAttrSet("data.zookeeper_connection.dst", "role"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,125 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/tfzk/terraform-provider-zookeeper/client"
+)
+
+const (
+	ensembleConfigID            = "/zookeeper/config"
+	ensembleReconfigLinkForDesc = "[dynamic configuration](https://zookeeper.apache.org/doc/current/zookeeperReconfig.html)"
+)
+
+func datasourceEnsembleConfig() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceEnsembleConfigRead,
+		Schema: map[string]*schema.Schema{
+			"version": {
+				Type:     schema.TypeString,
+				Computed: true,
+				Description: "Version of the Ensemble configuration, as hexadecimal string (ex. `100000000`). " +
+					"Changes every time the Ensemble is reconfigured.",
+			},
+			"participants": ensembleServersSchema("List of servers of the Ensemble that take part in leader election and quorum."),
+			"observers":    ensembleServersSchema("List of servers of the Ensemble that are observers, i.e. that don't take part in leader election and quorum."),
+		},
+		Description: "Provides access to the " + ensembleReconfigLinkForDesc + " of the ZooKeeper Ensemble, " +
+			"by reading and parsing the `/zookeeper/config` ZNode. " +
+			"Requires ZooKeeper 3.5+.",
+	}
+}
+
+func ensembleServersSchema(description string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: description,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"id": {
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "ID of the server (i.e. the `<id>` in `server.<id>`).",
+				},
+				"address": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Address of the server, used for quorum and leader election.",
+				},
+				"quorum_port": {
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "Port used by followers to connect to the leader.",
+				},
+				"election_port": {
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "Port used for leader election.",
+				},
+				"role": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Role of the server: `participant` or `observer`.",
+				},
+				"client_address": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Address the server listens on for client connections. Empty if not specified.",
+				},
+				"client_port": {
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "Port the server listens on for client connections.",
+				},
+			},
+		},
+	}
+}
+
+func dataSourceEnsembleConfigRead(_ context.Context, rscData *schema.ResourceData, prvClient interface{}) diag.Diagnostics {
+	zkClient := prvClient.(*client.Client)
+
+	config, err := zkClient.ReadEnsembleConfig()
+	if err != nil {
+		return zkErrorf(zkErrorHint(zkClient, zNodeOperationRead, ensembleConfigID, err), "Unable to read Ensemble configuration: %v", err)
+	}
+
+	participants := make([]interface{}, 0, len(config.Servers))
+	observers := make([]interface{}, 0, len(config.Servers))
+	for _, server := range config.Servers {
+		flatServer := map[string]interface{}{
+			"id":             server.ID,
+			"address":        server.Address,
+			"quorum_port":    server.QuorumPort,
+			"election_port":  server.ElectionPort,
+			"role":           server.Role,
+			"client_address": server.ClientAddress,
+			"client_port":    server.ClientPort,
+		}
+
+		if server.Role == client.EnsembleRoleObserver {
+			observers = append(observers, flatServer)
+		} else {
+			participants = append(participants, flatServer)
+		}
+	}
+
+	// Terraform will use the path of the configuration ZNode as unique identifier for this Data Source
+	rscData.SetId(ensembleConfigID)
+
+	diags := diag.Diagnostics{}
+	for attribute, value := range map[string]interface{}{
+		"version":      config.Version,
+		"participants": participants,
+		"observers":    observers,
+	} {
+		if err := rscData.Set(attribute, value); err != nil {
+			diags = append(diags, diag.FromErr(err)...)
+		}
+	}
+
+	return diags
+}
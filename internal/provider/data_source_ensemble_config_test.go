@@ -0,0 +1,24 @@
+package provider_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceEnsembleConfig(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { checkPreconditions(t) },
+		ProtoV6ProviderFactories: providerFactoriesMap(),
+		Steps: []resource.TestStep{
+			{
+				Config: `data "zookeeper_ensemble_config" "dst" {}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.zookeeper_ensemble_config.dst", "id", "/zookeeper/config"),
+					resource.TestCheckResourceAttrSet("data.zookeeper_ensemble_config.dst", "participants.#"),
+					resource.TestCheckResourceAttrSet("data.zookeeper_ensemble_config.dst", "observers.#"),
+				),
+			},
+		},
+	})
+}
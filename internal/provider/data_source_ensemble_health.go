@@ -0,0 +1,210 @@
+package provider
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/tfzk/terraform-provider-zookeeper/client"
+)
+
+const (
+	fourLetterWordsLinkForDesc = "[Four Letter Words](https://zookeeper.apache.org/doc/current/zookeeperAdmin.html#sc_4lw)"
+	ensembleLeaderMode         = "leader"
+)
+
+func datasourceEnsembleHealth() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceEnsembleHealthRead,
+		Schema: map[string]*schema.Schema{
+			"servers": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Description: "List of `host:port` ZooKeeper Servers to check. " +
+					"Defaults to the `servers` the provider is configured with.",
+			},
+			"timeout": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "5s",
+				ValidateDiagFunc: validation.ToDiagFunc(validateDuration),
+				Description: "How long to wait for each server to reply to each command. " +
+					"Expressed as a " + durationLinkForDesc + " (ex. `5s`, `1m`).",
+			},
+			"require_healthy": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "If `true`, fail if any of the servers is not healthy. " +
+					"Useful to gate downstream changes on the Ensemble being healthy.",
+			},
+			"healthy": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether all the servers are healthy.",
+			},
+			"leader": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The `host:port` of the server that is currently the leader. Empty if no server reports to be leader.",
+			},
+			"hosts": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Health of each of the servers, in the same order as `servers`.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"server": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The `host:port` of the server.",
+						},
+						"ok": {
+							Type:     schema.TypeBool,
+							Computed: true,
+							Description: "Whether the server replied `imok` to `ruok` or, " +
+								"if `ruok` is not in `4lw.commands.whitelist`, whether it replied to `srvr`.",
+						},
+						"mode": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Role of the server, as reported by `srvr` (ex. `leader`, `follower`, `observer`, `standalone`).",
+						},
+						"version": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ZooKeeper version of the server.",
+						},
+						"min_latency": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Minimum request latency, in milliseconds.",
+						},
+						"avg_latency": {
+							Type:        schema.TypeFloat,
+							Computed:    true,
+							Description: "Average request latency, in milliseconds.",
+						},
+						"max_latency": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Maximum request latency, in milliseconds.",
+						},
+						"outstanding_requests": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Number of queued requests, waiting to be processed.",
+						},
+						"connections": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Number of client connections.",
+						},
+						"znode_count": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Number of ZNodes.",
+						},
+						"metrics": {
+							Type:     schema.TypeMap,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+							Description: "Metrics reported by `mntr` (ex. `zk_server_state`, `zk_pending_syncs`). " +
+								"Empty if `mntr` is not in `4lw.commands.whitelist`.",
+						},
+						"error": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Error encountered checking the server. Empty if none.",
+						},
+					},
+				},
+			},
+		},
+		Description: "Checks the health of each server of the ZooKeeper Ensemble, " +
+			"using the `ruok`, `srvr` and `mntr` " + fourLetterWordsLinkForDesc + ". " +
+			"The commands must be listed in the `4lw.commands.whitelist` server configuration " +
+			"(ZooKeeper 3.5+ allows only `srvr` by default).",
+	}
+}
+
+func dataSourceEnsembleHealthRead(_ context.Context, rscData *schema.ResourceData, prvClient interface{}) diag.Diagnostics {
+	zkClient := prvClient.(*client.Client)
+
+	timeout, err := time.ParseDuration(rscData.Get("timeout").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var health []*client.ServerHealth
+	if serversRaw, ok := rscData.GetOk("servers"); ok {
+		configuredServers := make([]string, 0, len(serversRaw.([]interface{})))
+		for _, serverRaw := range serversRaw.([]interface{}) {
+			configuredServers = append(configuredServers, serverRaw.(string))
+		}
+		health = zkClient.CheckServersHealthOf(configuredServers, timeout)
+	} else {
+		health = zkClient.CheckServersHealth(timeout)
+	}
+
+	servers := make([]string, 0, len(health))
+	hosts := make([]interface{}, 0, len(health))
+	healthy, leader := true, ""
+	unhealthy := []string{}
+	for _, h := range health {
+		errMsg := ""
+		if h.Error != nil {
+			errMsg = h.Error.Error()
+		}
+		if !h.OK {
+			healthy = false
+			unhealthy = append(unhealthy, h.Server)
+		}
+		if h.Mode == ensembleLeaderMode {
+			leader = h.Server
+		}
+
+		servers = append(servers, h.Server)
+		hosts = append(hosts, map[string]interface{}{
+			"server":               h.Server,
+			"ok":                   h.OK,
+			"mode":                 h.Mode,
+			"version":              h.Version,
+			"min_latency":          h.MinLatency,
+			"avg_latency":          h.AvgLatency,
+			"max_latency":          h.MaxLatency,
+			"outstanding_requests": h.Outstanding,
+			"connections":          h.Connections,
+			"znode_count":          h.NodeCount,
+			"metrics":              h.Metrics,
+			"error":                errMsg,
+		})
+	}
+
+	if !healthy && rscData.Get("require_healthy").(bool) {
+		return diag.Errorf("ZooKeeper Ensemble is not healthy: unhealthy servers '%s'", strings.Join(unhealthy, ","))
+	}
+
+	// Terraform will use the hash of the servers as unique identifier for this Data Source
+	rscData.SetId(strconv.Itoa(schema.HashString(strings.Join(servers, ","))))
+
+	diags := diag.Diagnostics{}
+	for attribute, value := range map[string]interface{}{
+		"servers": servers,
+		"healthy": healthy,
+		"leader":  leader,
+		"hosts":   hosts,
+	} {
+		if err := rscData.Set(attribute, value); err != nil {
+			diags = append(diags, diag.FromErr(err)...)
+		}
+	}
+
+	return diags
+}
@@ -0,0 +1,44 @@
+package provider_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceEnsembleHealth(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { checkPreconditions(t) },
+		ProtoV6ProviderFactories: providerFactoriesMap(),
+		Steps: []resource.TestStep{
+			{
+				Config: `data "zookeeper_ensemble_health" "dst" {}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.zookeeper_ensemble_health.dst", "id"),
+					resource.TestCheckResourceAttr("data.zookeeper_ensemble_health.dst", "healthy", "true"),
+					resource.TestCheckResourceAttrSet("data.zookeeper_ensemble_health.dst", "hosts.0.mode"),
+					resource.TestCheckResourceAttr("data.zookeeper_ensemble_health.dst", "hosts.0.ok", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceEnsembleHealth_RequireHealthy(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { checkPreconditions(t) },
+		ProtoV6ProviderFactories: providerFactoriesMap(),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					data "zookeeper_ensemble_health" "dst" {
+						servers         = ["127.0.0.1:1"]
+						timeout         = "1s"
+						require_healthy = true
+					}`,
+				ExpectError: regexp.MustCompile(`ZooKeeper Ensemble is not healthy`),
+			},
+		},
+	})
+}
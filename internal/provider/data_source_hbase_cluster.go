@@ -0,0 +1,291 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/tfzk/terraform-provider-zookeeper/client"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+const (
+	hbaseDefaultZNodeParent    = "/hbase"
+	hbaseMasterPath            = "master"
+	hbaseBackupMastersPath     = "backup-masters"
+	hbaseRegionServersPath     = "rs"
+	hbaseServerNameSep         = ","
+	hbaseZNodeDataMagic        = 0xFF
+	hbaseZNodeDataProtobufMark = "PBUF"
+
+	// Field numbers of the HBase `Master` and `ServerName` protobuf messages,
+	// as defined in `ZooKeeper.proto` and `HBase.proto`.
+	hbaseMasterFieldServerName    protowire.Number = 1
+	hbaseServerNameFieldHost      protowire.Number = 1
+	hbaseServerNameFieldPort      protowire.Number = 2
+	hbaseServerNameFieldStartCode protowire.Number = 3
+)
+
+func datasourceHBaseCluster() *schema.Resource {
+	serverSchema := func(description string) *schema.Schema {
+		return &schema.Schema{
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"server_name": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The HBase server name, in the form `<host>,<port>,<start_code>`.",
+					},
+					"host": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The server host.",
+					},
+					"port": {
+						Type:        schema.TypeInt,
+						Computed:    true,
+						Description: "The server RPC port.",
+					},
+					"start_code": {
+						Type:        schema.TypeInt,
+						Computed:    true,
+						Description: "The server start code (i.e. the time in milliseconds from epoch when it started).",
+					},
+				},
+			},
+			Description: description,
+		}
+	}
+
+	return &schema.Resource{
+		ReadContext: dataSourceHBaseClusterRead,
+		Schema: map[string]*schema.Schema{
+			"znode_parent": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  hbaseDefaultZNodeParent,
+				Description: "The root ZNode of the HBase cluster " +
+					"(i.e. HBase `zookeeper.znode.parent`). Defaults to `/hbase`.",
+			},
+			"master":         serverSchema("The active master, if any is currently elected. Contains at most one element."),
+			"backup_masters": serverSchema("The backup masters, sorted by `server_name`."),
+			"region_servers": serverSchema("The region servers currently registered, sorted by `server_name`."),
+			"region_server_addresses": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Same as `region_servers`, but as `host:port` pairs.",
+			},
+		},
+		Description: "Provides access to the topology of an [HBase](https://hbase.apache.org/) cluster, " +
+			"by reading its `master`, `backup-masters` and `rs` ZNodes. " +
+			"Exposes the active master and the list of region servers, " +
+			"for example to configure firewalling or monitoring resources.",
+	}
+}
+
+func dataSourceHBaseClusterRead(_ context.Context, rscData *schema.ResourceData, prvClient interface{}) diag.Diagnostics {
+	zkClient := prvClient.(*client.Client)
+
+	znodeParent := rscData.Get("znode_parent").(string)
+
+	masters := make([]interface{}, 0, 1)
+	masterPath := path.Join(znodeParent, hbaseMasterPath)
+	masterZNode, err := zkClient.Read(masterPath)
+	switch {
+	case errors.Is(err, client.ErrorZNodeDoesNotExist):
+		// No active master elected at the moment
+	case err != nil:
+		return zkErrorf(zkErrorHint(zkClient, zNodeOperationRead, masterPath, err), "Unable to read HBase master from '%s': %v", masterPath, err)
+	default:
+		master, err := parseHBaseMaster(masterZNode.Data)
+		if err != nil {
+			return diag.Errorf("Unable to parse HBase master from '%s': %v", masterPath, err)
+		}
+		masters = append(masters, master)
+	}
+
+	backupMasters, err := readHBaseServers(zkClient, path.Join(znodeParent, hbaseBackupMastersPath))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	regionServers, err := readHBaseServers(zkClient, path.Join(znodeParent, hbaseRegionServersPath))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	regionServerAddresses := make([]string, 0, len(regionServers))
+	for _, regionServer := range regionServers {
+		server := regionServer.(map[string]interface{})
+		regionServerAddresses = append(regionServerAddresses, net.JoinHostPort(server["host"].(string), strconv.Itoa(server["port"].(int))))
+	}
+
+	// Terraform will use the ZNode parent as unique identifier for this Data Source
+	rscData.SetId(znodeParent)
+
+	diags := diag.Diagnostics{}
+	for attribute, value := range map[string]interface{}{
+		"master":                  masters,
+		"backup_masters":          backupMasters,
+		"region_servers":          regionServers,
+		"region_server_addresses": regionServerAddresses,
+	} {
+		if err := rscData.Set(attribute, value); err != nil {
+			diags = append(diags, diag.FromErr(err)...)
+		}
+	}
+
+	return diags
+}
+
+// readHBaseServers reads the children of the given ZNode, whose names are HBase server names,
+// returning them in the form of Terraform Schema compliant list.
+//
+// A missing ZNode is treated as having no children.
+func readHBaseServers(zkClient *client.Client, serversPath string) ([]interface{}, error) {
+	serverZNodes, err := zkClient.ReadChildrenStats(serversPath)
+	if err != nil && !errors.Is(err, client.ErrorZNodeDoesNotExist) {
+		return nil, fmt.Errorf("unable to read HBase servers from '%s': %w", serversPath, err)
+	}
+
+	servers := make([]interface{}, 0, len(serverZNodes))
+	for _, serverZNode := range serverZNodes {
+		server, err := parseHBaseServerName(path.Base(serverZNode.Path))
+		if err != nil {
+			return nil, err
+		}
+		servers = append(servers, server)
+	}
+
+	return servers, nil
+}
+
+// parseHBaseServerName parses an HBase server name (i.e. `<host>,<port>,<start_code>`).
+func parseHBaseServerName(serverName string) (map[string]interface{}, error) {
+	parts := strings.Split(serverName, hbaseServerNameSep)
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("unexpected HBase server name '%s': expected '<host>,<port>,<start_code>'", serverName)
+	}
+
+	port, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("unexpected HBase server name '%s': invalid port: %w", serverName, err)
+	}
+
+	startCode, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("unexpected HBase server name '%s': invalid start code: %w", serverName, err)
+	}
+
+	return map[string]interface{}{
+		"server_name": serverName,
+		"host":        parts[0],
+		"port":        port,
+		"start_code":  startCode,
+	}, nil
+}
+
+// parseHBaseMaster parses the content of the HBase master ZNode.
+//
+// The content is made of a magic byte, the length and content of the RPC identifier
+// of the writer, a `PBUF` marker and finally the protobuf-encoded `Master` message.
+func parseHBaseMaster(data []byte) (map[string]interface{}, error) {
+	if len(data) < 5 || data[0] != hbaseZNodeDataMagic {
+		return nil, fmt.Errorf("missing HBase magic prefix")
+	}
+
+	idLength := int(binary.BigEndian.Uint32(data[1:5]))
+	message := data[5:]
+	if idLength > len(message) || !bytes.HasPrefix(message[idLength:], []byte(hbaseZNodeDataProtobufMark)) {
+		return nil, fmt.Errorf("missing HBase protobuf marker")
+	}
+	message = message[idLength+len(hbaseZNodeDataProtobufMark):]
+
+	master := map[string]interface{}{}
+	err := consumeProtobufFields(message, func(num protowire.Number, value uint64, bytesValue []byte) error {
+		if num == hbaseMasterFieldServerName && bytesValue != nil {
+			return consumeProtobufFields(bytesValue, func(num protowire.Number, value uint64, bytesValue []byte) error {
+				switch num {
+				case hbaseServerNameFieldHost:
+					master["host"] = string(bytesValue)
+				case hbaseServerNameFieldPort:
+					master["port"] = int(value)
+				case hbaseServerNameFieldStartCode:
+					master["start_code"] = int64(value)
+				}
+				return nil
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := master["host"]; !ok {
+		return nil, fmt.Errorf("missing HBase master server name")
+	}
+	// `port` and `start_code` are optional in the protobuf `ServerName` message
+	if _, ok := master["port"]; !ok {
+		master["port"] = 0
+	}
+	if _, ok := master["start_code"]; !ok {
+		master["start_code"] = int64(0)
+	}
+	master["server_name"] = fmt.Sprintf("%s%s%d%s%d", master["host"], hbaseServerNameSep, master["port"], hbaseServerNameSep, master["start_code"])
+
+	return master, nil
+}
+
+// consumeProtobufFields iterates over the fields of a protobuf-encoded message, invoking fieldFn for each.
+//
+// Only varint and length-delimited fields are passed to fieldFn (as `value` and `bytesValue` respectively):
+// fields of any other wire type are skipped.
+func consumeProtobufFields(message []byte, fieldFn func(num protowire.Number, value uint64, bytesValue []byte) error) error {
+	for len(message) > 0 {
+		num, wireType, n := protowire.ConsumeTag(message)
+		if n < 0 {
+			return fmt.Errorf("invalid protobuf message: %w", protowire.ParseError(n))
+		}
+		message = message[n:]
+
+		var err error
+		switch wireType {
+		case protowire.VarintType:
+			value, m := protowire.ConsumeVarint(message)
+			n = m
+			if n >= 0 {
+				err = fieldFn(num, value, nil)
+			}
+		case protowire.BytesType:
+			value, m := protowire.ConsumeBytes(message)
+			n = m
+			if n >= 0 {
+				err = fieldFn(num, 0, value)
+			}
+		default:
+			n = protowire.ConsumeFieldValue(num, wireType, message)
+		}
+
+		if n < 0 {
+			return fmt.Errorf("invalid protobuf message: %w", protowire.ParseError(n))
+		}
+		if err != nil {
+			return err
+		}
+		message = message[n:]
+	}
+
+	return nil
+}
@@ -0,0 +1,53 @@
+package provider
+
+import (
+	"encoding/binary"
+	"testing"
+
+	testifyAssert "github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// hbaseMasterData encodes the content of the HBase master ZNode, for the given `ServerName` message.
+func hbaseMasterData(serverName []byte) []byte {
+	data := []byte{hbaseZNodeDataMagic}
+	data = binary.BigEndian.AppendUint32(data, uint32(len("id")))
+	data = append(data, "id"...)
+	data = append(data, hbaseZNodeDataProtobufMark...)
+	data = protowire.AppendTag(data, hbaseMasterFieldServerName, protowire.BytesType)
+	return protowire.AppendBytes(data, serverName)
+}
+
+func TestParseHBaseMaster(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	serverName := protowire.AppendTag(nil, hbaseServerNameFieldHost, protowire.BytesType)
+	serverName = protowire.AppendString(serverName, "hbase-master-1")
+	withoutOptional := serverName
+	serverName = protowire.AppendTag(serverName, hbaseServerNameFieldPort, protowire.VarintType)
+	serverName = protowire.AppendVarint(serverName, 16000)
+	serverName = protowire.AppendTag(serverName, hbaseServerNameFieldStartCode, protowire.VarintType)
+	serverName = protowire.AppendVarint(serverName, 1700000000001)
+
+	master, err := parseHBaseMaster(hbaseMasterData(serverName))
+	assert.NoError(err)
+	assert.Equal(map[string]interface{}{
+		"server_name": "hbase-master-1,16000,1700000000001",
+		"host":        "hbase-master-1",
+		"port":        16000,
+		"start_code":  int64(1700000000001),
+	}, master)
+
+	// `port` and `start_code` are optional
+	master, err = parseHBaseMaster(hbaseMasterData(withoutOptional))
+	assert.NoError(err)
+	assert.Equal(map[string]interface{}{
+		"server_name": "hbase-master-1,0,0",
+		"host":        "hbase-master-1",
+		"port":        0,
+		"start_code":  int64(0),
+	}, master)
+
+	_, err = parseHBaseMaster([]byte("hbase-master-1,16000,1700000000001"))
+	assert.ErrorContains(err, "missing HBase magic prefix")
+}
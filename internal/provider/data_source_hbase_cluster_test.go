@@ -0,0 +1,65 @@
+package provider_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceHBaseCluster(t *testing.T) {
+	znodeParent := "/" + acctest.RandString(10)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { checkPreconditions(t) },
+		ProtoV6ProviderFactories: providerFactoriesMap(),
+		CheckDestroy:             confirmAllZNodeDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "zookeeper_znode" "master" {
+						path        = "%[1]s/master"
+						# Magic byte, writer ID, "PBUF" marker and protobuf Master message
+						data_base64 = "/wAAAAxtYXN0ZXI6MTYwMDBQQlVGChoKDmhiYXNlLW1hc3Rlci0xEIB9GIDQlf+8MRAAGIp9"
+					}
+					resource "zookeeper_znode" "backup_master" {
+						path = "%[1]s/backup-masters/hbase-master-2,16000,1700000000002"
+					}
+					resource "zookeeper_znode" "rs_2" {
+						path = "%[1]s/rs/hbase-rs-2,16020,1700000000004"
+					}
+					resource "zookeeper_znode" "rs_1" {
+						path = "%[1]s/rs/hbase-rs-1,16020,1700000000003"
+					}
+					data "zookeeper_hbase_cluster" "dst" {
+						depends_on = [
+							zookeeper_znode.master,
+							zookeeper_znode.backup_master,
+							zookeeper_znode.rs_1,
+							zookeeper_znode.rs_2,
+						]
+						znode_parent = "%[1]s"
+					}`, znodeParent,
+				),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.zookeeper_hbase_cluster.dst", "master.#", "1"),
+					resource.TestCheckResourceAttr("data.zookeeper_hbase_cluster.dst", "master.0.host", "hbase-master-1"),
+					resource.TestCheckResourceAttr("data.zookeeper_hbase_cluster.dst", "master.0.port", "16000"),
+					resource.TestCheckResourceAttr("data.zookeeper_hbase_cluster.dst", "master.0.start_code", "1700000000000"),
+					resource.TestCheckResourceAttr("data.zookeeper_hbase_cluster.dst", "master.0.server_name", "hbase-master-1,16000,1700000000000"),
+
+					resource.TestCheckResourceAttr("data.zookeeper_hbase_cluster.dst", "backup_masters.#", "1"),
+					resource.TestCheckResourceAttr("data.zookeeper_hbase_cluster.dst", "backup_masters.0.host", "hbase-master-2"),
+
+					resource.TestCheckResourceAttr("data.zookeeper_hbase_cluster.dst", "region_servers.#", "2"),
+					resource.TestCheckResourceAttr("data.zookeeper_hbase_cluster.dst", "region_servers.0.host", "hbase-rs-1"),
+					resource.TestCheckResourceAttr("data.zookeeper_hbase_cluster.dst", "region_servers.0.port", "16020"),
+					resource.TestCheckResourceAttr("data.zookeeper_hbase_cluster.dst", "region_servers.1.start_code", "1700000000004"),
+					resource.TestCheckResourceAttr("data.zookeeper_hbase_cluster.dst", "region_server_addresses.0", "hbase-rs-1:16020"),
+					resource.TestCheckResourceAttr("data.zookeeper_hbase_cluster.dst", "region_server_addresses.1", "hbase-rs-2:16020"),
+				),
+			},
+		},
+	})
+}
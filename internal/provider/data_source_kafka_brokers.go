@@ -0,0 +1,218 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/tfzk/terraform-provider-zookeeper/client"
+)
+
+const (
+	kafkaBrokersIDsPath       = "brokers/ids"
+	kafkaEndpointListenerSep  = "://"
+	kafkaBootstrapServersSep  = ","
+	kafkaDefaultChroot        = "/"
+	kafkaRegistrationDocsLink = "[broker registration](https://kafka.apache.org/documentation/#zk_brokerregistration)"
+)
+
+// kafkaBrokerRegistration is the JSON content of a Kafka Broker registration ZNode (i.e. `/brokers/ids/<id>`).
+type kafkaBrokerRegistration struct {
+	Host      string   `json:"host"`
+	Port      int      `json:"port"`
+	Endpoints []string `json:"endpoints"`
+	Rack      string   `json:"rack"`
+}
+
+func datasourceKafkaBrokers() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceKafkaBrokersRead,
+		Schema: map[string]*schema.Schema{
+			"chroot": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  kafkaDefaultChroot,
+				Description: "The ZooKeeper chroot path used by the Kafka cluster " +
+					"(i.e. the path part of Kafka `zookeeper.connect`, ex. `/kafka`). " +
+					"Defaults to `/`.",
+			},
+			"listener": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "Name of the listener (ex. `PLAINTEXT`, `SSL`) to use to build `bootstrap_servers`. " +
+					"If not set, the first endpoint advertised by each broker is used.",
+			},
+			"bootstrap_servers": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Comma separated list of `host:port` pairs, ready to be used as Kafka `bootstrap.servers`.",
+			},
+			"bootstrap_servers_list": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Same as `bootstrap_servers`, but as a list of `host:port` pairs.",
+			},
+			"brokers": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The broker ID.",
+						},
+						"host": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The broker host, as registered for the default listener.",
+						},
+						"port": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The broker port, as registered for the default listener.",
+						},
+						"rack": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The broker rack, if configured.",
+						},
+						"endpoints": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "The broker endpoints, in the form `<listener>://<host>:<port>`.",
+						},
+						"listeners": {
+							Type:        schema.TypeMap,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Map of listener name to `host:port`.",
+						},
+					},
+				},
+				Description: "The brokers currently registered, sorted by `id`.",
+			},
+		},
+		Description: "Discovers the brokers of a Kafka cluster, by reading their " +
+			kafkaRegistrationDocsLink + " ZNodes (`/brokers/ids/*`). " +
+			"Exposes a ready-to-use `bootstrap_servers` list, as well as per-broker endpoints. " +
+			"Only applies to Kafka clusters that use ZooKeeper (i.e. not KRaft).",
+	}
+}
+
+func dataSourceKafkaBrokersRead(_ context.Context, rscData *schema.ResourceData, prvClient interface{}) diag.Diagnostics {
+	zkClient := prvClient.(*client.Client)
+
+	brokerIDsPath := path.Join(rscData.Get("chroot").(string), kafkaBrokersIDsPath)
+	listener := rscData.Get("listener").(string)
+
+	brokerZNodes, err := zkClient.ReadChildren(brokerIDsPath)
+	if err != nil {
+		return zkErrorf(zkErrorHint(zkClient, zNodeOperationRead, brokerIDsPath, err), "Unable to read Kafka brokers from '%s': %v", brokerIDsPath, err)
+	}
+
+	brokers := make([]map[string]interface{}, 0, len(brokerZNodes))
+	for _, brokerZNode := range brokerZNodes {
+		broker, err := parseKafkaBroker(zkClient, brokerZNode)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		brokers = append(brokers, broker)
+	}
+
+	// Sort by broker ID, numerically
+	sort.Slice(brokers, func(i, j int) bool {
+		return brokers[i]["id"].(int) < brokers[j]["id"].(int)
+	})
+
+	brokersList := make([]interface{}, 0, len(brokers))
+	bootstrapServers := make([]string, 0, len(brokers))
+	for _, broker := range brokers {
+		bootstrapServer, err := kafkaBootstrapServer(broker, listener)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		brokersList = append(brokersList, broker)
+		bootstrapServers = append(bootstrapServers, bootstrapServer)
+	}
+
+	// Terraform will use the path to the broker registrations as unique identifier for this Data Source
+	rscData.SetId(brokerIDsPath)
+
+	diags := diag.Diagnostics{}
+	for attribute, value := range map[string]interface{}{
+		"brokers":                brokersList,
+		"bootstrap_servers_list": bootstrapServers,
+		"bootstrap_servers":      strings.Join(bootstrapServers, kafkaBootstrapServersSep),
+	} {
+		if err := rscData.Set(attribute, value); err != nil {
+			diags = append(diags, diag.FromErr(err)...)
+		}
+	}
+
+	return diags
+}
+
+// parseKafkaBroker parses the JSON content of a broker registration ZNode,
+// into a Terraform Schema compliant map.
+//
+// Parsing errors are redacted, if zkClient is configured to (see redactData).
+func parseKafkaBroker(zkClient *client.Client, brokerZNode *client.ZNode) (map[string]interface{}, error) {
+	brokerID, err := strconv.Atoi(path.Base(brokerZNode.Path))
+	if err != nil {
+		return nil, fmt.Errorf("unexpected Kafka broker registration ZNode '%s': name is not a broker ID", brokerZNode.Path)
+	}
+
+	var registration kafkaBrokerRegistration
+	if err := json.Unmarshal(brokerZNode.Data, &registration); err != nil {
+		return nil, fmt.Errorf("failed to parse Kafka broker registration '%s': %w", brokerZNode.Path, redactDataError(zkClient, err))
+	}
+
+	listeners := make(map[string]interface{}, len(registration.Endpoints))
+	for _, endpoint := range registration.Endpoints {
+		listenerName, hostPort, found := strings.Cut(endpoint, kafkaEndpointListenerSep)
+		if !found {
+			return nil, fmt.Errorf("failed to parse endpoint '%s' of Kafka broker registration '%s'", redactData(zkClient, endpoint), brokerZNode.Path)
+		}
+		listeners[listenerName] = hostPort
+	}
+
+	return map[string]interface{}{
+		"id":        brokerID,
+		"host":      registration.Host,
+		"port":      registration.Port,
+		"rack":      registration.Rack,
+		"endpoints": registration.Endpoints,
+		"listeners": listeners,
+	}, nil
+}
+
+// kafkaBootstrapServer returns the `host:port` to use to bootstrap against the given broker.
+//
+// If `listener` is empty, the first endpoint is used, falling back to the (legacy) `host` and `port`.
+func kafkaBootstrapServer(broker map[string]interface{}, listener string) (string, error) {
+	if listener != "" {
+		hostPort, ok := broker["listeners"].(map[string]interface{})[listener]
+		if !ok {
+			return "", fmt.Errorf("kafka broker %d has no listener named '%s'", broker["id"], listener)
+		}
+		return hostPort.(string), nil
+	}
+
+	if endpoints := broker["endpoints"].([]string); len(endpoints) > 0 {
+		_, hostPort, _ := strings.Cut(endpoints[0], kafkaEndpointListenerSep)
+		return hostPort, nil
+	}
+
+	return net.JoinHostPort(broker["host"].(string), strconv.Itoa(broker["port"].(int))), nil
+}
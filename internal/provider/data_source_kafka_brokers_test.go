@@ -0,0 +1,93 @@
+package provider_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceKafkaBrokers(t *testing.T) {
+	chroot := "/" + acctest.RandString(10)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { checkPreconditions(t) },
+		ProtoV6ProviderFactories: providerFactoriesMap(),
+		CheckDestroy:             confirmAllZNodeDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "zookeeper_znode" "broker_10" {
+						path = "%[1]s/brokers/ids/10"
+						data = jsonencode({
+							listener_security_protocol_map = { PLAINTEXT = "PLAINTEXT", SSL = "SSL" }
+							endpoints                      = ["PLAINTEXT://kafka-10:9092", "SSL://kafka-10:9093"]
+							jmx_port                       = -1
+							host                           = "kafka-10"
+							port                           = 9092
+							rack                           = "rack-b"
+							timestamp                      = "1700000000000"
+							version                        = 5
+						})
+					}
+					resource "zookeeper_znode" "broker_9" {
+						path = "%[1]s/brokers/ids/9"
+						data = jsonencode({
+							listener_security_protocol_map = { PLAINTEXT = "PLAINTEXT", SSL = "SSL" }
+							endpoints                      = ["PLAINTEXT://kafka-9:9092", "SSL://kafka-9:9093"]
+							jmx_port                       = -1
+							host                           = "kafka-9"
+							port                           = 9092
+							rack                           = "rack-a"
+							timestamp                      = "1700000000000"
+							version                        = 5
+						})
+					}
+					data "zookeeper_kafka_brokers" "default" {
+						depends_on = [zookeeper_znode.broker_9, zookeeper_znode.broker_10]
+						chroot     = "%[1]s"
+					}
+					data "zookeeper_kafka_brokers" "ssl" {
+						depends_on = [zookeeper_znode.broker_9, zookeeper_znode.broker_10]
+						chroot     = "%[1]s"
+						listener   = "SSL"
+					}`, chroot,
+				),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.zookeeper_kafka_brokers.default", "bootstrap_servers", "kafka-9:9092,kafka-10:9092"),
+					resource.TestCheckResourceAttr("data.zookeeper_kafka_brokers.default", "bootstrap_servers_list.#", "2"),
+					resource.TestCheckResourceAttr("data.zookeeper_kafka_brokers.default", "brokers.#", "2"),
+					resource.TestCheckResourceAttr("data.zookeeper_kafka_brokers.default", "brokers.0.id", "9"),
+					resource.TestCheckResourceAttr("data.zookeeper_kafka_brokers.default", "brokers.0.host", "kafka-9"),
+					resource.TestCheckResourceAttr("data.zookeeper_kafka_brokers.default", "brokers.0.port", "9092"),
+					resource.TestCheckResourceAttr("data.zookeeper_kafka_brokers.default", "brokers.0.rack", "rack-a"),
+					resource.TestCheckResourceAttr("data.zookeeper_kafka_brokers.default", "brokers.0.endpoints.#", "2"),
+					resource.TestCheckResourceAttr("data.zookeeper_kafka_brokers.default", "brokers.0.listeners.SSL", "kafka-9:9093"),
+					resource.TestCheckResourceAttr("data.zookeeper_kafka_brokers.default", "brokers.1.id", "10"),
+
+					resource.TestCheckResourceAttr("data.zookeeper_kafka_brokers.ssl", "bootstrap_servers", "kafka-9:9093,kafka-10:9093"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+					resource "zookeeper_znode" "broker_1" {
+						path = "%[1]s/brokers/ids/1"
+						data = jsonencode({
+							endpoints = ["PLAINTEXT://kafka-1:9092"]
+							host      = "kafka-1"
+							port      = 9092
+						})
+					}
+					data "zookeeper_kafka_brokers" "sasl" {
+						depends_on = [zookeeper_znode.broker_1]
+						chroot     = "%[1]s"
+						listener   = "SASL_SSL"
+					}`, chroot,
+				),
+				ExpectError: regexp.MustCompile(`kafka broker 1 has no listener named 'SASL_SSL'`),
+			},
+		},
+	})
+}
@@ -0,0 +1,159 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"path"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/tfzk/terraform-provider-zookeeper/client"
+)
+
+const (
+	patroniDefaultNamespace = "/service"
+	patroniLeaderPath       = "leader"
+	patroniMembersPath      = "members"
+	patroniDefaultPort      = 5432
+	patroniDocsLink         = "[Patroni](https://patroni.readthedocs.io/)"
+)
+
+// patroniMember is the JSON content of a Patroni member ZNode (i.e. `<namespace>/<scope>/members/<name>`).
+type patroniMember struct {
+	ConnURL string `json:"conn_url"`
+	APIURL  string `json:"api_url"`
+	State   string `json:"state"`
+	Role    string `json:"role"`
+	Version string `json:"version"`
+}
+
+func datasourcePatroniLeader() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcePatroniLeaderRead,
+		Schema: map[string]*schema.Schema{
+			"namespace": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  patroniDefaultNamespace,
+				Description: "The Patroni `namespace`, i.e. the path under which all Patroni clusters store their state. " +
+					"Defaults to `/service`.",
+			},
+			"scope": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The Patroni `scope`, i.e. the name of the cluster.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The name of the member currently holding the leader key (i.e. the primary).",
+			},
+			"conn_url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The PostgreSQL connection URL of the primary.",
+			},
+			"api_url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The Patroni REST API URL of the primary.",
+			},
+			"host": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The PostgreSQL host of the primary, extracted from `conn_url`.",
+			},
+			"port": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The PostgreSQL port of the primary, extracted from `conn_url`.",
+			},
+			"state": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The state of the primary, as reported by Patroni (ex. `running`).",
+			},
+			"role": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The role of the primary, as reported by Patroni (ex. `master`, `primary`).",
+			},
+			"version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The Patroni version of the primary.",
+			},
+		},
+		Description: "Provides access to the current primary of a " + patroniDocsLink + " cluster using ZooKeeper as DCS, " +
+			"by reading its `leader` key and the related `members` ZNode. " +
+			"Useful to generate failover-aware infrastructure. " +
+			"Fails if the cluster has no leader at the moment (ex. during a failover).",
+	}
+}
+
+func dataSourcePatroniLeaderRead(_ context.Context, rscData *schema.ResourceData, prvClient interface{}) diag.Diagnostics {
+	zkClient := prvClient.(*client.Client)
+
+	scopePath := path.Join(rscData.Get("namespace").(string), rscData.Get("scope").(string))
+
+	leaderPath := path.Join(scopePath, patroniLeaderPath)
+	leaderZNode, err := zkClient.Read(leaderPath)
+	if errors.Is(err, client.ErrorZNodeDoesNotExist) {
+		return diag.Errorf("Patroni cluster '%s' has no leader at the moment: '%s' does not exist", scopePath, leaderPath)
+	}
+	if err != nil {
+		return zkErrorf(zkErrorHint(zkClient, zNodeOperationRead, leaderPath, err), "Unable to read Patroni leader from '%s': %v", leaderPath, err)
+	}
+	leaderName := string(leaderZNode.Data)
+
+	memberPath := path.Join(scopePath, patroniMembersPath, leaderName)
+	memberZNode, err := zkClient.Read(memberPath)
+	if err != nil {
+		return zkErrorf(zkErrorHint(zkClient, zNodeOperationRead, memberPath, err), "Unable to read Patroni leader member from '%s': %v", memberPath, err)
+	}
+
+	var member patroniMember
+	if err := json.Unmarshal(memberZNode.Data, &member); err != nil {
+		return diag.Errorf("Unable to parse Patroni leader member '%s': %v", memberPath, redactDataError(zkClient, err))
+	}
+
+	connURL, err := url.Parse(member.ConnURL)
+	if err != nil {
+		// The URL might embed credentials: never reported
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return diag.Errorf("Unable to parse 'conn_url' of Patroni leader member '%s': %v", memberPath, redactDataError(zkClient, err))
+	}
+	port := patroniDefaultPort
+	if connURL.Port() != "" {
+		if port, err = strconv.Atoi(connURL.Port()); err != nil {
+			return diag.Errorf("Unable to parse port of 'conn_url' of Patroni leader member '%s': %v", memberPath, redactDataError(zkClient, err))
+		}
+	}
+
+	// Terraform will use the path of the Patroni cluster as unique identifier for this Data Source
+	rscData.SetId(scopePath)
+
+	diags := diag.Diagnostics{}
+	for attribute, value := range map[string]interface{}{
+		"name":     leaderName,
+		"conn_url": member.ConnURL,
+		"api_url":  member.APIURL,
+		"host":     connURL.Hostname(),
+		"port":     port,
+		"state":    member.State,
+		"role":     member.Role,
+		"version":  member.Version,
+	} {
+		if err := rscData.Set(attribute, value); err != nil {
+			diags = append(diags, diag.FromErr(err)...)
+		}
+	}
+
+	return diags
+}
@@ -0,0 +1,76 @@
+package provider_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourcePatroniLeader(t *testing.T) {
+	namespace := "/" + acctest.RandString(10)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { checkPreconditions(t) },
+		ProtoV6ProviderFactories: providerFactoriesMap(),
+		CheckDestroy:             confirmAllZNodeDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "zookeeper_znode" "leader" {
+						path = "%[1]s/orders-db/leader"
+						data = "pg-2"
+					}
+					resource "zookeeper_znode" "member_pg_2" {
+						path = "%[1]s/orders-db/members/pg-2"
+						data = jsonencode({
+							conn_url = "postgres://10.0.0.2:5433/postgres"
+							api_url  = "http://10.0.0.2:8008/patroni"
+							state    = "running"
+							role     = "primary"
+							version  = "3.3.0"
+						})
+					}
+					data "zookeeper_patroni_leader" "dst" {
+						depends_on = [zookeeper_znode.leader, zookeeper_znode.member_pg_2]
+						namespace  = "%[1]s"
+						scope      = "orders-db"
+					}`, namespace,
+				),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.zookeeper_patroni_leader.dst", "id", namespace+"/orders-db"),
+					resource.TestCheckResourceAttr("data.zookeeper_patroni_leader.dst", "name", "pg-2"),
+					resource.TestCheckResourceAttr("data.zookeeper_patroni_leader.dst", "conn_url", "postgres://10.0.0.2:5433/postgres"),
+					resource.TestCheckResourceAttr("data.zookeeper_patroni_leader.dst", "api_url", "http://10.0.0.2:8008/patroni"),
+					resource.TestCheckResourceAttr("data.zookeeper_patroni_leader.dst", "host", "10.0.0.2"),
+					resource.TestCheckResourceAttr("data.zookeeper_patroni_leader.dst", "port", "5433"),
+					resource.TestCheckResourceAttr("data.zookeeper_patroni_leader.dst", "state", "running"),
+					resource.TestCheckResourceAttr("data.zookeeper_patroni_leader.dst", "role", "primary"),
+					resource.TestCheckResourceAttr("data.zookeeper_patroni_leader.dst", "version", "3.3.0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourcePatroniLeader_NoLeader(t *testing.T) {
+	namespace := "/" + acctest.RandString(10)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { checkPreconditions(t) },
+		ProtoV6ProviderFactories: providerFactoriesMap(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					data "zookeeper_patroni_leader" "dst" {
+						namespace = "%s"
+						scope     = "orders-db"
+					}`, namespace,
+				),
+				ExpectError: regexp.MustCompile(`has no leader at the moment`),
+			},
+		},
+	})
+}
@@ -0,0 +1,106 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/tfzk/terraform-provider-zookeeper/client"
+)
+
+const quotaLinkForDesc = "[quota](https://zookeeper.apache.org/doc/current/zookeeperQuotas.html)"
+
+func datasourceQuota() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceQuotaRead,
+		Schema: map[string]*schema.Schema{
+			"path": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validatePath,
+				Description:  "Absolute path to the ZNode the quota is set on.",
+			},
+			"znodes": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of ZNodes in the subtree, including the ZNode itself.",
+			},
+			"bytes": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Total size in bytes of the content of the ZNodes in the subtree.",
+			},
+			"znodes_limit": quotaLimitSchema("Soft limit of `znodes`: ZooKeeper only logs a warning when exceeded."),
+			"bytes_limit":  quotaLimitSchema("Soft limit of `bytes`: ZooKeeper only logs a warning when exceeded."),
+			"znodes_hard_limit": quotaLimitSchema("Hard limit of `znodes`: ZooKeeper 3.7+ rejects the changes exceeding it, " +
+				"if `enforceQuota` is enabled."),
+			"bytes_hard_limit": quotaLimitSchema("Hard limit of `bytes`: ZooKeeper 3.7+ rejects the changes exceeding it, " +
+				"if `enforceQuota` is enabled."),
+			"znodes_exceeded": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether `znodes` exceeds `znodes_limit` or `znodes_hard_limit`.",
+			},
+			"bytes_exceeded": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether `bytes` exceeds `bytes_limit` or `bytes_hard_limit`.",
+			},
+		},
+		Description: "Provides access to the " + quotaLinkForDesc + " set on a " + zNodeLinkForDesc + ", " +
+			"and to the current usage of it, by reading the `zookeeper_limits` and `zookeeper_stats` ZNodes " +
+			"under `/zookeeper/quota`. Useful for capacity dashboards, and for checks failing before the quota is exceeded " +
+			"(ex. via a `postcondition`). Fails if no quota is set on the ZNode.",
+	}
+}
+
+func quotaLimitSchema(description string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeInt,
+		Computed:    true,
+		Description: description + " `-1` if not set.",
+	}
+}
+
+func dataSourceQuotaRead(_ context.Context, rscData *schema.ResourceData, prvClient interface{}) diag.Diagnostics {
+	zkClient := prvClient.(*client.Client)
+
+	znodePath := rscData.Get("path").(string)
+
+	quota, err := zkClient.ReadQuota(znodePath)
+	if err != nil {
+		return zkErrorf(zkErrorHint(zkClient, zNodeOperationRead, znodePath, err), "Unable to read quota of ZNode '%s': %v", znodePath, err)
+	}
+
+	// Terraform will use the path of the quota ZNode as unique identifier for this Data Source
+	rscData.SetId(client.QuotaPath(znodePath))
+
+	diags := diag.Diagnostics{}
+	for attribute, value := range map[string]interface{}{
+		"znodes":            quota.Count,
+		"bytes":             quota.Bytes,
+		"znodes_limit":      quota.CountLimit,
+		"bytes_limit":       quota.BytesLimit,
+		"znodes_hard_limit": quota.CountHardLimit,
+		"bytes_hard_limit":  quota.BytesHardLimit,
+		"znodes_exceeded":   quotaExceeded(quota.Count, quota.CountLimit, quota.CountHardLimit),
+		"bytes_exceeded":    quotaExceeded(quota.Bytes, quota.BytesLimit, quota.BytesHardLimit),
+	} {
+		if err := rscData.Set(attribute, value); err != nil {
+			diags = append(diags, diag.FromErr(err)...)
+		}
+	}
+
+	return diags
+}
+
+// quotaExceeded returns true if the given usage exceeds any of the given limits that is set.
+func quotaExceeded(usage int64, limits ...int64) bool {
+	for _, limit := range limits {
+		if limit != client.QuotaUnlimited && usage > limit {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,33 @@
+package provider_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceQuota_NoQuota(t *testing.T) {
+	path := "/" + acctest.RandString(10)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { checkPreconditions(t) },
+		ProtoV6ProviderFactories: providerFactoriesMap(),
+		CheckDestroy:             confirmAllZNodeDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "zookeeper_znode" "src" {
+						path = "%s"
+					}
+					data "zookeeper_quota" "dst" {
+						path = zookeeper_znode.src.path
+					}`, path,
+				),
+				ExpectError: regexp.MustCompile("No quota is set on ZNode"),
+			},
+		},
+	})
+}
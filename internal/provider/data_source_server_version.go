@@ -0,0 +1,138 @@
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/tfzk/terraform-provider-zookeeper/client"
+)
+
+const serverVersionDefaultTimeout = "5s"
+
+// serverVersionDataSource is implemented with terraform-plugin-framework.
+type serverVersionDataSource struct {
+	zkClient *client.Client
+}
+
+type serverVersionDataSourceModel struct {
+	ID      types.String `tfsdk:"id"`
+	Server  types.String `tfsdk:"server"`
+	Timeout types.String `tfsdk:"timeout"`
+	Version types.String `tfsdk:"version"`
+	Major   types.Int64  `tfsdk:"major"`
+	Minor   types.Int64  `tfsdk:"minor"`
+	Patch   types.Int64  `tfsdk:"patch"`
+	Commit  types.String `tfsdk:"commit"`
+	BuiltOn types.String `tfsdk:"built_on"`
+}
+
+var _ datasource.DataSourceWithConfigure = &serverVersionDataSource{}
+
+func newServerVersionDataSource() datasource.DataSource {
+	return &serverVersionDataSource{}
+}
+
+func (d *serverVersionDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_server_version"
+}
+
+func (d *serverVersionDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	configureDataSourceClient(req, resp, &d.zkClient)
+}
+
+func (d *serverVersionDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The `host:port` of the ZooKeeper Server queried.",
+			},
+			"server": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				Description: "The `host:port` of the ZooKeeper Server to query. " +
+					"Defaults to the server the provider is currently connected to.",
+			},
+			"timeout": schema.StringAttribute{
+				Optional:   true,
+				Validators: []validator.String{durationValidator{}},
+				Description: "How long to wait for the server to reply. " +
+					"Expressed as a " + durationLinkForDesc + " (ex. `5s`, `1m`). " +
+					"Defaults to `" + serverVersionDefaultTimeout + "`.",
+			},
+			"version": schema.StringAttribute{
+				Computed:    true,
+				Description: "Version of the server (ex. `3.8.4`).",
+			},
+			"major": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Major version of the server (ex. `3` for `3.8.4`).",
+			},
+			"minor": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Minor version of the server (ex. `8` for `3.8.4`).",
+			},
+			"patch": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Patch version of the server (ex. `4` for `3.8.4`).",
+			},
+			"commit": schema.StringAttribute{
+				Computed:    true,
+				Description: "Hash of the commit the server was built from. Empty if not reported.",
+			},
+			"built_on": schema.StringAttribute{
+				Computed:    true,
+				Description: "Build date of the server, as reported by it (ex. `2024-02-12 22:16 UTC`).",
+			},
+		},
+		Description: "Provides the version and build information of a ZooKeeper Server, " +
+			"using the `srvr` " + fourLetterWordsLinkForDesc + ". " +
+			"Useful to conditionally enable features that depend on the ZooKeeper version (ex. `3.6+`).",
+	}
+}
+
+func (d *serverVersionDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var model serverVersionDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeoutStr := serverVersionDefaultTimeout
+	if !model.Timeout.IsNull() {
+		timeoutStr = model.Timeout.ValueString()
+	}
+	timeout, err := time.ParseDuration(timeoutStr)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("timeout"), "Invalid duration", err.Error())
+		return
+	}
+
+	var version *client.ServerVersion
+	if !model.Server.IsNull() {
+		version, err = d.zkClient.ServerVersion(model.Server.ValueString(), timeout)
+	} else {
+		version, err = d.zkClient.ConnectedServerVersion(timeout)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read ZooKeeper Server version", err.Error())
+		return
+	}
+
+	// Terraform will use the server address as unique identifier for this Data Source
+	model.ID = types.StringValue(version.Server)
+	model.Server = types.StringValue(version.Server)
+	model.Version = types.StringValue(version.Version)
+	model.Major = types.Int64Value(int64(version.Major))
+	model.Minor = types.Int64Value(int64(version.Minor))
+	model.Patch = types.Int64Value(int64(version.Patch))
+	model.Commit = types.StringValue(version.Commit)
+	model.BuiltOn = types.StringValue(version.BuiltOn)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
@@ -0,0 +1,27 @@
+package provider_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceServerVersion(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { checkPreconditions(t) },
+		ProtoV6ProviderFactories: providerFactoriesMap(),
+		Steps: []resource.TestStep{
+			{
+				Config: `data "zookeeper_server_version" "dst" {}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.zookeeper_server_version.dst", "server"),
+					resource.TestMatchResourceAttr("data.zookeeper_server_version.dst", "version", regexp.MustCompile(`^3\.\d+\.\d+`)),
+					resource.TestCheckResourceAttr("data.zookeeper_server_version.dst", "major", "3"),
+					resource.TestCheckResourceAttrSet("data.zookeeper_server_version.dst", "minor"),
+					resource.TestCheckResourceAttrSet("data.zookeeper_server_version.dst", "built_on"),
+				),
+			},
+		},
+	})
+}
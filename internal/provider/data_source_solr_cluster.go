@@ -0,0 +1,283 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/tfzk/terraform-provider-zookeeper/client"
+)
+
+const (
+	solrLiveNodesPath          = "live_nodes"
+	solrCollectionsPath        = "collections"
+	solrCollectionStateZNode   = "state.json"
+	solrLegacyClusterStatePath = "clusterstate.json"
+	solrDefaultChroot          = "/"
+	solrNodeNameContextSep     = "_"
+	solrCloudDocsLink          = "[SolrCloud](https://solr.apache.org/guide/solr/latest/deployment-guide/cluster-types.html#solrcloud-mode)"
+)
+
+// solrCollectionState is the JSON content of a SolrCloud collection state
+// (i.e. `/collections/<collection>/state.json`, or an entry of the legacy `/clusterstate.json`).
+type solrCollectionState struct {
+	Shards map[string]solrShardState `json:"shards"`
+}
+
+type solrShardState struct {
+	Range    string                      `json:"range"`
+	State    string                      `json:"state"`
+	Replicas map[string]solrReplicaState `json:"replicas"`
+}
+
+type solrReplicaState struct {
+	Core     string `json:"core"`
+	NodeName string `json:"node_name"`
+	BaseURL  string `json:"base_url"`
+	State    string `json:"state"`
+	Type     string `json:"type"`
+	Leader   string `json:"leader"`
+}
+
+func datasourceSolrCluster() *schema.Resource {
+	replicaSchema := map[string]*schema.Schema{
+		"name": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The replica name (ex. `core_node1`).",
+		},
+		"core": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The name of the Solr core backing the replica.",
+		},
+		"node_name": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The name of the Solr node hosting the replica.",
+		},
+		"base_url": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The base URL of the Solr node hosting the replica.",
+		},
+		"state": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The state of the replica (ex. `active`, `down`, `recovering`).",
+		},
+		"type": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The type of the replica (ex. `NRT`, `TLOG`, `PULL`).",
+		},
+		"leader": {
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "Whether the replica is currently the leader of its shard.",
+		},
+	}
+
+	shardSchema := map[string]*schema.Schema{
+		"name": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The shard name (ex. `shard1`).",
+		},
+		"range": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The hash range of the shard.",
+		},
+		"state": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The state of the shard (ex. `active`, `inactive`).",
+		},
+		"replicas": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem:        &schema.Resource{Schema: replicaSchema},
+			Description: "The replicas of the shard, sorted by `name`.",
+		},
+	}
+
+	return &schema.Resource{
+		ReadContext: dataSourceSolrClusterRead,
+		Schema: map[string]*schema.Schema{
+			"chroot": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  solrDefaultChroot,
+				Description: "The ZooKeeper chroot path used by the SolrCloud cluster " +
+					"(i.e. the path part of Solr `zkHost`, ex. `/solr`). " +
+					"Defaults to `/`.",
+			},
+			"live_nodes": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Names of the Solr nodes currently live (ex. `solr-1:8983_solr`), sorted.",
+			},
+			"live_node_addresses": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Same as `live_nodes`, but as `host:port` pairs.",
+			},
+			"collections": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The collection name.",
+						},
+						"shards": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Resource{Schema: shardSchema},
+							Description: "The shards of the collection, sorted by `name`.",
+						},
+					},
+				},
+				Description: "The collections of the cluster, sorted by `name`.",
+			},
+		},
+		Description: "Provides access to the state of a " + solrCloudDocsLink + " cluster, " +
+			"by reading its `live_nodes` and collections state ZNodes. " +
+			"Exposes the list of live nodes, as well as the collection, shard and replica structure. " +
+			"Both per-collection `state.json` and legacy `clusterstate.json` formats are supported.",
+	}
+}
+
+func dataSourceSolrClusterRead(_ context.Context, rscData *schema.ResourceData, prvClient interface{}) diag.Diagnostics {
+	zkClient := prvClient.(*client.Client)
+
+	chroot := rscData.Get("chroot").(string)
+
+	liveNodesPath := path.Join(chroot, solrLiveNodesPath)
+	liveNodeZNodes, err := zkClient.ReadChildrenStats(liveNodesPath)
+	if err != nil {
+		return zkErrorf(zkErrorHint(zkClient, zNodeOperationRead, liveNodesPath, err), "Unable to read Solr live nodes from '%s': %v", liveNodesPath, err)
+	}
+
+	liveNodes := make([]string, 0, len(liveNodeZNodes))
+	liveNodeAddresses := make([]string, 0, len(liveNodeZNodes))
+	for _, liveNodeZNode := range liveNodeZNodes {
+		nodeName := path.Base(liveNodeZNode.Path)
+		address, _, _ := strings.Cut(nodeName, solrNodeNameContextSep)
+
+		liveNodes = append(liveNodes, nodeName)
+		liveNodeAddresses = append(liveNodeAddresses, address)
+	}
+
+	collections, err := readSolrCollections(zkClient, chroot)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Terraform will use the chroot as unique identifier for this Data Source
+	rscData.SetId(chroot)
+
+	diags := diag.Diagnostics{}
+	for attribute, value := range map[string]interface{}{
+		"live_nodes":          liveNodes,
+		"live_node_addresses": liveNodeAddresses,
+		"collections":         collections,
+	} {
+		if err := rscData.Set(attribute, value); err != nil {
+			diags = append(diags, diag.FromErr(err)...)
+		}
+	}
+
+	return diags
+}
+
+// readSolrCollections reads the state of all the SolrCloud collections under the given chroot,
+// returning it in the form of Terraform Schema compliant list.
+func readSolrCollections(zkClient *client.Client, chroot string) ([]interface{}, error) {
+	states := map[string]solrCollectionState{}
+
+	// Legacy format: all collections in a single ZNode
+	legacyClusterStatePath := path.Join(chroot, solrLegacyClusterStatePath)
+	legacyClusterState, err := zkClient.Read(legacyClusterStatePath)
+	if err != nil && !errors.Is(err, client.ErrorZNodeDoesNotExist) {
+		return nil, err
+	}
+	if legacyClusterState != nil && len(legacyClusterState.Data) > 0 {
+		if err := json.Unmarshal(legacyClusterState.Data, &states); err != nil {
+			return nil, fmt.Errorf("failed to parse Solr cluster state '%s': %w", legacyClusterStatePath, redactDataError(zkClient, err))
+		}
+	}
+
+	// Current format: one `state.json` per collection, taking precedence over the legacy format
+	collectionsPath := path.Join(chroot, solrCollectionsPath)
+	collectionZNodes, err := zkClient.ReadChildrenStats(collectionsPath)
+	if err != nil && !errors.Is(err, client.ErrorZNodeDoesNotExist) {
+		return nil, err
+	}
+	for _, collectionZNode := range collectionZNodes {
+		statePath := path.Join(collectionZNode.Path, solrCollectionStateZNode)
+		stateZNode, err := zkClient.Read(statePath)
+		if errors.Is(err, client.ErrorZNodeDoesNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		collectionStates := map[string]solrCollectionState{}
+		if err := json.Unmarshal(stateZNode.Data, &collectionStates); err != nil {
+			return nil, fmt.Errorf("failed to parse Solr collection state '%s': %w", statePath, redactDataError(zkClient, err))
+		}
+		for name, state := range collectionStates {
+			states[name] = state
+		}
+	}
+
+	collections := make([]interface{}, 0, len(states))
+	for _, collectionName := range sortedKeys(states) {
+		shardStates := states[collectionName].Shards
+
+		shards := make([]interface{}, 0, len(shardStates))
+		for _, shardName := range sortedKeys(shardStates) {
+			replicaStates := shardStates[shardName].Replicas
+
+			replicas := make([]interface{}, 0, len(replicaStates))
+			for _, replicaName := range sortedKeys(replicaStates) {
+				replica := replicaStates[replicaName]
+				replicas = append(replicas, map[string]interface{}{
+					"name":      replicaName,
+					"core":      replica.Core,
+					"node_name": replica.NodeName,
+					"base_url":  replica.BaseURL,
+					"state":     replica.State,
+					"type":      replica.Type,
+					"leader":    replica.Leader == "true",
+				})
+			}
+
+			shards = append(shards, map[string]interface{}{
+				"name":     shardName,
+				"range":    shardStates[shardName].Range,
+				"state":    shardStates[shardName].State,
+				"replicas": replicas,
+			})
+		}
+
+		collections = append(collections, map[string]interface{}{
+			"name":   collectionName,
+			"shards": shards,
+		})
+	}
+
+	return collections, nil
+}
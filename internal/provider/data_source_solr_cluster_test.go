@@ -0,0 +1,87 @@
+package provider_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceSolrCluster(t *testing.T) {
+	chroot := "/" + acctest.RandString(10)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { checkPreconditions(t) },
+		ProtoV6ProviderFactories: providerFactoriesMap(),
+		CheckDestroy:             confirmAllZNodeDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "zookeeper_znode" "live_node_1" {
+						path = "%[1]s/live_nodes/solr-1:8983_solr"
+					}
+					resource "zookeeper_znode" "live_node_2" {
+						path = "%[1]s/live_nodes/solr-2:8983_solr"
+					}
+					resource "zookeeper_znode" "products_state" {
+						path = "%[1]s/collections/products/state.json"
+						data = jsonencode({
+							products = {
+								router = { name = "compositeId" }
+								shards = {
+									shard1 = {
+										range = "80000000-ffffffff"
+										state = "active"
+										replicas = {
+											core_node2 = {
+												core      = "products_shard1_replica_n1"
+												node_name = "solr-1:8983_solr"
+												base_url  = "http://solr-1:8983/solr"
+												state     = "active"
+												type      = "NRT"
+												leader    = "true"
+											}
+											core_node4 = {
+												core      = "products_shard1_replica_n3"
+												node_name = "solr-2:8983_solr"
+												base_url  = "http://solr-2:8983/solr"
+												state     = "recovering"
+												type      = "NRT"
+											}
+										}
+									}
+								}
+							}
+						})
+					}
+					data "zookeeper_solr_cluster" "dst" {
+						depends_on = [
+							zookeeper_znode.live_node_1,
+							zookeeper_znode.live_node_2,
+							zookeeper_znode.products_state,
+						]
+						chroot = "%[1]s"
+					}`, chroot,
+				),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.zookeeper_solr_cluster.dst", "live_nodes.#", "2"),
+					resource.TestCheckResourceAttr("data.zookeeper_solr_cluster.dst", "live_nodes.0", "solr-1:8983_solr"),
+					resource.TestCheckResourceAttr("data.zookeeper_solr_cluster.dst", "live_node_addresses.1", "solr-2:8983"),
+
+					resource.TestCheckResourceAttr("data.zookeeper_solr_cluster.dst", "collections.#", "1"),
+					resource.TestCheckResourceAttr("data.zookeeper_solr_cluster.dst", "collections.0.name", "products"),
+					resource.TestCheckResourceAttr("data.zookeeper_solr_cluster.dst", "collections.0.shards.#", "1"),
+					resource.TestCheckResourceAttr("data.zookeeper_solr_cluster.dst", "collections.0.shards.0.name", "shard1"),
+					resource.TestCheckResourceAttr("data.zookeeper_solr_cluster.dst", "collections.0.shards.0.state", "active"),
+					resource.TestCheckResourceAttr("data.zookeeper_solr_cluster.dst", "collections.0.shards.0.replicas.#", "2"),
+					resource.TestCheckResourceAttr("data.zookeeper_solr_cluster.dst", "collections.0.shards.0.replicas.0.name", "core_node2"),
+					resource.TestCheckResourceAttr("data.zookeeper_solr_cluster.dst", "collections.0.shards.0.replicas.0.base_url", "http://solr-1:8983/solr"),
+					resource.TestCheckResourceAttr("data.zookeeper_solr_cluster.dst", "collections.0.shards.0.replicas.0.leader", "true"),
+					resource.TestCheckResourceAttr("data.zookeeper_solr_cluster.dst", "collections.0.shards.0.replicas.1.state", "recovering"),
+					resource.TestCheckResourceAttr("data.zookeeper_solr_cluster.dst", "collections.0.shards.0.replicas.1.leader", "false"),
+				),
+			},
+		},
+	})
+}
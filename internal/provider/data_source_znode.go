@@ -2,10 +2,15 @@ package provider
 
 import (
 	"context"
+	"errors"
+	"regexp"
+	"time"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/tfzk/terraform-provider-zookeeper/internal/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/tfzk/terraform-provider-zookeeper/client"
 )
 
 func datasourceZNode() *schema.Resource {
@@ -13,9 +18,44 @@ func datasourceZNode() *schema.Resource {
 		ReadContext: dataSourceZNodeRead,
 		Schema: map[string]*schema.Schema{
 			"path": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "Absolute path to the ZNode to read.",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validatePath,
+				Description:  "Absolute path to the ZNode to read.",
+			},
+			"wait_for_exists": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "If `true`, wait for the ZNode to exist instead of failing immediately if it's missing. " +
+					"Useful to wait for an application to publish its registration ZNode. " +
+					"How long to wait is controlled by `timeout`.",
+			},
+			"wait_for_data": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"wait_for_data_regex"},
+				Description: "Wait for the content of the ZNode to be exactly this UTF-8 string (`\"\"` waits for it to be emptied). " +
+					"Useful to gate downstream changes on an application reaching a desired state. " +
+					"How long to wait is controlled by `timeout`. " +
+					"Mutually exclusive with `wait_for_data_regex`.",
+			},
+			"wait_for_data_regex": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ConflictsWith:    []string{"wait_for_data"},
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsValidRegExp),
+				Description: "Wait for the content of the ZNode to match this regular expression. " +
+					"How long to wait is controlled by `timeout`. " +
+					"Mutually exclusive with `wait_for_data`.",
+			},
+			"timeout": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "5m",
+				ValidateDiagFunc: validation.ToDiagFunc(validateDuration),
+				Description: "How long to wait when `wait_for_exists`, `wait_for_data` or `wait_for_data_regex` are set. " +
+					"Expressed as a " + durationLinkForDesc + " (ex. `30s`, `5m`).",
 			},
 			"data": {
 				Type:        schema.TypeString,
@@ -28,7 +68,33 @@ func datasourceZNode() *schema.Resource {
 				Description: "Content of the ZNode, encoded in Base64. " +
 					"Use this if content is binary (i.e. sequence of bytes).",
 			},
-			"stat": statSchema(),
+			"data_hex": {
+				Type:     schema.TypeString,
+				Computed: true,
+				Description: "Content of the ZNode, encoded in hexadecimal (lowercase). " +
+					"Use this to inspect short binary content (ex. magic bytes).",
+			},
+			"charset":         charsetSchema(),
+			"content_version": contentVersionSchema(),
+			"allow_missing": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "If `true`, a missing ZNode is not considered an error: " +
+					"`found` will be `false`, and `data`/`data_base64`/`data_hex` will be empty. " +
+					"Useful for optional configuration lookups.",
+			},
+			"found": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the ZNode was found. Can be `false` only when `allow_missing` is `true`.",
+			},
+			"retries":             retriesSchema(),
+			"retry_interval":      retryIntervalSchema(),
+			"retry_error_classes": retryErrorClassesSchema(),
+			"stat":                statSchema(),
+			"is_ephemeral":        isEphemeralSchema(),
+			"ephemeral_owner":     ephemeralOwnerSchema(),
 			"acl": {
 				Type:        schema.TypeList,
 				Computed:    true,
@@ -64,18 +130,116 @@ func datasourceZNode() *schema.Resource {
 	}
 }
 
-func dataSourceZNodeRead(_ context.Context, rscData *schema.ResourceData, prvClient interface{}) diag.Diagnostics {
+func dataSourceZNodeRead(ctx context.Context, rscData *schema.ResourceData, prvClient interface{}) diag.Diagnostics {
 	zkClient := prvClient.(*client.Client)
 
 	znodePath := rscData.Get("path").(string)
 
-	znode, err := zkClient.Read(znodePath)
+	if diags := dataSourceZNodeWait(ctx, rscData, zkClient, znodePath); diags.HasError() {
+		return diags
+	}
+
+	retryPolicy, err := getRetryPolicyFromResourceData(rscData)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var znode *client.ZNode
+	err = zkClient.Retry(ctx, retryPolicy, func() (readErr error) {
+		znode, readErr = zkClient.Read(znodePath)
+		return readErr
+	})
 	if err != nil {
-		return diag.Errorf("Unable read ZNode from '%s': %v", znodePath, err)
+		if errors.Is(err, client.ErrorZNodeDoesNotExist) && rscData.Get("allow_missing").(bool) {
+			rscData.SetId(znodePath)
+			return setAttributesForMissingZNode(rscData, diag.Diagnostics{})
+		}
+
+		return zkErrorf(zkErrorHint(zkClient, zNodeOperationRead, znodePath, err), "Unable read ZNode from '%s': %v", znodePath, err)
 	}
 
 	// Terraform will use the ZNode.Path as unique identifier for this Data Source
 	rscData.SetId(znode.Path)
 
+	if err := rscData.Set("found", true); err != nil {
+		return diag.FromErr(err)
+	}
+
 	return setAttributesFromZNode(rscData, znode, diag.Diagnostics{})
 }
+
+// setAttributesForMissingZNode populates the *schema.ResourceData for a ZNode that was not found,
+// leaving content, stat and ACL empty.
+func setAttributesForMissingZNode(rscData *schema.ResourceData, diags diag.Diagnostics) diag.Diagnostics {
+	emptyAttributes := map[string]interface{}{
+		"found":           false,
+		"data":            "",
+		"data_base64":     "",
+		"data_hex":        "",
+		"content_version": "",
+		"stat":            []interface{}{},
+		"is_ephemeral":    false,
+		"ephemeral_owner": "",
+		"acl":             []interface{}{},
+	}
+
+	for attribute, value := range emptyAttributes {
+		if err := rscData.Set(attribute, value); err != nil {
+			diags = append(diags, diag.FromErr(err)...)
+		}
+	}
+
+	return diags
+}
+
+// configuredWaitForData returns the `wait_for_data` set in the given raw configuration, and whether it's set:
+// unlike schema.ResourceData GetOk, an empty string is set (i.e. wait for the ZNode to be emptied).
+func configuredWaitForData(rawConfig cty.Value) (string, bool) {
+	if !rawConfig.IsKnown() || rawConfig.IsNull() || !rawConfig.Type().HasAttribute("wait_for_data") {
+		return "", false
+	}
+
+	waitForData := rawConfig.GetAttr("wait_for_data")
+	if !waitForData.IsKnown() || waitForData.IsNull() {
+		return "", false
+	}
+
+	return waitForData.AsString(), true
+}
+
+// dataSourceZNodeWait blocks until the ZNode satisfies the `wait_for_*` conditions, if any is set.
+func dataSourceZNodeWait(ctx context.Context, rscData *schema.ResourceData, zkClient *client.Client, znodePath string) diag.Diagnostics {
+	waitForData, waitForDataSet := configuredWaitForData(rscData.GetRawConfig())
+	waitForDataRegex, waitForDataRegexSet := rscData.GetOk("wait_for_data_regex")
+	waitForExists := rscData.Get("wait_for_exists").(bool)
+
+	if !waitForExists && !waitForDataSet && !waitForDataRegexSet {
+		return diag.Diagnostics{}
+	}
+
+	timeout, err := time.ParseDuration(rscData.Get("timeout").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	switch {
+	case waitForDataSet:
+		err = zkClient.WaitForData(ctx, znodePath, timeout, func(data []byte) bool {
+			return string(data) == waitForData
+		})
+	case waitForDataRegexSet:
+		expectedRegex, regexErr := regexp.Compile(waitForDataRegex.(string))
+		if regexErr != nil {
+			return diag.Errorf("Invalid 'wait_for_data_regex': %v", regexErr)
+		}
+		err = zkClient.WaitForData(ctx, znodePath, timeout, expectedRegex.Match)
+	default:
+		err = zkClient.WaitForExists(ctx, znodePath, timeout)
+	}
+
+	if err != nil {
+		return zkErrorf(zkErrorHint(zkClient, zNodeOperationRead, znodePath, err), "Unable to wait for ZNode '%s': %v", znodePath, err)
+	}
+
+	return diag.Diagnostics{}
+}
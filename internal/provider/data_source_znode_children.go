@@ -0,0 +1,93 @@
+package provider
+
+import (
+	"context"
+	"path"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/tfzk/terraform-provider-zookeeper/client"
+)
+
+func datasourceZNodeChildren() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceZNodeChildrenRead,
+		Schema: map[string]*schema.Schema{
+			"path": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Absolute path to the ZNode whose children to read.",
+			},
+			"names": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Names of the children of the ZNode, sorted.",
+			},
+			"children": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the child ZNode.",
+						},
+						"path": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Absolute path to the child ZNode.",
+						},
+						"stat": statSchema(),
+					},
+				},
+				Description: "Children of the ZNode, sorted by `name`, each with its `stat`. " +
+					"To use it as a map of `name` to `stat`, use a `for` expression like " +
+					"`{ for c in data.zookeeper_znode_children.example.children : c.name => c.stat[0] }`.",
+			},
+		},
+		Description: "Provides access to the children of a " +
+			zNodeLinkForDesc + ", and to their `stat` (ex. `mtime`, `data_length`, `ephemeral_owner`). " +
+			"Useful for policy checks, like flagging stale or oversized registration ZNodes. " +
+			"The content of the children is not read. " +
+			"The ability to access ZNodes is determined by ZooKeeper ACL.",
+	}
+}
+
+func dataSourceZNodeChildrenRead(_ context.Context, rscData *schema.ResourceData, prvClient interface{}) diag.Diagnostics {
+	zkClient := prvClient.(*client.Client)
+
+	znodePath := rscData.Get("path").(string)
+
+	childrenZNodes, err := zkClient.ReadChildrenStats(znodePath)
+	if err != nil {
+		return zkErrorf(zkErrorHint(zkClient, zNodeOperationRead, znodePath, err), "Unable to read children of ZNode '%s': %v", znodePath, err)
+	}
+
+	names := make([]string, 0, len(childrenZNodes))
+	children := make([]interface{}, 0, len(childrenZNodes))
+	for _, childZNode := range childrenZNodes {
+		name := path.Base(childZNode.Path)
+
+		names = append(names, name)
+		children = append(children, map[string]interface{}{
+			"name": name,
+			"path": childZNode.Path,
+			"stat": []interface{}{zNodeStatToMap(childZNode)},
+		})
+	}
+
+	// Terraform will use the ZNode.Path as unique identifier for this Data Source
+	rscData.SetId(znodePath)
+
+	diags := diag.Diagnostics{}
+	if err := rscData.Set("names", names); err != nil {
+		diags = append(diags, diag.FromErr(err)...)
+	}
+	if err := rscData.Set("children", children); err != nil {
+		diags = append(diags, diag.FromErr(err)...)
+	}
+
+	return diags
+}
@@ -0,0 +1,55 @@
+package provider_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceZNodeChildren(t *testing.T) {
+	parentPath := "/" + acctest.RandString(10)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { checkPreconditions(t) },
+		ProtoV6ProviderFactories: providerFactoriesMap(),
+		CheckDestroy:             confirmAllZNodeDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "zookeeper_znode" "parent" {
+						path = "%s"
+					}
+					resource "zookeeper_znode" "b" {
+						path = "${zookeeper_znode.parent.path}/b"
+						data = "bb"
+					}
+					resource "zookeeper_znode" "a" {
+						path = "${zookeeper_znode.parent.path}/a"
+						data = "a"
+					}
+					data "zookeeper_znode_children" "dst" {
+						depends_on = [zookeeper_znode.a, zookeeper_znode.b]
+						path       = zookeeper_znode.parent.path
+					}`, parentPath,
+				),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.zookeeper_znode_children.dst", "names.#", "2"),
+					resource.TestCheckResourceAttr("data.zookeeper_znode_children.dst", "names.0", "a"),
+					resource.TestCheckResourceAttr("data.zookeeper_znode_children.dst", "names.1", "b"),
+
+					resource.TestCheckResourceAttr("data.zookeeper_znode_children.dst", "children.#", "2"),
+					resource.TestCheckResourceAttr("data.zookeeper_znode_children.dst", "children.0.name", "a"),
+					resource.TestCheckResourceAttrPair("data.zookeeper_znode_children.dst", "children.0.path", "zookeeper_znode.a", "path"),
+					resource.TestCheckResourceAttr("data.zookeeper_znode_children.dst", "children.0.stat.0.data_length", "1"),
+					resource.TestCheckResourceAttrPair("data.zookeeper_znode_children.dst", "children.0.stat.0.mtime", "zookeeper_znode.a", "stat.0.mtime"),
+					resource.TestCheckResourceAttr("data.zookeeper_znode_children.dst", "children.0.stat.0.ephemeral_owner", "0"),
+					resource.TestCheckResourceAttr("data.zookeeper_znode_children.dst", "children.1.name", "b"),
+					resource.TestCheckResourceAttrPair("data.zookeeper_znode_children.dst", "children.1.path", "zookeeper_znode.b", "path"),
+					resource.TestCheckResourceAttr("data.zookeeper_znode_children.dst", "children.1.stat.0.data_length", "2"),
+				),
+			},
+		},
+	})
+}
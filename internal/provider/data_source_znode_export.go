@@ -0,0 +1,162 @@
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/tfzk/terraform-provider-zookeeper/client"
+)
+
+// zNodeExportFormatVersion is the version of the JSON document produced by zookeeper_znode_export,
+// to be bumped in case of incompatible changes.
+const zNodeExportFormatVersion = 1
+
+// zNodeExport is the JSON document produced by zookeeper_znode_export.
+type zNodeExport struct {
+	FormatVersion int                `json:"format_version"`
+	Path          string             `json:"path"`
+	ZNodes        []zNodeExportZNode `json:"znodes"`
+}
+
+// zNodeExportZNode is a ZNode, as serialized in zNodeExport.
+type zNodeExportZNode struct {
+	Path       string                 `json:"path"`
+	DataBase64 string                 `json:"data_base64"`
+	ACL        []zNodeExportACL       `json:"acl,omitempty"`
+	Stat       map[string]interface{} `json:"stat,omitempty"`
+}
+
+// zNodeExportACL is an ACL entry, as serialized in zNodeExport.
+type zNodeExportACL struct {
+	Scheme      string `json:"scheme"`
+	ID          string `json:"id"`
+	Permissions int32  `json:"permissions"`
+}
+
+func datasourceZNodeExport() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceZNodeExportRead,
+		Schema: map[string]*schema.Schema{
+			"path": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Absolute path to the ZNode at the root of the subtree to export.",
+			},
+			"max_depth": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          0,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
+				Description: "How many levels below `path` to export: " +
+					"`1` means only `path` and its direct children. `0` (default) means no limit.",
+			},
+			"concurrency": walkConcurrencySchema(),
+			"include_acl": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "If `true` (default), the ACL of each ZNode is included in the export.",
+			},
+			"stat_fields": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:             schema.TypeString,
+					ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(statFieldNames(), false)),
+				},
+				Description: "Fields of the `stat` of each ZNode to include in the export (ex. `version`, `mtime`). " +
+					"Defaults to none, so that the export changes only when content or ACL do.",
+			},
+			"json": {
+				Type:     schema.TypeString,
+				Computed: true,
+				Description: "JSON document containing the exported subtree: `format_version`, `path` and `znodes`, " +
+					"a list of objects with `path`, `data_base64`, `acl` and `stat` of each ZNode, in depth-first lexicographic order.",
+			},
+			"znode_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of ZNodes exported.",
+			},
+		},
+		Description: "Exports an entire subtree of " + zNodeLinkForDesc + "s into a single JSON document. " +
+			"Useful for Terraform-driven backups of critical configuration (ex. pushing `json` to object storage).",
+	}
+}
+
+func dataSourceZNodeExportRead(_ context.Context, rscData *schema.ResourceData, prvClient interface{}) diag.Diagnostics {
+	zkClient := prvClient.(*client.Client)
+
+	rootPath := rscData.Get("path").(string)
+	includeACL := rscData.Get("include_acl").(bool)
+
+	maxDepth := rscData.Get("max_depth").(int)
+	if maxDepth == 0 {
+		maxDepth = -1
+	}
+
+	statFieldsRaw := rscData.Get("stat_fields").([]interface{})
+	statFields := make([]string, 0, len(statFieldsRaw))
+	for _, statFieldRaw := range statFieldsRaw {
+		statFields = append(statFields, statFieldRaw.(string))
+	}
+
+	export := zNodeExport{
+		FormatVersion: zNodeExportFormatVersion,
+		Path:          rootPath,
+		ZNodes:        []zNodeExportZNode{},
+	}
+	walkOpts := client.WalkOptions{
+		MaxDepth:    maxDepth,
+		Concurrency: rscData.Get("concurrency").(int),
+		IncludeACL:  includeACL,
+	}
+	err := zkClient.WalkConcurrently(rootPath, walkOpts, func(znode *client.ZNode, _ int) error {
+		exported := zNodeExportZNode{
+			Path:       znode.Path,
+			DataBase64: base64.StdEncoding.EncodeToString(znode.Data),
+		}
+
+		for _, acl := range znode.ACL {
+			exported.ACL = append(exported.ACL, zNodeExportACL{Scheme: acl.Scheme, ID: acl.ID, Permissions: acl.Perms})
+		}
+
+		if len(statFields) > 0 {
+			stat := zNodeStatToMap(znode)
+			exported.Stat = make(map[string]interface{}, len(statFields))
+			for _, field := range statFields {
+				exported.Stat[field] = stat[field]
+			}
+		}
+
+		export.ZNodes = append(export.ZNodes, exported)
+		return nil
+	})
+	if err != nil {
+		return zkErrorf(zkErrorHint(zkClient, zNodeOperationRead, rootPath, err), "Unable to export subtree of ZNode '%s': %v", rootPath, err)
+	}
+
+	exportJSON, err := json.Marshal(export)
+	if err != nil {
+		return diag.Errorf("Unable to encode export of ZNode '%s': %v", rootPath, err)
+	}
+
+	// Terraform will use the path of the root ZNode as unique identifier for this Data Source
+	rscData.SetId(rootPath)
+
+	diags := diag.Diagnostics{}
+	for attribute, value := range map[string]interface{}{
+		"json":        string(exportJSON),
+		"znode_count": len(export.ZNodes),
+	} {
+		if err := rscData.Set(attribute, value); err != nil {
+			diags = append(diags, diag.FromErr(err)...)
+		}
+	}
+
+	return diags
+}
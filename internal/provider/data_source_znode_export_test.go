@@ -0,0 +1,61 @@
+package provider_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceZNodeExport(t *testing.T) {
+	rootPath := "/" + acctest.RandString(10)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { checkPreconditions(t) },
+		ProtoV6ProviderFactories: providerFactoriesMap(),
+		CheckDestroy:             confirmAllZNodeDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "zookeeper_znode" "a" {
+						path = "%[1]s/a"
+						data = "alpha"
+					}
+					resource "zookeeper_znode" "b_deep" {
+						path = "%[1]s/b/deep"
+						data = "beta"
+					}
+					data "zookeeper_znode_export" "no_acl" {
+						depends_on  = [zookeeper_znode.a, zookeeper_znode.b_deep]
+						path        = "%[1]s"
+						include_acl = false
+						concurrency = 1
+					}
+					data "zookeeper_znode_export" "shallow_with_stat" {
+						depends_on  = [zookeeper_znode.a, zookeeper_znode.b_deep]
+						path        = "%[1]s"
+						max_depth   = 1
+						stat_fields = ["version", "num_children"]
+					}`, rootPath,
+				),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.zookeeper_znode_export.no_acl", "id", rootPath),
+					resource.TestCheckResourceAttr("data.zookeeper_znode_export.no_acl", "znode_count", "4"),
+					resource.TestCheckResourceAttr("data.zookeeper_znode_export.no_acl", "json", fmt.Sprintf(
+						`{"format_version":1,"path":"%[1]s","znodes":[`+
+							`{"path":"%[1]s","data_base64":""},`+
+							`{"path":"%[1]s/a","data_base64":"YWxwaGE="},`+
+							`{"path":"%[1]s/b","data_base64":""},`+
+							`{"path":"%[1]s/b/deep","data_base64":"YmV0YQ=="}]}`, rootPath,
+					)),
+					resource.TestCheckResourceAttr("data.zookeeper_znode_export.shallow_with_stat", "znode_count", "3"),
+					resource.TestMatchResourceAttr("data.zookeeper_znode_export.shallow_with_stat", "json", regexp.MustCompile(
+						`"acl":\[\{"scheme":"world","id":"anyone","permissions":31\}\],"stat":\{"num_children":0,"version":0\}`,
+					)),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,34 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	testifyAssert "github.com/stretchr/testify/assert"
+)
+
+func TestConfiguredWaitForData(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	config := func(waitForData cty.Value) cty.Value {
+		return cty.ObjectVal(map[string]cty.Value{"path": cty.StringVal("/forza"), "wait_for_data": waitForData})
+	}
+
+	waitForData, ok := configuredWaitForData(config(cty.StringVal("status: ready")))
+	assert.True(ok)
+	assert.Equal("status: ready", waitForData)
+
+	// Waiting for the ZNode to be emptied
+	waitForData, ok = configuredWaitForData(config(cty.StringVal("")))
+	assert.True(ok)
+	assert.Equal("", waitForData)
+
+	_, ok = configuredWaitForData(config(cty.NullVal(cty.String)))
+	assert.False(ok)
+
+	_, ok = configuredWaitForData(config(cty.UnknownVal(cty.String)))
+	assert.False(ok)
+
+	_, ok = configuredWaitForData(cty.NullVal(cty.EmptyObject))
+	assert.False(ok)
+}
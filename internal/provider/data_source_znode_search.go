@@ -0,0 +1,136 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/tfzk/terraform-provider-zookeeper/client"
+)
+
+func datasourceZNodeSearch() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceZNodeSearchRead,
+		Schema: map[string]*schema.Schema{
+			"path": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Absolute path to the ZNode at the root of the subtree to search.",
+			},
+			"data_regex": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ExactlyOneOf:     []string{"data_regex", "data_contains"},
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringIsValidRegExp),
+				Description: "Regular expression that the content of a ZNode must match. " +
+					"Mutually exclusive with `data_contains`.",
+			},
+			"data_contains": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"data_regex", "data_contains"},
+				Description: "Substring that the content of a ZNode must contain. " +
+					"Mutually exclusive with `data_regex`.",
+			},
+			"max_depth": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          0,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
+				Description: "How many levels below `path` to search: " +
+					"`1` means only the direct children of `path`. `0` (default) means no limit.",
+			},
+			"concurrency": walkConcurrencySchema(),
+			"max_results": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          0,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
+				Description: "Stop searching once this many matching ZNodes are found. " +
+					"`0` (default) means no limit.",
+			},
+			"paths": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Absolute paths of the ZNodes whose content matches, in depth-first lexicographic order.",
+			},
+			"truncated": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the search stopped early, because `max_results` was reached.",
+			},
+		},
+		Description: "Searches the subtree of a " +
+			zNodeLinkForDesc + " for ZNodes whose content matches a regular expression, or contains a substring. " +
+			"Useful to locate configuration entries scattered across a tree. " +
+			"ZNodes the provider is not authorized to read, according to ZooKeeper ACL, are skipped along with their subtree.",
+	}
+}
+
+func dataSourceZNodeSearchRead(_ context.Context, rscData *schema.ResourceData, prvClient interface{}) diag.Diagnostics {
+	zkClient := prvClient.(*client.Client)
+
+	znodePath := rscData.Get("path").(string)
+	maxResults := rscData.Get("max_results").(int)
+
+	// `0` means "no limit" for the user: for client.Walk that is any negative value
+	maxDepth := rscData.Get("max_depth").(int)
+	if maxDepth == 0 {
+		maxDepth = -1
+	}
+
+	var matches func(data []byte) bool
+	if dataRegex, ok := rscData.GetOk("data_regex"); ok {
+		regex, err := regexp.Compile(dataRegex.(string))
+		if err != nil {
+			return diag.Errorf("Invalid 'data_regex': %v", err)
+		}
+		matches = regex.Match
+	} else {
+		substring := []byte(rscData.Get("data_contains").(string))
+		matches = func(data []byte) bool {
+			return bytes.Contains(data, substring)
+		}
+	}
+
+	matchingPaths := make([]string, 0)
+	truncated := false
+	walkOpts := client.WalkOptions{
+		MaxDepth:         maxDepth,
+		Concurrency:      rscData.Get("concurrency").(int),
+		SkipUnauthorized: true,
+	}
+	err := zkClient.WalkConcurrently(znodePath, walkOpts, func(znode *client.ZNode, _ int) error {
+		if !matches(znode.Data) {
+			return nil
+		}
+
+		if maxResults > 0 && len(matchingPaths) >= maxResults {
+			truncated = true
+			return client.ErrorStopWalk
+		}
+
+		matchingPaths = append(matchingPaths, znode.Path)
+		return nil
+	})
+	if err != nil {
+		return zkErrorf(zkErrorHint(zkClient, zNodeOperationRead, znodePath, err), "Unable to search ZNodes under '%s': %v", znodePath, err)
+	}
+
+	// Terraform will use the ZNode.Path as unique identifier for this Data Source
+	rscData.SetId(znodePath)
+
+	diags := diag.Diagnostics{}
+	if err := rscData.Set("paths", matchingPaths); err != nil {
+		diags = append(diags, diag.FromErr(err)...)
+	}
+	if err := rscData.Set("truncated", truncated); err != nil {
+		diags = append(diags, diag.FromErr(err)...)
+	}
+
+	return diags
+}
@@ -0,0 +1,75 @@
+package provider_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceZNodeSearch(t *testing.T) {
+	rootPath := "/" + acctest.RandString(10)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { checkPreconditions(t) },
+		ProtoV6ProviderFactories: providerFactoriesMap(),
+		CheckDestroy:             confirmAllZNodeDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "zookeeper_znode" "a" {
+						path = "%[1]s/a"
+						data = "host=db-old.example.com"
+					}
+					resource "zookeeper_znode" "b" {
+						path = "%[1]s/b"
+						data = "host=db-new.example.com"
+					}
+					resource "zookeeper_znode" "c_deep" {
+						path = "%[1]s/c/deep/deeper"
+						data = "host=db-old.example.com"
+					}
+					data "zookeeper_znode_search" "contains" {
+						depends_on    = [zookeeper_znode.a, zookeeper_znode.b, zookeeper_znode.c_deep]
+						path          = "%[1]s"
+						data_contains = "db-old"
+					}
+					data "zookeeper_znode_search" "regex" {
+						depends_on = [zookeeper_znode.a, zookeeper_znode.b, zookeeper_znode.c_deep]
+						path       = "%[1]s"
+						data_regex = "^host=db-(old|new)\\."
+					}
+					data "zookeeper_znode_search" "shallow" {
+						depends_on    = [zookeeper_znode.a, zookeeper_znode.b, zookeeper_znode.c_deep]
+						path          = "%[1]s"
+						data_contains = "db-old"
+						max_depth     = 1
+					}
+					data "zookeeper_znode_search" "limited" {
+						depends_on  = [zookeeper_znode.a, zookeeper_znode.b, zookeeper_znode.c_deep]
+						path        = "%[1]s"
+						data_regex  = "db-"
+						max_results = 1
+						concurrency = 1
+					}`, rootPath,
+				),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.zookeeper_znode_search.contains", "paths.#", "2"),
+					resource.TestCheckResourceAttr("data.zookeeper_znode_search.contains", "paths.0", rootPath+"/a"),
+					resource.TestCheckResourceAttr("data.zookeeper_znode_search.contains", "paths.1", rootPath+"/c/deep/deeper"),
+					resource.TestCheckResourceAttr("data.zookeeper_znode_search.contains", "truncated", "false"),
+
+					resource.TestCheckResourceAttr("data.zookeeper_znode_search.regex", "paths.#", "3"),
+
+					resource.TestCheckResourceAttr("data.zookeeper_znode_search.shallow", "paths.#", "1"),
+					resource.TestCheckResourceAttr("data.zookeeper_znode_search.shallow", "paths.0", rootPath+"/a"),
+
+					resource.TestCheckResourceAttr("data.zookeeper_znode_search.limited", "paths.#", "1"),
+					resource.TestCheckResourceAttr("data.zookeeper_znode_search.limited", "paths.0", rootPath+"/a"),
+					resource.TestCheckResourceAttr("data.zookeeper_znode_search.limited", "truncated", "true"),
+				),
+			},
+		},
+	})
+}
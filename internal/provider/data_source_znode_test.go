@@ -2,6 +2,7 @@ package provider_test
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
@@ -12,9 +13,9 @@ func TestAccDataSourceZNode(t *testing.T) {
 	srcPath := "/" + acctest.RandString(10)
 
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { checkPreconditions(t) },
-		ProviderFactories: providerFactoriesMap(),
-		CheckDestroy:      confirmAllZNodeDestroyed,
+		PreCheck:                 func() { checkPreconditions(t) },
+		ProtoV6ProviderFactories: providerFactoriesMap(),
+		CheckDestroy:             confirmAllZNodeDestroyed,
 		Steps: []resource.TestStep{
 			{
 				Config: fmt.Sprintf(`
@@ -35,6 +36,7 @@ func TestAccDataSourceZNode(t *testing.T) {
 
 					resource.TestCheckResourceAttrPair("data.zookeeper_znode.dst", "data_base64", "zookeeper_znode.src", "data_base64"),
 					resource.TestCheckResourceAttr("data.zookeeper_znode.dst", "data_base64", "Rm9yemEgTmFwb2xpIQ=="),
+					resource.TestCheckResourceAttr("data.zookeeper_znode.dst", "data_hex", "466f727a61204e61706f6c6921"),
 
 					resource.TestCheckResourceAttrPair("data.zookeeper_znode.dst", "stat", "zookeeper_znode.src", "stat"),
 
@@ -53,6 +55,10 @@ func TestAccDataSourceZNode(t *testing.T) {
 					resource.TestCheckResourceAttr("data.zookeeper_znode.dst", "stat.0.aversion", "0"),
 
 					resource.TestCheckResourceAttrPair("data.zookeeper_znode.dst", "stat.0.ephemeral_owner", "zookeeper_znode.src", "stat.0.ephemeral_owner"),
+					resource.TestCheckResourceAttr("data.zookeeper_znode.dst", "is_ephemeral", "false"),
+					resource.TestCheckResourceAttrPair("data.zookeeper_znode.dst", "is_ephemeral", "zookeeper_znode.src", "is_ephemeral"),
+					resource.TestCheckResourceAttr("data.zookeeper_znode.dst", "ephemeral_owner", ""),
+					resource.TestCheckResourceAttrPair("data.zookeeper_znode.dst", "ephemeral_owner", "zookeeper_znode.src", "ephemeral_owner"),
 
 					resource.TestCheckResourceAttrPair("data.zookeeper_znode.dst", "stat.0.data_length", "zookeeper_znode.src", "stat.0.data_length"),
 					resource.TestCheckResourceAttr("data.zookeeper_znode.dst", "stat.0.data_length", "13"),
@@ -71,3 +77,182 @@ func TestAccDataSourceZNode(t *testing.T) {
 		},
 	})
 }
+
+func TestAccDataSourceZNode_WaitForExists(t *testing.T) {
+	srcPath := "/" + acctest.RandString(10)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { checkPreconditions(t) },
+		ProtoV6ProviderFactories: providerFactoriesMap(),
+		CheckDestroy:             confirmAllZNodeDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "zookeeper_znode" "src" {
+						path = "%s"
+						data = "I'm here"
+					}
+					data "zookeeper_znode" "dst" {
+						path            = zookeeper_znode.src.path
+						wait_for_exists = true
+						timeout         = "30s"
+					}`, srcPath,
+				),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.zookeeper_znode.dst", "path", "zookeeper_znode.src", "path"),
+					resource.TestCheckResourceAttr("data.zookeeper_znode.dst", "data", "I'm here"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceZNode_WaitForExistsTimeout(t *testing.T) {
+	missingPath := "/" + acctest.RandString(10)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { checkPreconditions(t) },
+		ProtoV6ProviderFactories: providerFactoriesMap(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					data "zookeeper_znode" "missing" {
+						path            = "%s"
+						wait_for_exists = true
+						timeout         = "1s"
+					}`, missingPath,
+				),
+				ExpectError: regexp.MustCompile(`timed out after 1s waiting for ZNode`),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceZNode_WaitForData(t *testing.T) {
+	srcPath := "/" + acctest.RandString(10)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { checkPreconditions(t) },
+		ProtoV6ProviderFactories: providerFactoriesMap(),
+		CheckDestroy:             confirmAllZNodeDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "zookeeper_znode" "src" {
+						path = "%s"
+						data = "status: ready"
+					}
+					data "zookeeper_znode" "exact" {
+						path          = zookeeper_znode.src.path
+						wait_for_data = "status: ready"
+						timeout       = "30s"
+					}
+					data "zookeeper_znode" "regex" {
+						path                = zookeeper_znode.src.path
+						wait_for_data_regex = "^status: (ready|done)$"
+						timeout             = "30s"
+					}`, srcPath,
+				),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.zookeeper_znode.exact", "data", "status: ready"),
+					resource.TestCheckResourceAttr("data.zookeeper_znode.regex", "data", "status: ready"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+					resource "zookeeper_znode" "src" {
+						path = "%s"
+						data = "status: starting"
+					}
+					data "zookeeper_znode" "exact" {
+						path          = zookeeper_znode.src.path
+						wait_for_data = "status: ready"
+						timeout       = "1s"
+					}`, srcPath,
+				),
+				ExpectError: regexp.MustCompile(`waiting for ZNode '` + srcPath + `' to contain the expected data`),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceZNode_Retries(t *testing.T) {
+	srcPath := "/" + acctest.RandString(10)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { checkPreconditions(t) },
+		ProtoV6ProviderFactories: providerFactoriesMap(),
+		CheckDestroy:             confirmAllZNodeDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "zookeeper_znode" "src" {
+						path = "%s"
+						data = "Forza Napoli!"
+					}
+					data "zookeeper_znode" "dst" {
+						path           = zookeeper_znode.src.path
+						retries        = 3
+						retry_interval = "100ms"
+						retry_error_classes = {
+							no_node = 2
+						}
+					}`, srcPath,
+				),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.zookeeper_znode.dst", "data", "Forza Napoli!"),
+					resource.TestCheckResourceAttr("data.zookeeper_znode.dst", "retries", "3"),
+					resource.TestCheckResourceAttr("data.zookeeper_znode.dst", "retry_interval", "100ms"),
+					resource.TestCheckResourceAttr("data.zookeeper_znode.dst", "retry_error_classes.no_node", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceZNode_AllowMissing(t *testing.T) {
+	srcPath := "/" + acctest.RandString(10)
+	missingPath := "/" + acctest.RandString(10)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { checkPreconditions(t) },
+		ProtoV6ProviderFactories: providerFactoriesMap(),
+		CheckDestroy:             confirmAllZNodeDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "zookeeper_znode" "src" {
+						path = "%s"
+						data = "Forza Napoli!"
+					}
+					data "zookeeper_znode" "found" {
+						path          = zookeeper_znode.src.path
+						allow_missing = true
+					}
+					data "zookeeper_znode" "missing" {
+						path          = "%s"
+						allow_missing = true
+					}`, srcPath, missingPath,
+				),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.zookeeper_znode.found", "found", "true"),
+					resource.TestCheckResourceAttr("data.zookeeper_znode.found", "data", "Forza Napoli!"),
+					resource.TestCheckResourceAttr("data.zookeeper_znode.missing", "id", missingPath),
+					resource.TestCheckResourceAttr("data.zookeeper_znode.missing", "found", "false"),
+					resource.TestCheckResourceAttr("data.zookeeper_znode.missing", "data", ""),
+					resource.TestCheckResourceAttr("data.zookeeper_znode.missing", "data_base64", ""),
+					resource.TestCheckResourceAttr("data.zookeeper_znode.missing", "stat.#", "0"),
+					resource.TestCheckResourceAttr("data.zookeeper_znode.missing", "acl.#", "0"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+					data "zookeeper_znode" "missing" {
+						path = "%s"
+					}`, missingPath,
+				),
+				ExpectError: regexp.MustCompile(`node does not exist`),
+			},
+		},
+	})
+}
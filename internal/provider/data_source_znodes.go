@@ -0,0 +1,127 @@
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/tfzk/terraform-provider-zookeeper/client"
+)
+
+func datasourceZNodes() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceZNodesRead,
+		Schema: map[string]*schema.Schema{
+			"paths": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Absolute paths to the ZNodes to read.",
+			},
+			"retries":             retriesSchema(),
+			"retry_interval":      retryIntervalSchema(),
+			"retry_error_classes": retryErrorClassesSchema(),
+			"data": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Map of ZNode path to its content, as UTF-8 string.",
+			},
+			"data_base64": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Map of ZNode path to its content, encoded in Base64.",
+			},
+			"znodes": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"path": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Absolute path to the ZNode.",
+						},
+						"data": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Content of the ZNode, as UTF-8 string.",
+						},
+						"data_base64": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Content of the ZNode, encoded in Base64.",
+						},
+						"stat": statSchema(),
+					},
+				},
+				Description: "List of the ZNodes read, in the same order as `paths`.",
+			},
+		},
+		Description: "Provides access to the content of multiple ZNodes at once. " +
+			"Each " + zNodeLinkForDesc + " is read concurrently, replacing many individual `zookeeper_znode` data sources " +
+			"(and their round trips) with a single one. " +
+			"The ability to access ZNodes is determined by ZooKeeper ACL.",
+	}
+}
+
+func dataSourceZNodesRead(ctx context.Context, rscData *schema.ResourceData, prvClient interface{}) diag.Diagnostics {
+	zkClient := prvClient.(*client.Client)
+
+	pathsRaw := rscData.Get("paths").([]interface{})
+	znodePaths := make([]string, 0, len(pathsRaw))
+	for _, pathRaw := range pathsRaw {
+		znodePaths = append(znodePaths, pathRaw.(string))
+	}
+
+	retryPolicy, err := getRetryPolicyFromResourceData(rscData)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var znodes []*client.ZNode
+	err = zkClient.Retry(ctx, retryPolicy, func() (readErr error) {
+		znodes, readErr = zkClient.ReadMany(znodePaths)
+		return readErr
+	})
+	if err != nil {
+		return zkErrorf(zkErrorHint(zkClient, zNodeOperationRead, "", err), "Unable to read ZNodes: %v", err)
+	}
+
+	dataMap := make(map[string]interface{}, len(znodes))
+	dataBase64Map := make(map[string]interface{}, len(znodes))
+	znodesList := make([]interface{}, 0, len(znodes))
+	for _, znode := range znodes {
+		dataBase64 := base64.StdEncoding.EncodeToString(znode.Data)
+
+		dataMap[znode.Path] = string(znode.Data)
+		dataBase64Map[znode.Path] = dataBase64
+		znodesList = append(znodesList, map[string]interface{}{
+			"path":        znode.Path,
+			"data":        string(znode.Data),
+			"data_base64": dataBase64,
+			"stat":        []interface{}{zNodeStatToMap(znode)},
+		})
+	}
+
+	// Terraform will use the hash of the (ordered) paths as unique identifier for this Data Source
+	rscData.SetId(strconv.Itoa(schema.HashString(strings.Join(znodePaths, ","))))
+
+	diags := diag.Diagnostics{}
+	for attribute, value := range map[string]interface{}{
+		"data":        dataMap,
+		"data_base64": dataBase64Map,
+		"znodes":      znodesList,
+	} {
+		if err := rscData.Set(attribute, value); err != nil {
+			diags = append(diags, diag.FromErr(err)...)
+		}
+	}
+
+	return diags
+}
@@ -0,0 +1,74 @@
+package provider_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceZNodes(t *testing.T) {
+	parentPath := "/" + acctest.RandString(10)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { checkPreconditions(t) },
+		ProtoV6ProviderFactories: providerFactoriesMap(),
+		CheckDestroy:             confirmAllZNodeDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "zookeeper_znode" "first" {
+						path = "%[1]s/first"
+						data = "Forza"
+					}
+					resource "zookeeper_znode" "second" {
+						path = "%[1]s/second"
+						data = "Napoli!"
+					}
+					data "zookeeper_znodes" "dst" {
+						paths = [
+							zookeeper_znode.first.path,
+							zookeeper_znode.second.path,
+						]
+					}`, parentPath,
+				),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.zookeeper_znodes.dst", "data.%", "2"),
+					resource.TestCheckResourceAttr("data.zookeeper_znodes.dst", "data."+parentPath+"/first", "Forza"),
+					resource.TestCheckResourceAttr("data.zookeeper_znodes.dst", "data."+parentPath+"/second", "Napoli!"),
+					resource.TestCheckResourceAttr("data.zookeeper_znodes.dst", "data_base64."+parentPath+"/first", "Rm9yemE="),
+					resource.TestCheckResourceAttr("data.zookeeper_znodes.dst", "data_base64."+parentPath+"/second", "TmFwb2xpIQ=="),
+
+					resource.TestCheckResourceAttr("data.zookeeper_znodes.dst", "znodes.#", "2"),
+					resource.TestCheckResourceAttrPair("data.zookeeper_znodes.dst", "znodes.0.path", "zookeeper_znode.first", "path"),
+					resource.TestCheckResourceAttrPair("data.zookeeper_znodes.dst", "znodes.0.data", "zookeeper_znode.first", "data"),
+					resource.TestCheckResourceAttrPair("data.zookeeper_znodes.dst", "znodes.0.stat.0.mzxid", "zookeeper_znode.first", "stat.0.mzxid"),
+					resource.TestCheckResourceAttrPair("data.zookeeper_znodes.dst", "znodes.1.path", "zookeeper_znode.second", "path"),
+					resource.TestCheckResourceAttrPair("data.zookeeper_znodes.dst", "znodes.1.data_base64", "zookeeper_znode.second", "data_base64"),
+					resource.TestCheckResourceAttrPair("data.zookeeper_znodes.dst", "znodes.1.stat.0.mzxid", "zookeeper_znode.second", "stat.0.mzxid"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceZNodes_Missing(t *testing.T) {
+	missingPath := "/" + acctest.RandString(10)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { checkPreconditions(t) },
+		ProtoV6ProviderFactories: providerFactoriesMap(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					data "zookeeper_znodes" "missing" {
+						paths = ["%s"]
+					}`, missingPath,
+				),
+				ExpectError: regexp.MustCompile(`node does not exist`),
+			},
+		},
+	})
+}
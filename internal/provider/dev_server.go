@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/tfzk/terraform-provider-zookeeper/client"
+)
+
+const (
+	// devServerImage is the container image of the ZooKeeper Server started by `dev_server`.
+	devServerImage = "zookeeper:3.9"
+	// devServerStartTimeout is how long to wait for the ZooKeeper Server started by `dev_server` to be ready.
+	devServerStartTimeout = 60 * time.Second
+)
+
+// devServer is a throwaway, single server, ZooKeeper Ensemble running in a Docker container (see `dev_server`).
+type devServer struct {
+	// docker is the Docker CLI used to manage the container
+	docker      string
+	containerID string
+	// address is the 'host:port' the ZooKeeper Server listens on
+	address string
+}
+
+// startDevServer starts a devServer via the given Docker CLI, and waits for it to be ready.
+//
+// The container is removed once stopped: if it's not ready in time, it is stopped before returning.
+func startDevServer(ctx context.Context, docker string) (*devServer, error) {
+	ds := &devServer{docker: docker}
+
+	containerID, err := ds.run(ctx, "run", "--detach", "--rm", "--publish", "127.0.0.1::2181", devServerImage)
+	if err != nil {
+		return nil, fmt.Errorf("unable to start ZooKeeper dev server: %w", err)
+	}
+	ds.containerID = containerID
+
+	// Ex. `127.0.0.1:49153`
+	ports, err := ds.run(ctx, "port", ds.containerID, "2181/tcp")
+	if err != nil {
+		ds.stop()
+		return nil, fmt.Errorf("unable to find ZooKeeper dev server port: %w", err)
+	}
+	ds.address = strings.SplitN(ports, "\n", 2)[0]
+
+	deadline := time.Now().Add(devServerStartTimeout)
+	for {
+		if _, err = client.GetServerVersion(ds.address, time.Second); err == nil {
+			return ds, nil
+		}
+		if time.Now().After(deadline) || ctx.Err() != nil {
+			ds.stop()
+			return nil, fmt.Errorf("ZooKeeper dev server at '%s' not ready after %s: %w", ds.address, devServerStartTimeout, err)
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
+}
+
+// stop removes the container of the devServer, and so all its ZNodes. Errors are ignored: the provider is exiting.
+func (ds *devServer) stop() {
+	_, _ = ds.run(context.Background(), "rm", "--force", ds.containerID)
+}
+
+// run runs the Docker CLI with the given arguments, returning its trimmed standard output.
+func (ds *devServer) run(ctx context.Context, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, ds.docker, args...)
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("'%s %s' failed: %w (%s)", ds.docker, strings.Join(args, " "), err, strings.TrimSpace(stderr.String()))
+	}
+
+	return strings.TrimSpace(string(out)), nil
+}
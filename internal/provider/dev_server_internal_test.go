@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	testifyAssert "github.com/stretchr/testify/assert"
+)
+
+// fakeDocker writes a Docker CLI that "runs" a container listening at the given address,
+// recording its arguments to `docker.log` in the given directory.
+func fakeDocker(t *testing.T, dir string, address string) string {
+	docker := filepath.Join(dir, "docker")
+	script := "#!/bin/sh\n" +
+		"echo \"$@\" >> " + filepath.Join(dir, "docker.log") + "\n" +
+		"case \"$1\" in\n" +
+		"  run) echo container-id ;;\n" +
+		"  port) echo " + address + " ;;\n" +
+		"esac\n"
+	if err := os.WriteFile(docker, []byte(script), 0o700); err != nil { //nolint:gosec // Must be executable
+		t.Fatal(err)
+	}
+
+	return docker
+}
+
+// serveSrvr accepts connections replying to `srvr` like a ZooKeeper Server does.
+func serveSrvr(t *testing.T) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				if _, err := conn.Read(make([]byte, 4)); err == nil {
+					_, _ = io.WriteString(conn, "Zookeeper version: 3.9.2-e454e8c7283100c7caec6dcae2bc82aaecb63023, built on 2024-02-12 20:59 UTC\nMode: standalone\n")
+				}
+			}()
+		}
+	}()
+
+	return listener.Addr().String()
+}
+
+func TestZKClientCacheDevServer(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	dir := t.TempDir()
+	address := serveSrvr(t)
+	cache := &zkClientCache{docker: fakeDocker(t, dir, address)}
+
+	// Connecting happens in the background: the fake Server only needs to reply to `srvr`
+	c, err := cache.get(zkClientConfig{devServer: true, sessionTimeout: 1})
+	assert.NoError(err)
+	assert.Equal([]string{address}, c.Servers())
+
+	// The dev server is shared by all configurations
+	c, err = cache.get(zkClientConfig{devServer: true, sessionTimeout: 2})
+	assert.NoError(err)
+	assert.Equal([]string{address}, c.Servers())
+
+	cache.stop()
+	log, err := os.ReadFile(filepath.Join(dir, "docker.log"))
+	assert.NoError(err)
+	assert.Equal("run --detach --rm --publish 127.0.0.1::2181 "+devServerImage+"\n"+
+		"port container-id 2181/tcp\n"+
+		"rm --force container-id\n", string(log))
+}
@@ -0,0 +1,179 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	pathpkg "path"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/tfzk/terraform-provider-zookeeper/client"
+)
+
+// zNodeOperation is the operation on a ZNode that caused an error, used by zkErrorHint
+// to tailor the hint: for example, ZooKeeper checks the ACL of the parent when creating
+// or deleting a ZNode, and the ACL of the ZNode itself otherwise.
+type zNodeOperation string
+
+const (
+	zNodeOperationCreate zNodeOperation = "create"
+	zNodeOperationRead   zNodeOperation = "read"
+	zNodeOperationUpdate zNodeOperation = "update"
+	zNodeOperationDelete zNodeOperation = "delete"
+)
+
+// zkErrorHint translates an error returned by client.Client, while performing the given operation
+// on the ZNode at the given path, into an actionable hint for the user.
+//
+// It returns an empty string if there is no hint to give. `zkClient` is only used to look up
+// the ACL involved in authorization errors, and can be `nil`; `znodePath` can be empty
+// if the error is not about a specific ZNode.
+func zkErrorHint(zkClient *client.Client, operation zNodeOperation, znodePath string, err error) string {
+	znodeDesc := zNodeDesc(znodePath)
+
+	switch {
+	case errors.Is(err, client.ErrorNotAuthorized):
+		return notAuthorizedHint(zkClient, operation, znodePath, znodeDesc)
+	case errors.Is(err, client.ErrorPathNotAllowed):
+		return fmt.Sprintf("The provider is not allowed to %s %s: check the provider `allowed_path_prefixes`.", operation, znodeDesc)
+	case errors.Is(err, client.ErrorPathProtected):
+		return fmt.Sprintf("The provider refuses to %s %s, to protect it: ZNodes under `%s` and the provider `denied_paths` are never modified.",
+			operation, znodeDesc, systemZNodesPath)
+	case errors.Is(err, client.ErrorACLWorldOpen):
+		return "The provider `deny_world_open_acls` forbids granting `world:anyone` any permission other than READ: " +
+			"set an `acl` restricting who can modify the ZNode (ex. via the `digest` or `auth` schemes)."
+	case errors.Is(err, client.ErrorWriteLockTimeout):
+		return "Another Terraform run, configured with the same provider `lock_path`, is making changes: " +
+			"retry once it's done, or increase the provider `lock_timeout`."
+	case errors.Is(err, client.ErrorNoLeader):
+		return "The ZooKeeper Ensemble is likely electing a leader, and can't accept changes: " +
+			"retry once the election completes, or check the health of the Ensemble if it persists " +
+			"(ex. via the `zookeeper_ensemble_health` data source)."
+	case errors.Is(err, client.ErrorNoQuota):
+		return fmt.Sprintf("No quota is set on %s: set one via `setquota` in the ZooKeeper CLI, or check the path.", znodeDesc)
+	case errors.Is(err, client.ErrorReconfigDisabled):
+		return "Dynamic reconfiguration is disabled on the ZooKeeper Ensemble: enable `reconfigEnabled` on all its servers."
+	case errors.Is(err, client.ErrorAuthFailed):
+		return "Authentication with ZooKeeper failed: check the provider `username` and `password`."
+	case errors.Is(err, client.ErrorInvalidACL):
+		return "The ACL is not valid: check the `scheme` and `id` of each entry " +
+			"(ex. `digest` IDs are `<username>:<base64(sha1(<username>:<password>))>`, " +
+			"and `auth` requires the provider to be configured with `username` and `password`)."
+	case errors.Is(err, client.ErrorZNodeDoesNotExist):
+		return doesNotExistHint(operation, znodeDesc)
+	case errors.Is(err, client.ErrorZNodeAlreadyExists):
+		return fmt.Sprintf("%s already exists, but it's not managed by this resource: "+
+			"import it (see `terraform import`), or delete it first.", capitalize(znodeDesc))
+	case errors.Is(err, client.ErrorBadVersion):
+		return fmt.Sprintf("%s was concurrently modified by another client: "+
+			"refresh the state (ex. `terraform apply -refresh-only`) and retry.", capitalize(znodeDesc))
+	case errors.Is(err, client.ErrorZNodeHasChildren):
+		return fmt.Sprintf("%s has children, likely created concurrently by another client: retry.", capitalize(znodeDesc))
+	case client.IsTransientError(err):
+		return "The connection to the ZooKeeper Ensemble was lost: " +
+			"check its health (ex. via the `zookeeper_ensemble_health` data source) and retry."
+	}
+
+	return ""
+}
+
+// doesNotExistHint is the zkErrorHint for client.ErrorZNodeDoesNotExist.
+func doesNotExistHint(operation zNodeOperation, znodeDesc string) string {
+	// The client creates any missing parent before creating a ZNode
+	if operation == zNodeOperationCreate {
+		return fmt.Sprintf("A parent of %s does not exist: it was likely deleted by another client "+
+			"while the parents were being created. Retrying should create it.", znodeDesc)
+	}
+
+	return fmt.Sprintf("%s does not exist: it was likely deleted outside of Terraform.", capitalize(znodeDesc))
+}
+
+// notAuthorizedHint is the zkErrorHint for client.ErrorNotAuthorized.
+func notAuthorizedHint(zkClient *client.Client, operation zNodeOperation, znodePath, znodeDesc string) string {
+	// ZooKeeper authorizes creation and deletion of a ZNode via the ACL of its parent
+	aclPath, aclDesc := znodePath, znodeDesc
+	if (operation == zNodeOperationCreate || operation == zNodeOperationDelete) && znodePath != "" {
+		aclPath = pathpkg.Dir(znodePath)
+		aclDesc = fmt.Sprintf("its parent '%s'", aclPath)
+	}
+
+	hint := fmt.Sprintf("The provider is not authorized to %s %s by the ACL of %s. ", operation, znodeDesc, aclDesc)
+
+	acls := []string{}
+	if zkClient != nil && !zkClient.SkipsACLReads() && aclPath != "" {
+		// Best effort: reading the ACL might not be authorized either
+		if zkACLs, err := zkClient.ReadACL(aclPath); err == nil {
+			for _, acl := range zkACLs {
+				acls = append(acls, fmt.Sprintf("`%s:%s` (permissions: %d)", acl.Scheme, acl.ID, acl.Perms))
+			}
+		}
+	}
+	if len(acls) > 0 {
+		hint += fmt.Sprintf("Its ACL allows: %s. ", strings.Join(acls, ", "))
+	}
+
+	return hint + "Authenticate with a scheme and ID the ACL allows " +
+		"(ex. provider `username` and `password` for `digest`), or fix the ACL."
+}
+
+// zNodeDesc describes the ZNode at the given path, for use in hints.
+func zNodeDesc(znodePath string) string {
+	if znodePath == "" {
+		return "the ZNode"
+	}
+
+	return fmt.Sprintf("ZNode '%s'", znodePath)
+}
+
+// capitalize returns the given string with its first letter in upper case.
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+// redactedData replaces, in diagnostics, values derived from the content of ZNodes (see redactData).
+const redactedData = "(redacted)"
+
+// redactData returns the given value, derived from the content of a ZNode, to be included in a diagnostic:
+// it's replaced by redactedData if the provider is configured with `redact_data`.
+func redactData(zkClient *client.Client, value string) string {
+	if zkClient.RedactsData() {
+		return redactedData
+	}
+
+	return value
+}
+
+// redactDataError is like redactData, for errors that might include the content of a ZNode (ex. parsing errors).
+func redactDataError(zkClient *client.Client, err error) error {
+	if zkClient.RedactsData() {
+		return errors.New(redactedData)
+	}
+
+	return err
+}
+
+// zkErrorf is the equivalent of diag.Errorf for errors returned by client.Client:
+// the hint (see zkErrorHint), if any, is reported as the detail of the diagnostic.
+func zkErrorf(hint string, format string, a ...interface{}) diag.Diagnostics {
+	return diag.Diagnostics{
+		diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf(format, a...),
+			Detail:   hint,
+		},
+	}
+}
+
+// withHint appends the given hint (see zkErrorHint), if any, to the detail of a
+// terraform-plugin-framework diagnostic.
+func withHint(detail string, hint string) string {
+	if hint == "" {
+		return detail
+	}
+
+	return detail + "\n\n" + hint
+}
@@ -0,0 +1,65 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	testifyAssert "github.com/stretchr/testify/assert"
+	"github.com/tfzk/terraform-provider-zookeeper/client"
+)
+
+func TestZKErrorHint(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	wrap := func(err error) error {
+		return fmt.Errorf("failed to do something with ZNode '/a/b': %w", err)
+	}
+
+	assert.Contains(zkErrorHint(nil, zNodeOperationCreate, "/a/b", wrap(client.ErrorNotAuthorized)),
+		"not authorized to create ZNode '/a/b' by the ACL of its parent '/a'")
+	assert.Contains(zkErrorHint(nil, zNodeOperationUpdate, "/a/b", wrap(client.ErrorNotAuthorized)),
+		"not authorized to update ZNode '/a/b' by the ACL of ZNode '/a/b'")
+	assert.Contains(zkErrorHint(nil, zNodeOperationCreate, "/a/b", wrap(client.ErrorZNodeDoesNotExist)),
+		"A parent of ZNode '/a/b' does not exist")
+	assert.Contains(zkErrorHint(nil, zNodeOperationRead, "/a/b", wrap(client.ErrorZNodeDoesNotExist)),
+		"ZNode '/a/b' does not exist: it was likely deleted outside of Terraform")
+	assert.Contains(zkErrorHint(nil, zNodeOperationUpdate, "/a/b", wrap(client.ErrorBadVersion)),
+		"concurrently modified")
+	assert.Contains(zkErrorHint(nil, zNodeOperationRead, "", wrap(client.ErrorConnectionClosed)),
+		"connection to the ZooKeeper Ensemble was lost")
+	assert.Contains(zkErrorHint(nil, zNodeOperationRead, "", wrap(client.ErrorZNodeDoesNotExist)),
+		"The ZNode does not exist")
+	assert.Contains(zkErrorHint(nil, zNodeOperationDelete, "/a/b", wrap(client.ErrorPathNotAllowed)),
+		"not allowed to delete ZNode '/a/b': check the provider `allowed_path_prefixes`")
+	assert.Contains(zkErrorHint(nil, zNodeOperationUpdate, "/zookeeper/quota", wrap(client.ErrorPathProtected)),
+		"refuses to update ZNode '/zookeeper/quota', to protect it")
+	assert.Contains(zkErrorHint(nil, zNodeOperationCreate, "/a/b", wrap(client.ErrorACLWorldOpen)),
+		"`deny_world_open_acls` forbids granting `world:anyone`")
+	assert.Contains(zkErrorHint(nil, zNodeOperationCreate, "/a/b", wrap(client.ErrorWriteLockTimeout)),
+		"Another Terraform run, configured with the same provider `lock_path`")
+	assert.Contains(zkErrorHint(nil, zNodeOperationCreate, "/a/b", wrap(client.ErrorNoLeader)),
+		"The ZooKeeper Ensemble is likely electing a leader")
+	assert.Contains(zkErrorHint(nil, zNodeOperationRead, "/a/b", wrap(client.ErrorNoQuota)),
+		"No quota is set on ZNode '/a/b'")
+	assert.Contains(zkErrorHint(nil, zNodeOperationUpdate, "/zookeeper/config", wrap(client.ErrorReconfigDisabled)),
+		"Dynamic reconfiguration is disabled")
+	assert.Empty(zkErrorHint(nil, zNodeOperationRead, "/a/b", fmt.Errorf("something else")))
+}
+
+func TestRedactData(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	// Connecting happens in the background: no ZooKeeper Server is necessary
+	redactingClient, err := client.NewClient("127.0.0.1:1", 1, "", "", client.WithDataRedaction())
+	assert.NoError(err)
+	plainClient, err := client.NewClient("127.0.0.1:1", 1, "", "")
+	assert.NoError(err)
+
+	assert.Equal(redactedData, redactData(redactingClient, "s3cr3t"))
+	assert.Equal("s3cr3t", redactData(plainClient, "s3cr3t"))
+	assert.Equal("s3cr3t", redactData(nil, "s3cr3t"))
+
+	parseErr := fmt.Errorf("invalid character 's' looking for beginning of value")
+	assert.EqualError(redactDataError(redactingClient, parseErr), redactedData)
+	assert.Equal(parseErr, redactDataError(plainClient, parseErr))
+}
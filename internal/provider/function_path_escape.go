@@ -0,0 +1,48 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/tfzk/terraform-provider-zookeeper/client"
+)
+
+// pathEscapeFunction implements the `path_escape` provider-defined function.
+type pathEscapeFunction struct{}
+
+var _ function.Function = pathEscapeFunction{}
+
+func newPathEscapeFunction() function.Function {
+	return pathEscapeFunction{}
+}
+
+func (f pathEscapeFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "path_escape"
+}
+
+func (f pathEscapeFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Escapes a string to be used as ZNode name",
+		MarkdownDescription: "Escapes the given string, so that it can be safely used as the name of a ZNode (i.e. a single path segment). " +
+			"Characters like `/` are percent-encoded as they would be in a URL path (ex. `a/b` becomes `a%2Fb`), " +
+			"and so are the reserved names `.` and `..`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "name",
+				MarkdownDescription: "String to escape.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f pathEscapeFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var name string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &name))
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, client.EscapeName(name)))
+}
@@ -0,0 +1,45 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/tfzk/terraform-provider-zookeeper/client"
+)
+
+// pathJoinFunction implements the `path_join` provider-defined function.
+type pathJoinFunction struct{}
+
+var _ function.Function = pathJoinFunction{}
+
+func newPathJoinFunction() function.Function {
+	return pathJoinFunction{}
+}
+
+func (f pathJoinFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "path_join"
+}
+
+func (f pathJoinFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Joins parts into an absolute ZNode path",
+		MarkdownDescription: "Joins the given parts with `/`, into an absolute ZNode path. " +
+			"Empty parts and redundant `/` are ignored, so that `path_join(\"/parent/\", \"\", \"child\")` returns `/parent/child`.",
+		VariadicParameter: function.StringParameter{
+			Name:                "parts",
+			MarkdownDescription: "Parts of the path to join.",
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f pathJoinFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var parts []string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &parts))
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, client.JoinPaths(parts...)))
+}
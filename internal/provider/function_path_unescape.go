@@ -0,0 +1,55 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/tfzk/terraform-provider-zookeeper/client"
+)
+
+// pathUnescapeFunction implements the `path_unescape` provider-defined function.
+type pathUnescapeFunction struct{}
+
+var _ function.Function = pathUnescapeFunction{}
+
+func newPathUnescapeFunction() function.Function {
+	return pathUnescapeFunction{}
+}
+
+func (f pathUnescapeFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "path_unescape"
+}
+
+func (f pathUnescapeFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Unescapes the name of a ZNode escaped via path_escape",
+		MarkdownDescription: "Reverses `path_escape`, returning the original string from the name of a ZNode " +
+			"(ex. `a/b` for `a%2Fb`): useful to recover values from the `name` of ZNodes, " +
+			"or from the `names` of the `zookeeper_znode_children` data source. " +
+			"Fails if the name contains a `%` not followed by 2 hexadecimal digits.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "name",
+				MarkdownDescription: "Escaped name of a ZNode.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f pathUnescapeFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var name string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &name))
+	if resp.Error != nil {
+		return
+	}
+
+	unescaped, err := client.UnescapeName(name)
+	if err != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, function.NewArgumentFuncError(0, err.Error()))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, unescaped))
+}
@@ -0,0 +1,54 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/tfzk/terraform-provider-zookeeper/client"
+)
+
+// sequenceNumberFunction implements the `sequence_number` provider-defined function.
+type sequenceNumberFunction struct{}
+
+var _ function.Function = sequenceNumberFunction{}
+
+func newSequenceNumberFunction() function.Function {
+	return sequenceNumberFunction{}
+}
+
+func (f sequenceNumberFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "sequence_number"
+}
+
+func (f sequenceNumberFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Returns the number in the suffix of a Sequential ZNode path",
+		MarkdownDescription: "Returns the number contained in the unique, monotonically increasing, suffix of the path of a " +
+			"[Sequential ZNode](https://zookeeper.apache.org/doc/current/zookeeperProgrammers.html#Sequence+Nodes+--+Unique+Naming) " +
+			"(ex. `42` for `/locks/lock-0000000042`). Fails if the path doesn't end with such a suffix.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "path",
+				MarkdownDescription: "Path of the Sequential ZNode (ex. the `path` of a `zookeeper_sequential_znode`).",
+			},
+		},
+		Return: function.Int64Return{},
+	}
+}
+
+func (f sequenceNumberFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var path string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &path))
+	if resp.Error != nil {
+		return
+	}
+
+	number, err := client.SequenceNumber(path)
+	if err != nil {
+		resp.Error = function.ConcatFuncErrors(resp.Error, function.NewArgumentFuncError(0, err.Error()))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, number))
+}
@@ -0,0 +1,86 @@
+package provider_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	testifyAssert "github.com/stretchr/testify/assert"
+	"github.com/tfzk/terraform-provider-zookeeper/internal/provider"
+)
+
+// callFunction calls the given provider-defined function with string arguments,
+// returning the result of the given type.
+func callFunction(t *testing.T, name string, resultType tftypes.Type, args ...string) (tftypes.Value, *tfprotov6.FunctionError) {
+	providerServer, err := provider.NewProviderServer(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	arguments := make([]*tfprotov6.DynamicValue, 0, len(args))
+	for _, arg := range args {
+		argument, err := tfprotov6.NewDynamicValue(tftypes.String, tftypes.NewValue(tftypes.String, arg))
+		if err != nil {
+			t.Fatal(err)
+		}
+		arguments = append(arguments, &argument)
+	}
+
+	resp, err := providerServer().CallFunction(context.Background(), &tfprotov6.CallFunctionRequest{
+		Name:      name,
+		Arguments: arguments,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Error != nil {
+		return tftypes.Value{}, resp.Error
+	}
+
+	result, err := resp.Result.Unmarshal(resultType)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return result, nil
+}
+
+func TestFunctionPathJoin(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	result, funcErr := callFunction(t, "path_join", tftypes.String, "/parent/", "", "child")
+	assert.Nil(funcErr)
+	assert.Equal(tftypes.NewValue(tftypes.String, "/parent/child"), result)
+}
+
+func TestFunctionPathEscape(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	result, funcErr := callFunction(t, "path_escape", tftypes.String, "orders/db")
+	assert.Nil(funcErr)
+	assert.Equal(tftypes.NewValue(tftypes.String, "orders%2Fdb"), result)
+}
+
+func TestFunctionPathUnescape(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	result, funcErr := callFunction(t, "path_unescape", tftypes.String, "orders%2Fdb%20caf%C3%A9")
+	assert.Nil(funcErr)
+	assert.Equal(tftypes.NewValue(tftypes.String, "orders/db café"), result)
+
+	_, funcErr = callFunction(t, "path_unescape", tftypes.String, "100%")
+	assert.NotNil(funcErr)
+}
+
+func TestFunctionSequenceNumber(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	result, funcErr := callFunction(t, "sequence_number", tftypes.Number, "/locks/lock-0000000042")
+	assert.Nil(funcErr)
+	assert.True(tftypes.NewValue(tftypes.Number, big.NewFloat(42)).Equal(result))
+
+	_, funcErr = callFunction(t, "sequence_number", tftypes.Number, "/config/not-a-sequential")
+	assert.NotNil(funcErr)
+}
@@ -0,0 +1,133 @@
+package provider
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/tfzk/terraform-provider-zookeeper/client"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// GenerateOptions configures GenerateZNodeConfig.
+type GenerateOptions struct {
+	// MaxDepth is how many levels below the root path to generate: see `max_depth` of zookeeper_znode_export.
+	MaxDepth int
+	// Concurrency is the maximum number of ZNodes read concurrently (see client.WalkOptions).
+	Concurrency int
+	// IncludeACL generates the `acl` of each ZNode: otherwise, it is left to be read on import.
+	IncludeACL bool
+}
+
+// GenerateZNodeConfig walks the subtree at the given path, writing to `w` a zookeeper_znode Resource
+// for each ZNode, along with the `import` block to adopt it (Terraform 1.5+).
+//
+// The root `/`, ZooKeeper's own `/zookeeper` subtree and Ephemeral ZNodes are skipped, as they can't be managed.
+func GenerateZNodeConfig(w io.Writer, zkClient *client.Client, path string, opts GenerateOptions) error {
+	maxDepth := opts.MaxDepth
+	if maxDepth == 0 {
+		maxDepth = -1
+	}
+
+	var znodes []*client.ZNode
+	walkOpts := client.WalkOptions{
+		MaxDepth:    maxDepth,
+		Concurrency: opts.Concurrency,
+		IncludeACL:  opts.IncludeACL,
+	}
+	err := zkClient.WalkConcurrently(path, walkOpts, func(znode *client.ZNode, _ int) error {
+		if znode.Path == "/" || znode.Path == systemZNodesPath || strings.HasPrefix(znode.Path, systemZNodesPath+"/") ||
+			znode.Stat.EphemeralOwner != 0 {
+			return nil
+		}
+
+		znodes = append(znodes, znode)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("unable to walk subtree of ZNode '%s': %w", path, err)
+	}
+
+	if _, err := w.Write(generateZNodeConfig(znodes)); err != nil {
+		return fmt.Errorf("unable to write generated configuration: %w", err)
+	}
+
+	return nil
+}
+
+// generateZNodeConfig returns the HCL of a zookeeper_znode Resource, and its `import` block, for each given ZNode.
+//
+// Data is set via `data` if valid UTF-8, via `data_base64` otherwise. The ACL is set only if populated.
+func generateZNodeConfig(znodes []*client.ZNode) []byte {
+	file := hclwrite.NewEmptyFile()
+	body := file.Body()
+
+	names := make(map[string]struct{}, len(znodes))
+	for i, znode := range znodes {
+		if i > 0 {
+			body.AppendNewline()
+		}
+
+		name := generateResourceName(znode.Path, names)
+
+		importBody := body.AppendNewBlock("import", nil).Body()
+		importBody.SetAttributeTraversal("to", hcl.Traversal{hcl.TraverseRoot{Name: "zookeeper_znode"}, hcl.TraverseAttr{Name: name}})
+		importBody.SetAttributeValue("id", cty.StringVal(znode.Path))
+		body.AppendNewline()
+
+		rscBody := body.AppendNewBlock("resource", []string{"zookeeper_znode", name}).Body()
+		rscBody.SetAttributeValue("path", cty.StringVal(znode.Path))
+		switch {
+		case len(znode.Data) == 0:
+		case utf8.Valid(znode.Data):
+			rscBody.SetAttributeValue("data", cty.StringVal(string(znode.Data)))
+		default:
+			rscBody.SetAttributeValue("data_base64", cty.StringVal(base64.StdEncoding.EncodeToString(znode.Data)))
+		}
+
+		for _, acl := range znode.ACL {
+			rscBody.AppendNewline()
+			aclBody := rscBody.AppendNewBlock("acl", nil).Body()
+			aclBody.SetAttributeValue("scheme", cty.StringVal(acl.Scheme))
+			aclBody.SetAttributeValue("id", cty.StringVal(acl.ID))
+			aclBody.SetAttributeValue("permissions", cty.NumberIntVal(int64(acl.Perms)))
+		}
+	}
+
+	return file.Bytes()
+}
+
+// generateResourceName returns a name for the Resource of the ZNode at the given path, not already in `taken`
+// (that is then updated): ex. `/forza/napoli` is named `forza_napoli`.
+func generateResourceName(path string, taken map[string]struct{}) string {
+	// Characters not allowed in the name of a Terraform Resource are replaced by `_`
+	name := strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '-' {
+			return r
+		}
+		return '_'
+	}, path)
+	name = strings.Trim(name, "_")
+	switch {
+	case name == "":
+		name = "znode"
+	case (name[0] >= '0' && name[0] <= '9') || name[0] == '-':
+		name = "znode_" + name
+	}
+
+	unique := name
+	for i := 2; ; i++ {
+		if _, ok := taken[unique]; !ok {
+			break
+		}
+		unique = name + "_" + strconv.Itoa(i)
+	}
+
+	taken[unique] = struct{}{}
+	return unique
+}
@@ -0,0 +1,67 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/go-zookeeper/zk"
+	testifyAssert "github.com/stretchr/testify/assert"
+	"github.com/tfzk/terraform-provider-zookeeper/client"
+)
+
+func TestGenerateZNodeConfig(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	znodes := []*client.ZNode{
+		{Path: "/forza/napoli", Data: []byte("Sempre ${forza}!"), ACL: zk.WorldACL(zk.PermRead)},
+		{Path: "/forza/napoli/logo", Data: []byte{0xff, 0x00}},
+		{Path: "/forza/napoli-logo"},
+	}
+
+	assert.Equal(`import {
+  to = zookeeper_znode.forza_napoli
+  id = "/forza/napoli"
+}
+
+resource "zookeeper_znode" "forza_napoli" {
+  path = "/forza/napoli"
+  data = "Sempre $${forza}!"
+
+  acl {
+    scheme      = "world"
+    id          = "anyone"
+    permissions = 1
+  }
+}
+
+import {
+  to = zookeeper_znode.forza_napoli_logo
+  id = "/forza/napoli/logo"
+}
+
+resource "zookeeper_znode" "forza_napoli_logo" {
+  path        = "/forza/napoli/logo"
+  data_base64 = "/wA="
+}
+
+import {
+  to = zookeeper_znode.forza_napoli-logo
+  id = "/forza/napoli-logo"
+}
+
+resource "zookeeper_znode" "forza_napoli-logo" {
+  path = "/forza/napoli-logo"
+}
+`, string(generateZNodeConfig(znodes)))
+}
+
+func TestGenerateResourceName(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	taken := map[string]struct{}{}
+	assert.Equal("forza_napoli", generateResourceName("/forza/napoli", taken))
+	assert.Equal("forza_napoli_2", generateResourceName("/forza_napoli", taken))
+	assert.Equal("forza_napoli_3", generateResourceName("/forza/napoli/", taken))
+	assert.Equal("znode_1", generateResourceName("/1", taken))
+	assert.Equal("znode", generateResourceName("/@", taken))
+	assert.Equal("a_b_c", generateResourceName("/a.b c", taken))
+}
@@ -0,0 +1,192 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	listschema "github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-mux/tf5to6server"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/tfzk/terraform-provider-zookeeper/client"
+)
+
+// zNodeListResource is the list resource of zookeeper_znode, used by `terraform query` (Terraform 1.14+).
+//
+// The zookeeper_znode Resource is implemented with SDKv2: its schema and identity schema are provided
+// via RawV6Schemas, and the listed resources are populated via the SDKv2 Resource itself.
+type zNodeListResource struct {
+	zkClient *client.Client
+}
+
+type zNodeListResourceModel struct {
+	Path     types.String `tfsdk:"path"`
+	MaxDepth types.Int64  `tfsdk:"max_depth"`
+}
+
+var (
+	_ list.ListResourceWithConfigure    = &zNodeListResource{}
+	_ list.ListResourceWithRawV6Schemas = &zNodeListResource{}
+)
+
+func newZNodeListResource() list.ListResource {
+	return &zNodeListResource{}
+}
+
+func (r *zNodeListResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_znode"
+}
+
+func (r *zNodeListResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	configureResourceClient(req, resp, &r.zkClient)
+}
+
+func (r *zNodeListResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = listschema.Schema{
+		Attributes: map[string]listschema.Attribute{
+			"path": listschema.StringAttribute{
+				Required:    true,
+				Description: "Absolute path to the ZNode at the root of the subtree to list. The ZNode itself is included.",
+			},
+			"max_depth": listschema.Int64Attribute{
+				Optional: true,
+				Description: "How many levels below `path` to list: " +
+					"`1` means only `path` and its direct children. `0` (default) means no limit.",
+			},
+		},
+		Description: "Lists the " + zNodeLinkForDesc + "s of a subtree, as `zookeeper_znode` resources ready to be imported. " +
+			"Ephemeral ZNodes, and the ZNodes under `" + systemZNodesPath + "`, are not listed.",
+	}
+}
+
+func (r *zNodeListResource) RawV6Schemas(ctx context.Context, _ list.RawV6SchemaRequest, resp *list.RawV6SchemaResponse) {
+	rscSchema, identitySchema, err := sdkv2ResourceProtoV6Schemas(ctx, "zookeeper_znode", resourceZNode())
+	if err != nil {
+		// Surfaced by the framework as a missing schema, as this method can't report diagnostics
+		return
+	}
+
+	resp.ProtoV6Schema = rscSchema
+	resp.ProtoV6IdentitySchema = identitySchema
+}
+
+func (r *zNodeListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var model zNodeListResourceModel
+	if diags := req.Config.Get(ctx, &model); diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	rootPath := model.Path.ValueString()
+
+	maxDepth := -1
+	if !model.MaxDepth.IsNull() {
+		if model.MaxDepth.ValueInt64() < 0 {
+			var diags diag.Diagnostics
+			diags.AddAttributeError(path.Root("max_depth"), "Invalid 'max_depth'", "Expected 'max_depth' to be at least 0")
+			stream.Results = list.ListResultsStreamDiagnostics(diags)
+			return
+		}
+		if model.MaxDepth.ValueInt64() > 0 {
+			maxDepth = int(model.MaxDepth.ValueInt64())
+		}
+	}
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		var count int64
+		walkOpts := client.WalkOptions{MaxDepth: maxDepth, Concurrency: defaultWalkConcurrency, IncludeACL: req.IncludeResource}
+		err := r.zkClient.WalkConcurrently(rootPath, walkOpts, func(znode *client.ZNode, _ int) error {
+			if znode.Stat.EphemeralOwner != 0 || isSystemZNodePath(znode.Path) {
+				return nil
+			}
+
+			if !push(r.listResult(ctx, req, znode)) {
+				return client.ErrorStopWalk
+			}
+
+			count++
+			if req.Limit > 0 && count >= req.Limit {
+				return client.ErrorStopWalk
+			}
+
+			return nil
+		})
+		if err != nil {
+			var diags diag.Diagnostics
+			diags.AddError("Unable to list ZNodes", withHint(
+				fmt.Sprintf("Unable to list subtree of ZNode '%s': %v", rootPath, err),
+				zkErrorHint(r.zkClient, zNodeOperationRead, rootPath, err),
+			))
+			push(list.ListResult{Diagnostics: diags})
+		}
+	}
+}
+
+// listResult returns the list.ListResult of the given ZNode, populating the resource only if requested.
+func (r *zNodeListResource) listResult(ctx context.Context, req list.ListRequest, znode *client.ZNode) list.ListResult {
+	result := req.NewListResult(ctx)
+	result.DisplayName = znode.Path
+
+	result.Diagnostics.Append(result.Identity.SetAttribute(ctx, path.Root("path"), znode.Path)...)
+
+	if !req.IncludeResource || result.Diagnostics.HasError() {
+		return result
+	}
+
+	rscData := resourceZNode().Data(&terraform.InstanceState{})
+	rscData.SetId(znode.Path)
+	for _, sdkDiag := range setAttributesFromZNode(rscData, znode, nil) {
+		result.Diagnostics.AddError(sdkDiag.Summary, sdkDiag.Detail)
+	}
+	if result.Diagnostics.HasError() {
+		return result
+	}
+
+	rscState, err := rscData.TfTypeResourceState()
+	if err != nil {
+		result.Diagnostics.AddError("Unable to set ZNode attributes", fmt.Sprintf("Unable to set attributes of ZNode '%s': %v", znode.Path, err))
+		return result
+	}
+	result.Resource.Raw = *rscState
+
+	return result
+}
+
+// sdkv2ResourceProtoV6Schemas returns the protocol version 6 schema and identity schema of the given SDKv2 Resource,
+// upgraded via tf5to6server exactly like the SDKv2 provider they are served by (see NewProviderServer).
+func sdkv2ResourceProtoV6Schemas(ctx context.Context, typeName string, rsc *schema.Resource) (*tfprotov6.Schema, *tfprotov6.ResourceIdentitySchema, error) {
+	sdkv2Provider := &schema.Provider{ResourcesMap: map[string]*schema.Resource{typeName: rsc}}
+
+	upgradedServer, err := tf5to6server.UpgradeServer(ctx, sdkv2Provider.GRPCProvider)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to upgrade SDKv2 provider server to protocol version 6: %w", err)
+	}
+
+	schemaResp, err := upgradedServer.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get schema of '%s': %w", typeName, err)
+	}
+
+	identityResp, err := upgradedServer.GetResourceIdentitySchemas(ctx, &tfprotov6.GetResourceIdentitySchemasRequest{})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get identity schema of '%s': %w", typeName, err)
+	}
+
+	rscSchema, ok := schemaResp.ResourceSchemas[typeName]
+	if !ok {
+		return nil, nil, fmt.Errorf("missing schema of '%s'", typeName)
+	}
+
+	identitySchema, ok := identityResp.IdentitySchemas[typeName]
+	if !ok {
+		return nil, nil, fmt.Errorf("missing identity schema of '%s'", typeName)
+	}
+
+	return rscSchema, identitySchema, nil
+}
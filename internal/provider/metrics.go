@@ -0,0 +1,114 @@
+package provider
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/tfzk/terraform-provider-zookeeper/client"
+)
+
+const (
+	// metricsPrefix is the prefix of the name of every metric (see writeMetrics).
+	metricsPrefix = "zookeeper_provider_"
+	// metricsContentType is the content type of the Prometheus text exposition format.
+	// See: https://prometheus.io/docs/instrumenting/exposition_formats/#text-based-format.
+	metricsContentType = "text/plain; version=0.0.4; charset=utf-8"
+)
+
+// metricSample is a sample of a metric, for the ZooKeeper client with the given `servers`.
+type metricSample struct {
+	servers   string
+	operation string
+	value     float64
+}
+
+// metricsHandler returns an http.Handler serving the metrics of each client created so far (see writeMetrics).
+func (cc *zkClientCache) metricsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		cc.mu.Lock()
+		clients := append([]*client.Client{}, cc.created...)
+		cc.mu.Unlock()
+
+		w.Header().Set("Content-Type", metricsContentType)
+		// Nothing to do if the scraper went away
+		_ = writeMetrics(w, clients)
+	})
+}
+
+// writeMetrics writes the client.Telemetry, and the session state, of each of the given clients,
+// in the Prometheus text exposition format. Each sample is labelled with the `servers` of its client.
+func writeMetrics(w io.Writer, clients []*client.Client) error {
+	var operations, durations, retries, sent, received, sessions []metricSample
+	for _, c := range clients {
+		servers := strings.Join(c.Servers(), ",")
+		telemetry := c.Telemetry()
+
+		names := make([]string, 0, len(telemetry.Operations))
+		for operation := range telemetry.Operations {
+			names = append(names, operation)
+		}
+		sort.Strings(names)
+		for _, operation := range names {
+			operations = append(operations, metricSample{servers, operation, float64(telemetry.Operations[operation])})
+			durations = append(durations, metricSample{servers, operation, telemetry.Durations[operation].Seconds()})
+		}
+
+		retries = append(retries, metricSample{servers: servers, value: float64(telemetry.Retries)})
+		sent = append(sent, metricSample{servers: servers, value: float64(telemetry.BytesSent)})
+		received = append(received, metricSample{servers: servers, value: float64(telemetry.BytesReceived)})
+
+		session := 0.0
+		if c.HasSession() {
+			session = 1
+		}
+		sessions = append(sessions, metricSample{servers: servers, value: session})
+	}
+
+	var buf bytes.Buffer
+	writeMetricFamily(&buf, "operations_total", "counter",
+		"Operations performed against ZooKeeper, by client method (ex. Read).", operations)
+	writeMetricFamily(&buf, "operation_duration_seconds_total", "counter",
+		"Total time spent in the operations performed against ZooKeeper, by client method.", durations)
+	writeMetricFamily(&buf, "retries_total", "counter",
+		"Retries of operations failed because of transient errors.", retries)
+	writeMetricFamily(&buf, "sent_bytes_total", "counter",
+		"Bytes sent to the ZooKeeper Ensemble.", sent)
+	writeMetricFamily(&buf, "received_bytes_total", "counter",
+		"Bytes received from the ZooKeeper Ensemble.", received)
+	writeMetricFamily(&buf, "session_established", "gauge",
+		"Whether the client has an established session with the ZooKeeper Ensemble (1) or not (0).", sessions)
+
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		return fmt.Errorf("failed to write metrics: %w", err)
+	}
+
+	return nil
+}
+
+// writeMetricFamily writes the given samples of the metric with the given name (without metricsPrefix),
+// preceded by its `HELP` and `TYPE`. Nothing is written if there are no samples.
+func writeMetricFamily(buf *bytes.Buffer, name, metricType, help string, samples []metricSample) {
+	if len(samples) == 0 {
+		return
+	}
+
+	fmt.Fprintf(buf, "# HELP %s%s %s\n", metricsPrefix, name, help)
+	fmt.Fprintf(buf, "# TYPE %s%s %s\n", metricsPrefix, name, metricType)
+	for _, sample := range samples {
+		labels := fmt.Sprintf(`servers="%s"`, escapeMetricLabel(sample.servers))
+		if sample.operation != "" {
+			labels += fmt.Sprintf(`,operation="%s"`, escapeMetricLabel(sample.operation))
+		}
+		fmt.Fprintf(buf, "%s%s{%s} %s\n", metricsPrefix, name, labels, strconv.FormatFloat(sample.value, 'g', -1, 64))
+	}
+}
+
+// escapeMetricLabel escapes the given label value, as the Prometheus text exposition format requires.
+func escapeMetricLabel(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
@@ -0,0 +1,37 @@
+package provider
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"testing"
+
+	testifyAssert "github.com/stretchr/testify/assert"
+)
+
+func TestWriteMetricFamily(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	var buf bytes.Buffer
+	writeMetricFamily(&buf, "operations_total", "counter", "Operations.", []metricSample{
+		{servers: "zk1:2181,zk2:2181", operation: "Read", value: 42},
+		{servers: `zk"3":2181`, operation: "Create", value: 0.5},
+	})
+	writeMetricFamily(&buf, "retries_total", "counter", "Retries.", nil)
+
+	assert.Equal(`# HELP zookeeper_provider_operations_total Operations.
+# TYPE zookeeper_provider_operations_total counter
+zookeeper_provider_operations_total{servers="zk1:2181,zk2:2181",operation="Read"} 42
+zookeeper_provider_operations_total{servers="zk\"3\":2181",operation="Create"} 0.5
+`, buf.String())
+}
+
+func TestMetricsHandlerWithoutClients(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	recorder := httptest.NewRecorder()
+	(&zkClientCache{}).metricsHandler().ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+
+	assert.Equal(200, recorder.Code)
+	assert.Equal(metricsContentType, recorder.Header().Get("Content-Type"))
+	assert.Empty(recorder.Body.String())
+}
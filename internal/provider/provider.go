@@ -2,13 +2,100 @@ package provider
 
 import (
 	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/tfzk/terraform-provider-zookeeper/internal/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/tfzk/terraform-provider-zookeeper/client"
 )
 
+// Descriptions of the provider arguments, shared by the SDKv2 and the Framework providers:
+// when muxed, their schemas are expected to be identical.
+const (
+	serversDesc = "A comma separated list of 'host:port' pairs, pointing at ZooKeeper Server(s). " +
+		"Servers listed without a port (ex. `zk1`) use `default_port`."
+	defaultPortDesc = "Port of the `servers` listed without one. Default: `2181`, or `2281` when connecting over TLS " +
+		"(see `tls_*` and `require_tls`)."
+	checkServersDNSDesc = "If `true`, the hostnames of all the `servers` are resolved before connecting, each within 5 seconds, " +
+		"failing with the list of all the ones that don't resolve. Otherwise, connecting fails reporting only the first one, " +
+		"with 3 seconds to resolve them all."
+	preferServersDesc = "Which `servers` to connect to first: `participants` (i.e. leader and followers, as observers forward writes to the leader, " +
+		"adding latency to write-heavy applies) or `observers` (ex. to read from the ones in the local data center). " +
+		"The others are connected to only if none of the preferred ones is reachable. If not set, `servers` are connected to in random order. " +
+		"More information can be found [here](#observers)."
+	observerServersDesc = "The observers among the `servers`, as listed there, to classify them for `prefer_servers`. " +
+		"If empty, the mode of each server is detected via the `srvr` Four Letter Word before connecting: " +
+		"servers not reporting it (ex. `srvr` not whitelisted) are considered participants."
+	devServerDesc = "If `true`, the provider starts a throwaway ZooKeeper Server via Docker, and connects to it instead of `servers`: " +
+		"for local module development and `terraform test`. It's removed, along with all its ZNodes, when the provider exits. " +
+		"More information can be found [here](#dev-server)."
+	sessionTimeoutDesc = "How many seconds a session is considered valid after losing connectivity. " +
+		"More information about ZooKeeper sessions can be found [here](#zookeeper-sessions)."
+	usernameDesc     = "Username for digest authentication. Can be set via `ZOOKEEPER_USERNAME` environment variable."
+	passwordDesc     = "Password for digest authentication. Can be set via `ZOOKEEPER_PASSWORD` environment variable."
+	passwordFileDesc = "Path to a file containing the password for digest authentication, as alternative to `password`, " +
+		"to keep it out of the configuration. The file is read again when it changes, to authenticate with the rotated password " +
+		"once reconnected. Can be set via `ZOOKEEPER_PASSWORD_FILE` environment variable."
+	auditLogFileDesc = "Path to a local file where to append an audit log of every create, set (data or ACL) and " +
+		"delete performed by the provider, one JSON object per line with `time`, `operation`, `path`, `identity` " +
+		"and, for failed operations, `error`. Entries are recorded before each operation: if that fails, the operation is not performed."
+	redactDataDesc = "If `true`, the content of ZNodes is kept out of any diagnostic reported by the provider " +
+		"(ex. errors parsing the registrations of discovered services). " +
+		"Credentials embedded in URLs read from ZNodes (ex. Patroni `conn_url`) are always redacted. " +
+		"It does not mark `data` and `data_base64` as sensitive, as the schema can't depend on the provider configuration: " +
+		"to keep them out of the plan output too, wrap them with the Terraform `sensitive()` function."
+	allowedPathPrefixesDesc = "If not empty, the provider is only allowed to touch ZNodes at, or under, one of these absolute paths " +
+		"(ex. `/team-a` allows `/team-a/config`, but not `/team-ab`): any resource or data source touching a ZNode outside of them fails, " +
+		"when planning if the path is already known. Parents of a ZNode outside of them are never created."
+	deniedPathsDesc = "Absolute paths of ZNodes that the provider must never create, update or delete, along with anything under them " +
+		"(ex. `/kafka/brokers`). Deleting a ZNode that has any of them as descendant fails too. " +
+		"ZooKeeper's own `/zookeeper` subtree (ex. quotas, dynamic configuration) is always protected."
+	denyWorldOpenACLsDesc = "If `true`, creating or updating a ZNode with an ACL granting `world:anyone` any permission other than READ " +
+		"(including ZooKeeper's default ACL, used when `acl` is not set) fails, when planning if the ACL is already known. " +
+		"Otherwise, it is only warned about."
+	skipACLReadDesc = "If `true`, the ACL of ZNodes is never read (ex. when the provider identity lacks the permission to): " +
+		"the `acl` of resources is left as in the state, and only set when changed in the configuration, while the `acl` of data sources is empty. " +
+		"Changes to ACLs made outside of Terraform are not detected."
+	tolerateUnreadableZNodesDesc = "If `true`, refreshing a resource whose ZNode the provider is not authorized to read " +
+		"(ex. its ACL was changed outside of Terraform) keeps it as in the state, with a warning, instead of failing: " +
+		"the rest of the configuration can still be planned. Changes made to such ZNodes outside of Terraform are not detected."
+	lockPathDesc = "If set, the provider acquires the lock at this path before its first change (create, update or delete) " +
+		"and holds it until it exits, at the end of the apply: concurrent Terraform runs configured with the same `lock_path` " +
+		"(ex. from different pipelines, against the same subtree) make their changes one after the other. " +
+		"The lock is held via an Ephemeral Sequential ZNode under this path, following the ZooKeeper lock recipe: " +
+		"it must be inside `allowed_path_prefixes` and outside `denied_paths`, and with `deny_world_open_acls` " +
+		"the lock ZNodes are accessible only to the identity of the provider (i.e. `username`)."
+	lockTimeoutDesc = "How long to wait for the lock at `lock_path`, as a " + durationLinkForDesc + ", before failing. " +
+		"Default: `5m`."
+	requireLeaderDesc = "If `true`, the provider checks that the ZooKeeper Ensemble has an elected leader before its first change " +
+		"(create, update or delete), asking each of the `servers` for its mode via the `srvr` (or `mntr`) Four Letter Word: " +
+		"during leader elections, the apply fails fast with an explanation, instead of timing out on the first write. More information can be found [here](#leader-elections)."
+	tlsCAFileDesc = "Path to a PEM file of CA certificates to verify the ZooKeeper Servers with, when connecting over TLS. " +
+		"Defaults to the system ones. Setting any of the `tls_*` arguments enables TLS, for all the `servers`."
+	tlsCertFileDesc = "Path to a PEM certificate to present to the ZooKeeper Servers over TLS (ex. to be authenticated via the `x509` scheme). " +
+		"Requires `tls_key_file`. Like the other `tls_*` files, it's loaded again whenever it changes, before (re-)connecting to a Server."
+	tlsKeyFileDesc = "Path to the PEM private key of `tls_cert_file`."
+	requireTLSDesc = "If `true`, the provider refuses to establish plaintext connections: TLS is enabled, even without any of the `tls_*` arguments, " +
+		"so that a mistaken plaintext port in `servers` fails the TLS handshake instead of downgrading the connection. " +
+		"This includes Four Letter Words (ex. `zookeeper_ensemble_health`), while AdminServer commands require an HTTPS `url`."
+	auditZNodeDesc = "Path to an existing ZNode under which to record the same audit log of `audit_log_file`: " +
+		"each entry is the JSON data of a persistent sequential child (`entry-<sequence>`), created with the ACL of this ZNode."
+)
+
+// New returns the SDKv2 provider.
+//
+// The provider is being migrated to terraform-plugin-framework: see NewProviderServer
+// for how the SDKv2 and the Framework providers are served together.
 func New() (*schema.Provider, error) {
+	return newSDKv2Provider(&zkClientCache{}), nil
+}
+
+func newSDKv2Provider(clientCache *zkClientCache) *schema.Provider {
 	return &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"servers": {
@@ -16,29 +103,140 @@ func New() (*schema.Provider, error) {
 				Optional:    true,
 				Sensitive:   false,
 				DefaultFunc: schema.EnvDefaultFunc(client.EnvZooKeeperServer, nil),
-				Description: "A comma separated list of 'host:port' pairs, pointing at ZooKeeper Server(s).",
+				Description: serversDesc,
+			},
+			"default_port": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IsPortNumber,
+				Description:  defaultPortDesc,
+			},
+			"check_servers_dns": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: checkServersDNSDesc,
+			},
+			"prefer_servers": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{string(client.PreferParticipants), string(client.PreferObservers)}, false),
+				Description:  preferServersDesc,
+			},
+			"observer_servers": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: observerServersDesc,
+			},
+			"dev_server": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: devServerDesc,
 			},
 			"session_timeout": {
 				Type:        schema.TypeInt,
 				Optional:    true,
 				Sensitive:   false,
 				DefaultFunc: schema.EnvDefaultFunc(client.EnvZooKeeperSessionSec, client.DefaultZooKeeperSessionSec),
-				Description: "How many seconds a session is considered valid after losing connectivity. " +
-					"More information about ZooKeeper sessions can be found [here](#zookeeper-sessions).",
+				Description: sessionTimeoutDesc,
 			},
 			"username": {
 				Type:        schema.TypeString,
 				Optional:    true,
 				Sensitive:   true,
 				DefaultFunc: schema.EnvDefaultFunc(client.EnvZooKeeperUsername, nil),
-				Description: "Username for digest authentication. Can be set via `ZOOKEEPER_USERNAME` environment variable.",
+				Description: usernameDesc,
 			},
 			"password": {
 				Type:        schema.TypeString,
 				Optional:    true,
 				Sensitive:   true,
 				DefaultFunc: schema.EnvDefaultFunc(client.EnvZooKeeperPassword, nil),
-				Description: "Password for digest authentication. Can be set via `ZOOKEEPER_PASSWORD` environment variable.",
+				Description: passwordDesc,
+			},
+			"password_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc(client.EnvZooKeeperPasswordFile, nil),
+				Description: passwordFileDesc,
+			},
+			"redact_data": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: redactDataDesc,
+			},
+			"audit_log_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: auditLogFileDesc,
+			},
+			"audit_znode": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: auditZNodeDesc,
+			},
+			"allowed_path_prefixes": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: allowedPathPrefixesDesc,
+			},
+			"denied_paths": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: deniedPathsDesc,
+			},
+			"deny_world_open_acls": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: denyWorldOpenACLsDesc,
+			},
+			"skip_acl_read": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: skipACLReadDesc,
+			},
+			"tolerate_unreadable_znodes": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: tolerateUnreadableZNodesDesc,
+			},
+			"lock_path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: lockPathDesc,
+			},
+			"lock_timeout": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateDuration,
+				Description:  lockTimeoutDesc,
+			},
+			"require_leader": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: requireLeaderDesc,
+			},
+			"tls_ca_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: tlsCAFileDesc,
+			},
+			"tls_cert_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: tlsCertFileDesc,
+			},
+			"tls_key_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: tlsKeyFileDesc,
+			},
+			"require_tls": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: requireTLSDesc,
 			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
@@ -46,29 +244,251 @@ func New() (*schema.Provider, error) {
 			"zookeeper_sequential_znode": resourceSeqZNode(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-			"zookeeper_znode": datasourceZNode(),
+			"zookeeper_znode":           datasourceZNode(),
+			"zookeeper_znodes":          datasourceZNodes(),
+			"zookeeper_znode_search":    datasourceZNodeSearch(),
+			"zookeeper_znode_children":  datasourceZNodeChildren(),
+			"zookeeper_znode_export":    datasourceZNodeExport(),
+			"zookeeper_kafka_brokers":   datasourceKafkaBrokers(),
+			"zookeeper_solr_cluster":    datasourceSolrCluster(),
+			"zookeeper_hbase_cluster":   datasourceHBaseCluster(),
+			"zookeeper_patroni_leader":  datasourcePatroniLeader(),
+			"zookeeper_ensemble_config": datasourceEnsembleConfig(),
+			"zookeeper_ensemble_health": datasourceEnsembleHealth(),
+			"zookeeper_admin_command":   datasourceAdminCommand(),
+			"zookeeper_quota":           datasourceQuota(),
 		},
-		ConfigureContextFunc: configureProviderContext,
-	}, nil
+		ConfigureContextFunc: func(_ context.Context, rscData *schema.ResourceData) (interface{}, diag.Diagnostics) {
+			config := zkClientConfig{
+				servers:           rscData.Get("servers").(string),
+				defaultPort:       rscData.Get("default_port").(int),
+				checkServersDNS:   rscData.Get("check_servers_dns").(bool),
+				preferServers:     rscData.Get("prefer_servers").(string),
+				devServer:         rscData.Get("dev_server").(bool),
+				sessionTimeout:    rscData.Get("session_timeout").(int),
+				username:          rscData.Get("username").(string),
+				password:          rscData.Get("password").(string),
+				passwordFile:      rscData.Get("password_file").(string),
+				auditLogFile:      rscData.Get("audit_log_file").(string),
+				auditZNode:        rscData.Get("audit_znode").(string),
+				redactData:        rscData.Get("redact_data").(bool),
+				denyWorldOpenACLs: rscData.Get("deny_world_open_acls").(bool),
+				skipACLRead:       rscData.Get("skip_acl_read").(bool),
+				tolerateNoAuth:    rscData.Get("tolerate_unreadable_znodes").(bool),
+				lockPath:          rscData.Get("lock_path").(string),
+				requireLeader:     rscData.Get("require_leader").(bool),
+				tlsCAFile:         rscData.Get("tls_ca_file").(string),
+				tlsCertFile:       rscData.Get("tls_cert_file").(string),
+				tlsKeyFile:        rscData.Get("tls_key_file").(string),
+				requireTLS:        rscData.Get("require_tls").(bool),
+			}
+			if lockTimeout := rscData.Get("lock_timeout").(string); lockTimeout != "" {
+				var err error
+				if config.lockTimeout, err = time.ParseDuration(lockTimeout); err != nil {
+					return nil, diag.Errorf("Invalid 'lock_timeout': %v", err)
+				}
+			}
+			for _, server := range rscData.Get("observer_servers").([]interface{}) {
+				config.observerServers = append(config.observerServers, server.(string))
+			}
+			for _, prefix := range rscData.Get("allowed_path_prefixes").([]interface{}) {
+				config.allowedPathPrefixes = append(config.allowedPathPrefixes, prefix.(string))
+			}
+			for _, prefix := range rscData.Get("denied_paths").([]interface{}) {
+				config.deniedPaths = append(config.deniedPaths, prefix.(string))
+			}
+
+			if config.servers != "" || config.devServer {
+				c, err := clientCache.get(config)
+
+				if err != nil {
+					// Report inability to connect internal Client
+					return nil, diag.Errorf("Unable creating ZooKeeper client against '%s': %v", config.servers, err)
+				}
+
+				return c, diag.Diagnostics{}
+			}
+
+			// Report missing mandatory arguments
+			return nil, diag.Errorf("Provider requires at least the '%s' argument", "servers")
+		},
+	}
 }
 
-func configureProviderContext(_ context.Context, rscData *schema.ResourceData) (interface{}, diag.Diagnostics) {
-	servers := rscData.Get("servers").(string)
-	sessionTimeout := rscData.Get("session_timeout").(int)
-	username := rscData.Get("username").(string)
-	password := rscData.Get("password").(string)
+// defaultLockTimeout is how long to wait for the lock at `lock_path`, if `lock_timeout` is not set.
+const defaultLockTimeout = 5 * time.Minute
+
+// zkClientCache shares a client.Client between the SDKv2 and the Framework providers.
+//
+// When muxed, each provider is configured independently: this ensures that, given the same
+// configuration, only one ZooKeeper session is established.
+type zkClientCache struct {
+	mu     sync.Mutex
+	config zkClientConfig
+	client *client.Client
 
-	if servers != "" {
-		c, err := client.NewClient(servers, sessionTimeout, username, password)
+	// created are all the clients created so far, for telemetrySummary
+	created []*client.Client
+
+	// devServer is started by the first configuration with `dev_server`, and shared by the following ones
+	devServer *devServer
+	// docker is the Docker CLI to start devServer with: `docker` if empty
+	docker string
+}
+
+// zkClientConfig is the provider configuration a client.Client is created from.
+type zkClientConfig struct {
+	servers           string
+	defaultPort       int
+	checkServersDNS   bool
+	preferServers     string
+	devServer         bool
+	sessionTimeout    int
+	username          string
+	password          string
+	passwordFile      string
+	auditLogFile      string
+	auditZNode        string
+	redactData        bool
+	denyWorldOpenACLs bool
+	skipACLRead       bool
+	tolerateNoAuth    bool
+	lockPath          string
+	requireLeader     bool
+	tlsCAFile         string
+	tlsCertFile       string
+	tlsKeyFile        string
+	requireTLS        bool
+
+	// lockTimeout is defaultLockTimeout if zero
+	lockTimeout time.Duration
+
+	// observerServers are detected via `srvr` if `nil` (see client.WithServerPreference)
+	observerServers []string
+	// allowedPathPrefixes is `nil` if every path is allowed
+	allowedPathPrefixes []string
+	// deniedPaths are protected in addition to systemZNodesPath
+	deniedPaths []string
+}
 
-		if err != nil {
-			// Report inability to connect internal Client
-			return nil, diag.Errorf("Unable creating ZooKeeper client against '%s': %v", servers, err)
+// options returns the client.ClientOption(s) of the configuration.
+func (config zkClientConfig) options() []client.ClientOption {
+	opts := []client.ClientOption{
+		client.WithDeniedPathPrefixes(append([]string{systemZNodesPath}, config.deniedPaths...)),
+	}
+	if config.defaultPort != 0 {
+		opts = append(opts, client.WithDefaultPort(config.defaultPort))
+	}
+	if config.checkServersDNS {
+		opts = append(opts, client.WithServersDNSCheck())
+	}
+	if config.preferServers != "" {
+		opts = append(opts, client.WithServerPreference(client.ServerPreference(config.preferServers), config.observerServers))
+	}
+	if config.passwordFile != "" {
+		opts = append(opts, client.WithPasswordFile(config.passwordFile))
+	}
+	if config.auditLogFile != "" {
+		opts = append(opts, client.WithAuditLogFile(config.auditLogFile))
+	}
+	if config.auditZNode != "" {
+		opts = append(opts, client.WithAuditZNode(config.auditZNode))
+	}
+	if config.redactData {
+		opts = append(opts, client.WithDataRedaction())
+	}
+	if len(config.allowedPathPrefixes) > 0 {
+		opts = append(opts, client.WithAllowedPathPrefixes(config.allowedPathPrefixes))
+	}
+	if config.denyWorldOpenACLs {
+		opts = append(opts, client.WithWorldOpenACLsDenied())
+	}
+	if config.skipACLRead {
+		opts = append(opts, client.WithoutACLReads())
+	}
+	if config.tolerateNoAuth {
+		opts = append(opts, client.WithUnreadableZNodesTolerated())
+	}
+	if config.lockPath != "" {
+		lockTimeout := config.lockTimeout
+		if lockTimeout == 0 {
+			lockTimeout = defaultLockTimeout
 		}
+		opts = append(opts, client.WithWriteLock(config.lockPath, lockTimeout))
+	}
+	if config.requireLeader {
+		opts = append(opts, client.WithLeaderCheck())
+	}
+	if config.requireTLS || config.tlsCAFile != "" || config.tlsCertFile != "" || config.tlsKeyFile != "" {
+		opts = append(opts, client.WithTLS(config.tlsCAFile, config.tlsCertFile, config.tlsKeyFile))
+	}
+	if config.requireTLS {
+		opts = append(opts, client.WithTLSRequired())
+	}
+
+	return opts
+}
+
+func (cc *zkClientCache) get(config zkClientConfig) (*client.Client, error) {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+
+	if cc.client != nil && reflect.DeepEqual(cc.config, config) {
+		return cc.client, nil
+	}
+
+	servers := config.servers
+	if config.devServer {
+		if cc.devServer == nil {
+			docker := cc.docker
+			if docker == "" {
+				docker = "docker"
+			}
+
+			ds, err := startDevServer(context.Background(), docker)
+			if err != nil {
+				return nil, err
+			}
+			cc.devServer = ds
+		}
+		servers = cc.devServer.address
+	}
+
+	c, err := client.NewClient(servers, config.sessionTimeout, config.username, config.password, config.options()...)
+	if err != nil {
+		return nil, err
+	}
+
+	cc.config, cc.client = config, c
+	cc.created = append(cc.created, c)
+	return c, nil
+}
+
+// stop closes the clients created so far, releasing what is bound to their session (ex. the lock at `lock_path`),
+// and what was started for them (i.e. the devServer).
+func (cc *zkClientCache) stop() {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
+
+	for _, c := range cc.created {
+		c.Close()
+	}
+
+	if cc.devServer != nil {
+		cc.devServer.stop()
+		cc.devServer = nil
+	}
+}
+
+// telemetrySummary summarizes the client.Telemetry of each client created so far, one line per client.
+func (cc *zkClientCache) telemetrySummary() []string {
+	cc.mu.Lock()
+	defer cc.mu.Unlock()
 
-		return c, diag.Diagnostics{}
+	summary := make([]string, 0, len(cc.created))
+	for _, c := range cc.created {
+		summary = append(summary, fmt.Sprintf("ZooKeeper operations on '%s': %s", strings.Join(c.Servers(), ","), c.Telemetry()))
 	}
 
-	// Report missing mandatory arguments
-	return nil, diag.Errorf("Provider requires at least the '%s' argument", "servers")
+	return summary
 }
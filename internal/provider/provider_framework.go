@@ -0,0 +1,359 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	fwprovider "github.com/hashicorp/terraform-plugin-framework/provider"
+	fwschema "github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/tfzk/terraform-provider-zookeeper/client"
+)
+
+// frameworkProvider is the terraform-plugin-framework implementation of the provider.
+//
+// Resources and Data Sources are migrated here from the SDKv2 provider (see New) incrementally:
+// until the migration is complete, both providers are served together via NewProviderServer.
+type frameworkProvider struct {
+	clientCache *zkClientCache
+}
+
+// frameworkProviderModel maps the provider configuration, identical to the SDKv2 provider one.
+type frameworkProviderModel struct {
+	Servers         types.String `tfsdk:"servers"`
+	DefaultPort     types.Int64  `tfsdk:"default_port"`
+	CheckServersDNS types.Bool   `tfsdk:"check_servers_dns"`
+	PreferServers   types.String `tfsdk:"prefer_servers"`
+	DevServer       types.Bool   `tfsdk:"dev_server"`
+	SessionTimeout  types.Int64  `tfsdk:"session_timeout"`
+	Username        types.String `tfsdk:"username"`
+	Password        types.String `tfsdk:"password"`
+	PasswordFile    types.String `tfsdk:"password_file"`
+	AuditLogFile    types.String `tfsdk:"audit_log_file"`
+	AuditZNode      types.String `tfsdk:"audit_znode"`
+	RedactData      types.Bool   `tfsdk:"redact_data"`
+	SkipACLRead     types.Bool   `tfsdk:"skip_acl_read"`
+	LockPath        types.String `tfsdk:"lock_path"`
+	LockTimeout     types.String `tfsdk:"lock_timeout"`
+	RequireLeader   types.Bool   `tfsdk:"require_leader"`
+	TLSCAFile       types.String `tfsdk:"tls_ca_file"`
+	TLSCertFile     types.String `tfsdk:"tls_cert_file"`
+	TLSKeyFile      types.String `tfsdk:"tls_key_file"`
+	RequireTLS      types.Bool   `tfsdk:"require_tls"`
+
+	ObserverServers     types.List `tfsdk:"observer_servers"`
+	AllowedPathPrefixes types.List `tfsdk:"allowed_path_prefixes"`
+	DeniedPaths         types.List `tfsdk:"denied_paths"`
+	DenyWorldOpenACLs   types.Bool `tfsdk:"deny_world_open_acls"`
+	TolerateUnreadable  types.Bool `tfsdk:"tolerate_unreadable_znodes"`
+}
+
+var (
+	_ fwprovider.Provider                  = &frameworkProvider{}
+	_ fwprovider.ProviderWithFunctions     = &frameworkProvider{}
+	_ fwprovider.ProviderWithListResources = &frameworkProvider{}
+	_ fwprovider.ProviderWithActions       = &frameworkProvider{}
+)
+
+func newFrameworkProvider(clientCache *zkClientCache) fwprovider.Provider {
+	return &frameworkProvider{clientCache: clientCache}
+}
+
+func (p *frameworkProvider) Metadata(_ context.Context, _ fwprovider.MetadataRequest, resp *fwprovider.MetadataResponse) {
+	resp.TypeName = "zookeeper"
+}
+
+func (p *frameworkProvider) Schema(_ context.Context, _ fwprovider.SchemaRequest, resp *fwprovider.SchemaResponse) {
+	resp.Schema = fwschema.Schema{
+		Attributes: map[string]fwschema.Attribute{
+			"servers": fwschema.StringAttribute{
+				Optional:    true,
+				Description: serversDesc,
+			},
+			"default_port": fwschema.Int64Attribute{
+				Optional:    true,
+				Description: defaultPortDesc,
+			},
+			"check_servers_dns": fwschema.BoolAttribute{
+				Optional:    true,
+				Description: checkServersDNSDesc,
+			},
+			"prefer_servers": fwschema.StringAttribute{
+				Optional:    true,
+				Description: preferServersDesc,
+			},
+			"observer_servers": fwschema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: observerServersDesc,
+			},
+			"dev_server": fwschema.BoolAttribute{
+				Optional:    true,
+				Description: devServerDesc,
+			},
+			"session_timeout": fwschema.Int64Attribute{
+				Optional:    true,
+				Description: sessionTimeoutDesc,
+			},
+			"username": fwschema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: usernameDesc,
+			},
+			"password": fwschema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: passwordDesc,
+			},
+			"password_file": fwschema.StringAttribute{
+				Optional:    true,
+				Description: passwordFileDesc,
+			},
+			"redact_data": fwschema.BoolAttribute{
+				Optional:    true,
+				Description: redactDataDesc,
+			},
+			"audit_log_file": fwschema.StringAttribute{
+				Optional:    true,
+				Description: auditLogFileDesc,
+			},
+			"audit_znode": fwschema.StringAttribute{
+				Optional:    true,
+				Description: auditZNodeDesc,
+			},
+			"allowed_path_prefixes": fwschema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: allowedPathPrefixesDesc,
+			},
+			"denied_paths": fwschema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: deniedPathsDesc,
+			},
+			"deny_world_open_acls": fwschema.BoolAttribute{
+				Optional:    true,
+				Description: denyWorldOpenACLsDesc,
+			},
+			"skip_acl_read": fwschema.BoolAttribute{
+				Optional:    true,
+				Description: skipACLReadDesc,
+			},
+			"tolerate_unreadable_znodes": fwschema.BoolAttribute{
+				Optional:    true,
+				Description: tolerateUnreadableZNodesDesc,
+			},
+			"lock_path": fwschema.StringAttribute{
+				Optional:    true,
+				Description: lockPathDesc,
+			},
+			"lock_timeout": fwschema.StringAttribute{
+				Optional:    true,
+				Description: lockTimeoutDesc,
+			},
+			"require_leader": fwschema.BoolAttribute{
+				Optional:    true,
+				Description: requireLeaderDesc,
+			},
+			"tls_ca_file": fwschema.StringAttribute{
+				Optional:    true,
+				Description: tlsCAFileDesc,
+			},
+			"tls_cert_file": fwschema.StringAttribute{
+				Optional:    true,
+				Description: tlsCertFileDesc,
+			},
+			"tls_key_file": fwschema.StringAttribute{
+				Optional:    true,
+				Description: tlsKeyFileDesc,
+			},
+			"require_tls": fwschema.BoolAttribute{
+				Optional:    true,
+				Description: requireTLSDesc,
+			},
+		},
+	}
+}
+
+func (p *frameworkProvider) Configure(ctx context.Context, req fwprovider.ConfigureRequest, resp *fwprovider.ConfigureResponse) {
+	var config frameworkProviderModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Configuration will be known later on (ex. depends on a resource not created yet)
+	if config.Servers.IsUnknown() || config.DefaultPort.IsUnknown() || config.CheckServersDNS.IsUnknown() ||
+		config.PreferServers.IsUnknown() || config.ObserverServers.IsUnknown() || config.DevServer.IsUnknown() ||
+		config.SessionTimeout.IsUnknown() || config.Username.IsUnknown() || config.Password.IsUnknown() ||
+		config.PasswordFile.IsUnknown() || config.AuditLogFile.IsUnknown() || config.AuditZNode.IsUnknown() ||
+		config.RedactData.IsUnknown() || config.SkipACLRead.IsUnknown() || config.TolerateUnreadable.IsUnknown() ||
+		config.LockPath.IsUnknown() || config.LockTimeout.IsUnknown() || config.RequireLeader.IsUnknown() ||
+		config.TLSCAFile.IsUnknown() || config.TLSCertFile.IsUnknown() || config.TLSKeyFile.IsUnknown() ||
+		config.RequireTLS.IsUnknown() || config.AllowedPathPrefixes.IsUnknown() || config.DeniedPaths.IsUnknown() ||
+		config.DenyWorldOpenACLs.IsUnknown() {
+		return
+	}
+
+	// Same defaults as the SDKv2 provider, that relies on `schema.EnvDefaultFunc`
+	servers := stringValueOrEnv(config.Servers, client.EnvZooKeeperServer)
+	username := stringValueOrEnv(config.Username, client.EnvZooKeeperUsername)
+	password := stringValueOrEnv(config.Password, client.EnvZooKeeperPassword)
+	passwordFile := stringValueOrEnv(config.PasswordFile, client.EnvZooKeeperPasswordFile)
+
+	sessionTimeout := client.DefaultZooKeeperSessionSec
+	if !config.SessionTimeout.IsNull() {
+		sessionTimeout = int(config.SessionTimeout.ValueInt64())
+	} else if envSessionTimeout, ok := os.LookupEnv(client.EnvZooKeeperSessionSec); ok {
+		var err error
+		if sessionTimeout, err = strconv.Atoi(envSessionTimeout); err != nil {
+			resp.Diagnostics.AddError("Invalid 'session_timeout'", err.Error())
+			return
+		}
+	}
+
+	var lockTimeout time.Duration
+	if !config.LockTimeout.IsNull() {
+		var err error
+		if lockTimeout, err = time.ParseDuration(config.LockTimeout.ValueString()); err != nil {
+			resp.Diagnostics.AddError("Invalid 'lock_timeout'", err.Error())
+			return
+		}
+	}
+
+	observerServers, known := stringListValue(ctx, config.ObserverServers, &resp.Diagnostics)
+	if !known {
+		return
+	}
+	allowedPathPrefixes, known := stringListValue(ctx, config.AllowedPathPrefixes, &resp.Diagnostics)
+	if !known {
+		return
+	}
+	deniedPaths, known := stringListValue(ctx, config.DeniedPaths, &resp.Diagnostics)
+	if !known {
+		return
+	}
+
+	if servers == "" && !config.DevServer.ValueBool() {
+		// Report missing mandatory arguments
+		resp.Diagnostics.AddError("Missing 'servers'", "Provider requires at least the 'servers' argument")
+		return
+	}
+
+	zkClient, err := p.clientCache.get(zkClientConfig{
+		servers:         servers,
+		defaultPort:     int(config.DefaultPort.ValueInt64()),
+		checkServersDNS: config.CheckServersDNS.ValueBool(),
+		preferServers:   config.PreferServers.ValueString(),
+		devServer:       config.DevServer.ValueBool(),
+		sessionTimeout:  sessionTimeout,
+		username:        username,
+		password:        password,
+		passwordFile:    passwordFile,
+		auditLogFile:    config.AuditLogFile.ValueString(),
+		auditZNode:      config.AuditZNode.ValueString(),
+		redactData:      config.RedactData.ValueBool(),
+		skipACLRead:     config.SkipACLRead.ValueBool(),
+		tolerateNoAuth:  config.TolerateUnreadable.ValueBool(),
+		lockPath:        config.LockPath.ValueString(),
+		lockTimeout:     lockTimeout,
+		requireLeader:   config.RequireLeader.ValueBool(),
+		tlsCAFile:       config.TLSCAFile.ValueString(),
+		tlsCertFile:     config.TLSCertFile.ValueString(),
+		tlsKeyFile:      config.TLSKeyFile.ValueString(),
+		requireTLS:      config.RequireTLS.ValueBool(),
+
+		observerServers:     observerServers,
+		allowedPathPrefixes: allowedPathPrefixes,
+		deniedPaths:         deniedPaths,
+		denyWorldOpenACLs:   config.DenyWorldOpenACLs.ValueBool(),
+	})
+	if err != nil {
+		// Report inability to connect internal Client
+		resp.Diagnostics.AddError("Unable creating ZooKeeper client", fmt.Sprintf("Unable creating ZooKeeper client against '%s': %v", servers, err))
+		return
+	}
+
+	resp.DataSourceData = zkClient
+	resp.ResourceData = zkClient
+	resp.ListResourceData = zkClient
+	resp.ActionData = zkClient
+}
+
+func (p *frameworkProvider) DataSources(_ context.Context) []func() datasource.DataSource {
+	return []func() datasource.DataSource{
+		newServerVersionDataSource,
+		newConnectionDataSource,
+	}
+}
+
+func (p *frameworkProvider) Resources(_ context.Context) []func() resource.Resource {
+	return []func() resource.Resource{
+		newGCResource,
+		newEnsembleMemberResource,
+	}
+}
+
+func (p *frameworkProvider) ListResources(_ context.Context) []func() list.ListResource {
+	return []func() list.ListResource{
+		newZNodeListResource,
+	}
+}
+
+func (p *frameworkProvider) Actions(_ context.Context) []func() action.Action {
+	return []func() action.Action{
+		newDeleteSubtreeAction,
+	}
+}
+
+func (p *frameworkProvider) Functions(_ context.Context) []func() function.Function {
+	return []func() function.Function{
+		newPathJoinFunction,
+		newPathEscapeFunction,
+		newPathUnescapeFunction,
+		newSequenceNumberFunction,
+	}
+}
+
+// stringValueOrEnv returns the value of the given types.String, or the value of the given
+// environment variable if null.
+func stringValueOrEnv(value types.String, envVar string) string {
+	if !value.IsNull() {
+		return value.ValueString()
+	}
+
+	return os.Getenv(envVar)
+}
+
+// stringListValue returns the elements of the given types.List of strings, `nil` if null or empty
+// (like the SDKv2 provider does, so that both build the same zkClientConfig and share the client).
+//
+// It returns `false` if any element is not known yet, or can't be converted (reported via the given diag.Diagnostics).
+func stringListValue(ctx context.Context, value types.List, diags *diag.Diagnostics) ([]string, bool) {
+	if value.IsNull() {
+		return nil, true
+	}
+
+	for _, element := range value.Elements() {
+		if element.IsUnknown() {
+			return nil, false
+		}
+	}
+
+	var elements []string
+	diags.Append(value.ElementsAs(ctx, &elements, false)...)
+	if len(elements) == 0 {
+		return nil, !diags.HasError()
+	}
+
+	return elements, !diags.HasError()
+}
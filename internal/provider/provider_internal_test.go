@@ -0,0 +1,210 @@
+package provider
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	fwprovider "github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	testifyAssert "github.com/stretchr/testify/assert"
+	"github.com/tfzk/terraform-provider-zookeeper/client"
+)
+
+func TestZKClientCacheIsSafeForConcurrentUse(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	// Connecting happens in the background: no ZooKeeper Server is necessary
+	const workers = 16
+	cache := &zkClientCache{}
+	clients := make([]*client.Client, workers)
+	errs := make([]error, workers)
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			clients[i], errs[i] = cache.get(zkClientConfig{servers: "127.0.0.1:1", sessionTimeout: 1})
+		}()
+	}
+	wg.Wait()
+
+	for i := 0; i < workers; i++ {
+		assert.NoError(errs[i])
+		assert.Same(clients[0], clients[i])
+	}
+}
+
+func TestZKClientCacheTelemetrySummary(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	cache := &zkClientCache{}
+	assert.Empty(cache.telemetrySummary())
+
+	for _, servers := range []string{"127.0.0.1:1", "127.0.0.1:2"} {
+		_, err := cache.get(zkClientConfig{servers: servers, sessionTimeout: 1})
+		assert.NoError(err)
+	}
+
+	summary := cache.telemetrySummary()
+	assert.Len(summary, 2)
+	assert.Contains(summary[0], "ZooKeeper operations on '127.0.0.1:1': 0 operations")
+	assert.Contains(summary[1], "ZooKeeper operations on '127.0.0.1:2': 0 operations")
+}
+
+func TestZKClientCacheComparesAllowedPathPrefixes(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	cache := &zkClientCache{}
+	config := zkClientConfig{servers: "127.0.0.1:1", sessionTimeout: 1, allowedPathPrefixes: []string{"/team-a"}}
+
+	first, err := cache.get(config)
+	assert.NoError(err)
+	same, err := cache.get(zkClientConfig{servers: "127.0.0.1:1", sessionTimeout: 1, allowedPathPrefixes: []string{"/team-a"}})
+	assert.NoError(err)
+	assert.Same(first, same)
+
+	other, err := cache.get(zkClientConfig{servers: "127.0.0.1:1", sessionTimeout: 1, allowedPathPrefixes: []string{"/team-b"}})
+	assert.NoError(err)
+	assert.NotSame(first, other)
+	assert.ErrorIs(other.CheckPath("/team-a/config"), client.ErrorPathNotAllowed)
+}
+
+func TestZKClientConfigRequireTLSEnablesTLS(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	cache := &zkClientCache{}
+	c, err := cache.get(zkClientConfig{servers: "127.0.0.1:1", sessionTimeout: 1, requireTLS: true})
+	assert.NoError(err)
+	assert.True(c.RequiresTLS())
+
+	_, err = cache.get(zkClientConfig{servers: "127.0.0.1:1", sessionTimeout: 1, tlsCertFile: "client.pem"})
+	assert.ErrorContains(err, "must be specified together")
+}
+
+func TestZKClientConfigDefaultPort(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	cache := &zkClientCache{}
+	c, err := cache.get(zkClientConfig{servers: "127.0.0.1, 127.0.0.2:1", sessionTimeout: 1})
+	assert.NoError(err)
+	assert.Equal([]string{"127.0.0.1:2181", "127.0.0.2:1"}, c.Servers())
+
+	c, err = cache.get(zkClientConfig{servers: "127.0.0.1", sessionTimeout: 1, requireTLS: true})
+	assert.NoError(err)
+	assert.Equal([]string{"127.0.0.1:2281"}, c.Servers())
+
+	c, err = cache.get(zkClientConfig{servers: "127.0.0.1", sessionTimeout: 1, defaultPort: 1})
+	assert.NoError(err)
+	assert.Equal([]string{"127.0.0.1:1"}, c.Servers())
+
+	_, err = cache.get(zkClientConfig{servers: "127.0.0.1:napoli", sessionTimeout: 1})
+	assert.ErrorContains(err, "invalid server '127.0.0.1:napoli'")
+}
+
+func TestZKClientConfigCheckServersDNS(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	// `.invalid` never resolves (RFC 2606)
+	cache := &zkClientCache{}
+	_, err := cache.get(zkClientConfig{servers: "127.0.0.1,zk.invalid", sessionTimeout: 1, checkServersDNS: true})
+	assert.ErrorIs(err, client.ErrorServerNotResolved)
+	assert.ErrorContains(err, "'zk.invalid:2181'")
+
+	// Without checking, connecting fails anyway, reporting only the first one
+	_, err = cache.get(zkClientConfig{servers: "127.0.0.1,zk.invalid", sessionTimeout: 1})
+	assert.ErrorContains(err, "lookup zk.invalid")
+	assert.NotErrorIs(err, client.ErrorServerNotResolved)
+}
+
+func TestZKClientConfigSkipACLRead(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	cache := &zkClientCache{}
+	c, err := cache.get(zkClientConfig{servers: "127.0.0.1:1", sessionTimeout: 1, skipACLRead: true})
+	assert.NoError(err)
+	assert.True(c.SkipsACLReads())
+
+	c, err = cache.get(zkClientConfig{servers: "127.0.0.1:1", sessionTimeout: 1})
+	assert.NoError(err)
+	assert.False(c.SkipsACLReads())
+}
+
+func TestZKClientConfigTolerateNoAuth(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	cache := &zkClientCache{}
+	c, err := cache.get(zkClientConfig{servers: "127.0.0.1:1", sessionTimeout: 1, tolerateNoAuth: true})
+	assert.NoError(err)
+	assert.True(c.ToleratesUnreadableZNodes())
+
+	c, err = cache.get(zkClientConfig{servers: "127.0.0.1:1", sessionTimeout: 1})
+	assert.NoError(err)
+	assert.False(c.ToleratesUnreadableZNodes())
+}
+
+func TestZKClientConfigPasswordFile(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	cache := &zkClientCache{}
+	_, err := cache.get(zkClientConfig{servers: "127.0.0.1:1", sessionTimeout: 1, username: "foo", passwordFile: "missing"})
+	assert.ErrorContains(err, "failed to read password file 'missing'")
+}
+
+func TestZKClientConfigPreferServers(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	cache := &zkClientCache{}
+	c, err := cache.get(zkClientConfig{
+		servers:         "127.0.0.1:1,127.0.0.2:1",
+		sessionTimeout:  1,
+		preferServers:   "observers",
+		observerServers: []string{"127.0.0.2:1"},
+	})
+	assert.NoError(err)
+	// The configured order is kept
+	assert.Equal([]string{"127.0.0.1:1", "127.0.0.2:1"}, c.Servers())
+
+	_, err = cache.get(zkClientConfig{servers: "127.0.0.1:1", sessionTimeout: 1, preferServers: "leader"})
+	assert.ErrorContains(err, "server preference must be")
+}
+
+func TestZKClientConfigIsSameForBothProviders(t *testing.T) {
+	assert := testifyAssert.New(t)
+	ctx := context.Background()
+
+	// Connecting happens in the background: no ZooKeeper Server is necessary
+	cache := &zkClientCache{}
+
+	sdkv2Provider := newSDKv2Provider(cache)
+	diags := sdkv2Provider.Configure(ctx, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"servers":               "127.0.0.1:1",
+		"session_timeout":       1,
+		"allowed_path_prefixes": []interface{}{},
+	}))
+	assert.False(diags.HasError(), "%v", diags)
+
+	fwProvider := newFrameworkProvider(cache)
+	schemaResp := &fwprovider.SchemaResponse{}
+	fwProvider.Schema(ctx, fwprovider.SchemaRequest{}, schemaResp)
+	configType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	configValues := map[string]tftypes.Value{}
+	for name, attrType := range configType.AttributeTypes {
+		configValues[name] = tftypes.NewValue(attrType, nil)
+	}
+	configValues["servers"] = tftypes.NewValue(tftypes.String, "127.0.0.1:1")
+	configValues["session_timeout"] = tftypes.NewValue(tftypes.Number, 1)
+	configValues["allowed_path_prefixes"] = tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{})
+
+	configureResp := &fwprovider.ConfigureResponse{}
+	fwProvider.Configure(ctx, fwprovider.ConfigureRequest{
+		Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(configType, configValues)},
+	}, configureResp)
+	assert.False(configureResp.Diagnostics.HasError(), "%v", configureResp.Diagnostics)
+
+	assert.Len(cache.created, 1)
+	assert.Same(sdkv2Provider.Meta(), configureResp.ResourceData)
+}
@@ -1,14 +1,16 @@
 package provider_test
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	testifyAssert "github.com/stretchr/testify/assert"
-	"github.com/tfzk/terraform-provider-zookeeper/internal/client"
+	"github.com/tfzk/terraform-provider-zookeeper/client"
 	"github.com/tfzk/terraform-provider-zookeeper/internal/provider"
 )
 
@@ -21,6 +23,28 @@ func TestProvider(t *testing.T) {
 	assert.NoError(provider.InternalValidate())
 }
 
+func TestProviderServer(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	providerServer, err := provider.NewProviderServer(context.Background())
+	assert.NoError(err)
+
+	// Muxing fails if the SDKv2 and the Framework providers don't agree on the provider schema
+	resp, err := providerServer().GetProviderSchema(context.Background(), &tfprotov6.GetProviderSchemaRequest{})
+	assert.NoError(err)
+	assert.Empty(resp.Diagnostics)
+	assert.Contains(resp.ResourceSchemas, "zookeeper_znode")
+	assert.Contains(resp.DataSourceSchemas, "zookeeper_server_version")
+	assert.Contains(resp.ListResourceSchemas, "zookeeper_znode")
+	assert.Contains(resp.ActionSchemas, "zookeeper_delete_subtree")
+
+	// List resources require the identity of the matching managed resource
+	identityResp, err := providerServer().GetResourceIdentitySchemas(context.Background(), &tfprotov6.GetResourceIdentitySchemasRequest{})
+	assert.NoError(err)
+	assert.Empty(identityResp.Diagnostics)
+	assert.Contains(identityResp.IdentitySchemas, "zookeeper_znode")
+}
+
 // providerFactoriesMap associates to each Provider factory instance, a name.
 //
 // WARN: This is important as this will be the name the provider will be expected
@@ -28,9 +52,15 @@ func TestProvider(t *testing.T) {
 // Fail to match the provider expected name will mean that the underlying binary
 // terraform, used during acceptance tests, will error complaining it can't find
 // the provider and `terraform init` should be executed.
-func providerFactoriesMap() map[string]func() (*schema.Provider, error) {
-	return map[string]func() (*schema.Provider, error){
-		"zookeeper": provider.New,
+func providerFactoriesMap() map[string]func() (tfprotov6.ProviderServer, error) {
+	return map[string]func() (tfprotov6.ProviderServer, error){
+		"zookeeper": func() (tfprotov6.ProviderServer, error) {
+			providerServer, err := provider.NewProviderServer(context.Background())
+			if err != nil {
+				return nil, err
+			}
+			return providerServer(), nil
+		},
 	}
 }
 
@@ -64,3 +94,18 @@ func confirmAllZNodeDestroyed(s *terraform.State) error {
 
 	return nil
 }
+
+// confirmZNodeExistence returns a resource.TestCheckFunc confirming whether the ZNode at the given path exists.
+func confirmZNodeExistence(path string, expected bool) resource.TestCheckFunc {
+	return func(_ *terraform.State) error {
+		exists, err := getTestZKClient().Exists(path)
+		if err != nil {
+			return err
+		}
+		if exists != expected {
+			return fmt.Errorf("ZNode '%s' expected to exist: %t, but exists: %t", path, expected, exists)
+		}
+
+		return nil
+	}
+}
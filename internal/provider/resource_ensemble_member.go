@@ -0,0 +1,326 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/tfzk/terraform-provider-zookeeper/client"
+)
+
+const ensembleMemberIDPrefix = "server."
+
+// ensembleMemberResource is the zookeeper_ensemble_member resource, implemented with terraform-plugin-framework.
+//
+// Each instance is one server of the Ensemble dynamic configuration, added and removed via incremental
+// reconfigurations: scaling the Ensemble in or out is adding or removing instances, one server at a time.
+type ensembleMemberResource struct {
+	zkClient *client.Client
+}
+
+type ensembleMemberResourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	ServerID      types.Int64  `tfsdk:"server_id"`
+	Address       types.String `tfsdk:"address"`
+	QuorumPort    types.Int64  `tfsdk:"quorum_port"`
+	ElectionPort  types.Int64  `tfsdk:"election_port"`
+	Role          types.String `tfsdk:"role"`
+	ClientAddress types.String `tfsdk:"client_address"`
+	ClientPort    types.Int64  `tfsdk:"client_port"`
+	ConfigVersion types.String `tfsdk:"config_version"`
+}
+
+var (
+	_ resource.ResourceWithConfigure      = &ensembleMemberResource{}
+	_ resource.ResourceWithValidateConfig = &ensembleMemberResource{}
+	_ resource.ResourceWithImportState    = &ensembleMemberResource{}
+)
+
+func newEnsembleMemberResource() resource.Resource {
+	return &ensembleMemberResource{}
+}
+
+func (r *ensembleMemberResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ensemble_member"
+}
+
+func (r *ensembleMemberResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	configureResourceClient(req, resp, &r.zkClient)
+}
+
+func (r *ensembleMemberResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:      true,
+				Description:   "The key of the server in the Ensemble configuration (ex. `server.4`).",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"server_id": schema.Int64Attribute{
+				Required:      true,
+				Description:   "ID of the server (i.e. its `myid`). Changing it replaces the member.",
+				PlanModifiers: []planmodifier.Int64{int64planmodifier.RequiresReplace()},
+			},
+			"address": schema.StringAttribute{
+				Required:    true,
+				Description: "Address of the server, used for quorum and leader election.",
+			},
+			"quorum_port": schema.Int64Attribute{
+				Required:    true,
+				Description: "Port used by followers to connect to the leader.",
+			},
+			"election_port": schema.Int64Attribute{
+				Required:    true,
+				Description: "Port used for leader election.",
+			},
+			"role": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(client.EnsembleRoleParticipant),
+				Description: "Role of the server: `participant` (i.e. taking part in leader election and quorum) " +
+					"or `observer`. Defaults to `participant`.",
+			},
+			"client_address": schema.StringAttribute{
+				Optional:      true,
+				Computed:      true,
+				Description:   "Address the server listens on for client connections. If not set, the one ZooKeeper defaults to.",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"client_port": schema.Int64Attribute{
+				Required:    true,
+				Description: "Port the server listens on for client connections.",
+			},
+			"config_version": schema.StringAttribute{
+				Computed: true,
+				Description: "Version of the Ensemble configuration, as hexadecimal string (ex. `100000000`), " +
+					"as of the last change or refresh of this member.",
+			},
+		},
+		Description: "Manages one server of the " + ensembleReconfigLinkForDesc + " of the ZooKeeper Ensemble, " +
+			"via [incremental reconfiguration](https://zookeeper.apache.org/doc/current/zookeeperReconfig.html#sc_reconfig_incremental): " +
+			"creating it adds the server to the Ensemble, and destroying it removes the server. " +
+			"Servers not managed by this resource are left untouched. " +
+			"The server must be running, and configured to join the Ensemble, before it's added. " +
+			"Requires ZooKeeper 3.5+ with `reconfigEnabled`, and the provider to be authorized to reconfigure the Ensemble " +
+			"(ex. as super user).",
+	}
+}
+
+func (r *ensembleMemberResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var model ensembleMemberResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !model.ServerID.IsUnknown() && !model.ServerID.IsNull() && model.ServerID.ValueInt64() < 1 {
+		resp.Diagnostics.AddAttributeError(path.Root("server_id"), "Invalid 'server_id'",
+			fmt.Sprintf("Expected 'server_id' to be at least 1, got: %d", model.ServerID.ValueInt64()))
+	}
+
+	for attribute, port := range map[string]types.Int64{
+		"quorum_port":   model.QuorumPort,
+		"election_port": model.ElectionPort,
+		"client_port":   model.ClientPort,
+	} {
+		if !port.IsUnknown() && !port.IsNull() && (port.ValueInt64() < 1 || port.ValueInt64() > 65535) {
+			resp.Diagnostics.AddAttributeError(path.Root(attribute), fmt.Sprintf("Invalid '%s'", attribute),
+				fmt.Sprintf("Expected '%s' to be between 1 and 65535, got: %d", attribute, port.ValueInt64()))
+		}
+	}
+
+	if role := model.Role; !role.IsUnknown() && !role.IsNull() &&
+		role.ValueString() != client.EnsembleRoleParticipant && role.ValueString() != client.EnsembleRoleObserver {
+		resp.Diagnostics.AddAttributeError(path.Root("role"), "Invalid 'role'",
+			fmt.Sprintf("Expected 'role' to be '%s' or '%s', got: '%s'",
+				client.EnsembleRoleParticipant, client.EnsembleRoleObserver, role.ValueString()))
+	}
+}
+
+func (r *ensembleMemberResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan ensembleMemberResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Adding a server with the ID of a member would silently replace it
+	config, diags := r.readConfig()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if _, found := findEnsembleServer(config, plan.ServerID.ValueInt64()); found {
+		resp.Diagnostics.AddAttributeError(path.Root("server_id"), "Ensemble member already exists",
+			fmt.Sprintf("Server '%d' is already a member of the Ensemble, but it's not managed by this resource: "+
+				"import it (see `terraform import`), or remove it first.", plan.ServerID.ValueInt64()))
+		return
+	}
+
+	resp.Diagnostics.Append(r.add(&plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *ensembleMemberResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state ensembleMemberResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config, diags := r.readConfig()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	server, found := findEnsembleServer(config, state.ServerID.ValueInt64())
+	if !found {
+		resp.Diagnostics.AddWarning("Ensemble member removed outside of Terraform",
+			fmt.Sprintf("Server '%d' is no longer a member of the Ensemble: it will be added again.", state.ServerID.ValueInt64()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	setEnsembleMemberFromServer(&state, server, config.Version)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *ensembleMemberResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan ensembleMemberResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Joining with the ID of a member updates it
+	resp.Diagnostics.Append(r.add(&plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *ensembleMemberResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state ensembleMemberResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// ZooKeeper rejects reconfigurations that change nothing
+	config, diags := r.readConfig()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if _, found := findEnsembleServer(config, state.ServerID.ValueInt64()); !found {
+		return
+	}
+
+	if _, err := r.zkClient.RemoveEnsembleServer(int(state.ServerID.ValueInt64())); err != nil {
+		resp.Diagnostics.AddError("Unable to remove Ensemble member", withHint(
+			fmt.Sprintf("Unable to remove server '%d' from the Ensemble: %v", state.ServerID.ValueInt64(), err),
+			zkErrorHint(r.zkClient, zNodeOperationUpdate, ensembleConfigID, err),
+		))
+	}
+}
+
+func (r *ensembleMemberResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	serverID, err := strconv.ParseInt(strings.TrimPrefix(req.ID, ensembleMemberIDPrefix), 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID",
+			fmt.Sprintf("Expected the ID of the server (ex. '4' or 'server.4'), got: '%s'", req.ID))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("server_id"), serverID)...)
+}
+
+// add adds the server of the given model to the Ensemble, or updates it, and sets the model from the new configuration.
+func (r *ensembleMemberResource) add(model *ensembleMemberResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	server := client.EnsembleServer{
+		ID:            int(model.ServerID.ValueInt64()),
+		Address:       model.Address.ValueString(),
+		QuorumPort:    int(model.QuorumPort.ValueInt64()),
+		ElectionPort:  int(model.ElectionPort.ValueInt64()),
+		Role:          model.Role.ValueString(),
+		ClientAddress: model.ClientAddress.ValueString(),
+		ClientPort:    int(model.ClientPort.ValueInt64()),
+	}
+
+	config, err := r.zkClient.AddEnsembleServer(server)
+	if err != nil {
+		diags.AddError("Unable to reconfigure Ensemble", withHint(
+			fmt.Sprintf("Unable to add server '%d' to the Ensemble: %v", server.ID, err),
+			zkErrorHint(r.zkClient, zNodeOperationUpdate, ensembleConfigID, err),
+		))
+		return diags
+	}
+
+	added, found := findEnsembleServer(config, model.ServerID.ValueInt64())
+	if !found {
+		diags.AddError("Unable to reconfigure Ensemble",
+			fmt.Sprintf("Server '%d' is not a member of the Ensemble after adding it: it was likely removed concurrently.", server.ID))
+		return diags
+	}
+
+	setEnsembleMemberFromServer(model, added, config.Version)
+	return diags
+}
+
+// readConfig reads the configuration of the Ensemble.
+func (r *ensembleMemberResource) readConfig() (*client.EnsembleConfig, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	config, err := r.zkClient.ReadEnsembleConfig()
+	if err != nil {
+		diags.AddError("Unable to read Ensemble configuration", withHint(
+			fmt.Sprintf("Unable to read Ensemble configuration: %v", err),
+			zkErrorHint(r.zkClient, zNodeOperationRead, ensembleConfigID, err),
+		))
+	}
+
+	return config, diags
+}
+
+// findEnsembleServer returns the server with the given ID in the given configuration, if any.
+func findEnsembleServer(config *client.EnsembleConfig, serverID int64) (client.EnsembleServer, bool) {
+	for _, server := range config.Servers {
+		if int64(server.ID) == serverID {
+			return server, true
+		}
+	}
+
+	return client.EnsembleServer{}, false
+}
+
+// setEnsembleMemberFromServer sets the given model from the given server, of the configuration with the given version.
+func setEnsembleMemberFromServer(model *ensembleMemberResourceModel, server client.EnsembleServer, version string) {
+	model.ID = types.StringValue(ensembleMemberIDPrefix + strconv.Itoa(server.ID))
+	model.ServerID = types.Int64Value(int64(server.ID))
+	model.Address = types.StringValue(server.Address)
+	model.QuorumPort = types.Int64Value(int64(server.QuorumPort))
+	model.ElectionPort = types.Int64Value(int64(server.ElectionPort))
+	model.Role = types.StringValue(server.Role)
+	model.ClientAddress = types.StringValue(server.ClientAddress)
+	model.ClientPort = types.Int64Value(int64(server.ClientPort))
+	model.ConfigVersion = types.StringValue(version)
+}
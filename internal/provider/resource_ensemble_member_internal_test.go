@@ -0,0 +1,33 @@
+package provider
+
+import (
+	"testing"
+
+	testifyAssert "github.com/stretchr/testify/assert"
+	"github.com/tfzk/terraform-provider-zookeeper/client"
+)
+
+func TestFindEnsembleServer(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	config := &client.EnsembleConfig{
+		Version: "100000000",
+		Servers: []client.EnsembleServer{
+			{ID: 1, Address: "zk1", QuorumPort: 2888, ElectionPort: 3888, Role: "participant", ClientPort: 2181},
+			{ID: 4, Address: "zk4", QuorumPort: 2888, ElectionPort: 3888, Role: "observer", ClientPort: 2181},
+		},
+	}
+
+	server, found := findEnsembleServer(config, 4)
+	assert.True(found)
+	assert.Equal("zk4", server.Address)
+
+	_, found = findEnsembleServer(config, 2)
+	assert.False(found)
+
+	var model ensembleMemberResourceModel
+	setEnsembleMemberFromServer(&model, server, config.Version)
+	assert.Equal("server.4", model.ID.ValueString())
+	assert.Equal("observer", model.Role.ValueString())
+	assert.Equal("100000000", model.ConfigVersion.ValueString())
+}
@@ -0,0 +1,40 @@
+package provider_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceEnsembleMember_Validation(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { checkPreconditions(t) },
+		ProtoV6ProviderFactories: providerFactoriesMap(),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "zookeeper_ensemble_member" "zk4" {
+						server_id     = 4
+						address       = "zk4"
+						quorum_port   = 2888
+						election_port = 3888
+						client_port   = 2181
+						role          = "leader"
+					}`,
+				ExpectError: regexp.MustCompile("Expected 'role' to be 'participant' or 'observer', got: 'leader'"),
+			},
+			{
+				Config: `
+					resource "zookeeper_ensemble_member" "zk4" {
+						server_id     = 0
+						address       = "zk4"
+						quorum_port   = 2888
+						election_port = 3888
+						client_port   = 65536
+					}`,
+				ExpectError: regexp.MustCompile("Expected 'client_port' to be between 1 and 65535, got: 65536"),
+			},
+		},
+	})
+}
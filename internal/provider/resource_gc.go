@@ -0,0 +1,312 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/tfzk/terraform-provider-zookeeper/client"
+)
+
+const gcDefaultMaxDeletes = 100
+
+// gcResource is the zookeeper_gc resource, implemented with terraform-plugin-framework.
+//
+// It owns no ZNode: each refresh lists the children of `path` older than `max_age` as `pending`,
+// and ModifyPlan plans an update to delete them, so that each apply collects what became stale since the last one.
+type gcResource struct {
+	zkClient *client.Client
+}
+
+type gcResourceModel struct {
+	ID         types.String `tfsdk:"id"`
+	Path       types.String `tfsdk:"path"`
+	MaxAge     types.String `tfsdk:"max_age"`
+	MaxDeletes types.Int64  `tfsdk:"max_deletes"`
+	DryRun     types.Bool   `tfsdk:"dry_run"`
+	Pending    types.List   `tfsdk:"pending"`
+	Deleted    types.List   `tfsdk:"deleted"`
+}
+
+var (
+	_ resource.ResourceWithConfigure      = &gcResource{}
+	_ resource.ResourceWithValidateConfig = &gcResource{}
+	_ resource.ResourceWithModifyPlan     = &gcResource{}
+)
+
+func newGCResource() resource.Resource {
+	return &gcResource{}
+}
+
+func (r *gcResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_gc"
+}
+
+func (r *gcResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	configureResourceClient(req, resp, &r.zkClient)
+}
+
+func (r *gcResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:      true,
+				Description:   "The `path` of the collected ZNode.",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"path": schema.StringAttribute{
+				Required: true,
+				Description: "Absolute path to the ZNode whose children are collected. " +
+					"Can't be `/`, nor `" + systemZNodesPath + "` or any ZNode under it. " +
+					"It's not an error if it doesn't exist (yet).",
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"max_age": schema.StringAttribute{
+				Required:   true,
+				Validators: []validator.String{durationValidator{}},
+				Description: "How long since a child of `path` was last modified (i.e. its `mtime`) before it's collected, " +
+					"along with its descendants. Expressed as a " + durationLinkForDesc + " (ex. `72h`).",
+			},
+			"max_deletes": schema.Int64Attribute{
+				Optional: true,
+				Computed: true,
+				Default:  int64default.StaticInt64(gcDefaultMaxDeletes),
+				Description: "The most children an apply can delete: if more are stale, the plan fails without deleting any, " +
+					"as `max_age` is likely mistaken. Raise it temporarily to collect a backlog. " +
+					fmt.Sprintf("Defaults to `%d`.", gcDefaultMaxDeletes),
+			},
+			"dry_run": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+				Description: "If `true`, nothing is deleted: stale children are only reported in `pending` " +
+					"(ex. to review them before enabling the collection). Defaults to `false`.",
+			},
+			"pending": schema.ListAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "Paths of the children of `path` older than `max_age`, as of the last refresh, sorted by name: " +
+					"unless `dry_run`, they are deleted by the next apply.",
+			},
+			"deleted": schema.ListAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "Paths of the children of `path` deleted by the last apply that collected any, sorted by name.",
+			},
+		},
+		Description: "Garbage-collects the children of a " + zNodeLinkForDesc + " that were not modified for longer than `max_age`, " +
+			"on each apply (ex. registrations of finished jobs), for ZooKeeper versions without TTL ZNodes. " +
+			"The ZNode at `path` and its recent children are never modified, and destroying this resource deletes nothing. " +
+			"The ability to delete ZNodes is determined by ZooKeeper ACL.",
+	}
+}
+
+func (r *gcResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var model gcResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !model.Path.IsUnknown() && !model.Path.IsNull() {
+		znodePath := model.Path.ValueString()
+		if znodePath == "/" || isSystemZNodePath(znodePath) {
+			resp.Diagnostics.AddAttributeError(path.Root("path"), "Invalid 'path'",
+				fmt.Sprintf("Collecting the children of ZNode '%s' is not allowed", znodePath))
+		}
+	}
+
+	if !model.MaxDeletes.IsUnknown() && !model.MaxDeletes.IsNull() && model.MaxDeletes.ValueInt64() < 1 {
+		resp.Diagnostics.AddAttributeError(path.Root("max_deletes"), "Invalid 'max_deletes'",
+			fmt.Sprintf("Expected 'max_deletes' to be at least 1, got: %d", model.MaxDeletes.ValueInt64()))
+	}
+}
+
+func (r *gcResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Creating collects anyway, and destroying collects nothing
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, state gcResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() || plan.DryRun.IsUnknown() || plan.DryRun.ValueBool() {
+		return
+	}
+
+	if len(state.Pending.Elements()) == 0 {
+		return
+	}
+
+	if !plan.MaxDeletes.IsUnknown() {
+		resp.Diagnostics.Append(checkMaxDeletes(len(state.Pending.Elements()), plan.MaxDeletes.ValueInt64())...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Planning the update that deletes them: the diff of `pending` shows which ones
+	plan.Pending = types.ListValueMust(types.StringType, nil)
+	plan.Deleted = types.ListUnknown(types.StringType)
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+func (r *gcResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan gcResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = plan.Path
+	plan.Deleted = types.ListValueMust(types.StringType, nil)
+	resp.Diagnostics.Append(r.collect(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *gcResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state gcResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stale, diags := r.findStale(state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.Pending, diags = types.ListValueFrom(ctx, types.StringType, stale)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *gcResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state gcResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Kept as in the state if nothing gets deleted (ex. only `max_age` changed, or `dry_run`)
+	plan.Deleted = state.Deleted
+	resp.Diagnostics.Append(r.collect(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *gcResource) Delete(_ context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
+	// Nothing to delete: the collected ZNodes are not owned by the resource
+}
+
+// collect deletes the stale children of the given model `path` (see findStale), unless `dry_run`,
+// and sets its `pending` and `deleted` accordingly.
+func (r *gcResource) collect(ctx context.Context, model *gcResourceModel) diag.Diagnostics {
+	stale, diags := r.findStale(*model)
+	if diags.HasError() {
+		return diags
+	}
+
+	if !model.DryRun.ValueBool() && len(stale) > 0 {
+		diags.Append(checkMaxDeletes(len(stale), model.MaxDeletes.ValueInt64())...)
+		if diags.HasError() {
+			return diags
+		}
+
+		for _, stalePath := range stale {
+			// Tolerating children deleted concurrently, ex. by the process that created them
+			if err := r.zkClient.Delete(stalePath); err != nil && !errors.Is(err, client.ErrorZNodeDoesNotExist) {
+				diags.AddError("Unable to collect ZNode", withHint(
+					fmt.Sprintf("Unable to delete stale ZNode '%s': %v", stalePath, err),
+					zkErrorHint(r.zkClient, zNodeOperationDelete, stalePath, err),
+				))
+				return diags
+			}
+		}
+
+		deleted, listDiags := types.ListValueFrom(ctx, types.StringType, stale)
+		diags.Append(listDiags...)
+		model.Deleted = deleted
+		stale = nil
+	}
+
+	pending, listDiags := types.ListValueFrom(ctx, types.StringType, stale)
+	diags.Append(listDiags...)
+	model.Pending = pending
+
+	return diags
+}
+
+// findStale returns the paths of the children of the given model `path` older than its `max_age`,
+// or none if `path` doesn't exist.
+func (r *gcResource) findStale(model gcResourceModel) ([]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	maxAge, err := time.ParseDuration(model.MaxAge.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("max_age"), "Invalid 'max_age'", err.Error())
+		return nil, diags
+	}
+
+	znodePath := model.Path.ValueString()
+	children, err := r.zkClient.ReadChildrenStats(znodePath)
+	if errors.Is(err, client.ErrorZNodeDoesNotExist) {
+		return []string{}, diags
+	}
+	if err != nil {
+		diags.AddError("Unable to list ZNodes to collect", withHint(
+			fmt.Sprintf("Unable to list children of ZNode '%s': %v", znodePath, err),
+			zkErrorHint(r.zkClient, zNodeOperationRead, znodePath, err),
+		))
+		return nil, diags
+	}
+
+	return staleChildren(children, maxAge, time.Now()), diags
+}
+
+// staleChildren returns the paths of the given ZNodes last modified longer than `maxAge` before `now`, in the same order.
+func staleChildren(children []*client.ZNode, maxAge time.Duration, now time.Time) []string {
+	stale := []string{}
+	for _, child := range children {
+		// `mtime` is in milliseconds since the epoch
+		if now.Sub(time.UnixMilli(child.Stat.Mtime)) > maxAge {
+			stale = append(stale, child.Path)
+		}
+	}
+
+	return stale
+}
+
+// checkMaxDeletes returns an error if `count` ZNodes to delete exceed `maxDeletes`.
+func checkMaxDeletes(count int, maxDeletes int64) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if int64(count) > maxDeletes {
+		diags.AddAttributeError(path.Root("max_deletes"), "Too many ZNodes to collect",
+			fmt.Sprintf("%d ZNodes are older than 'max_age', more than 'max_deletes' (%d): none was deleted. "+
+				"Check that 'max_age' is correct (ex. via 'dry_run'), then raise 'max_deletes' to collect them.", count, maxDeletes))
+	}
+
+	return diags
+}
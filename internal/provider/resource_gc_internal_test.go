@@ -0,0 +1,37 @@
+package provider
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-zookeeper/zk"
+	testifyAssert "github.com/stretchr/testify/assert"
+	"github.com/tfzk/terraform-provider-zookeeper/client"
+)
+
+func TestStaleChildren(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	children := []*client.ZNode{
+		{Path: "/jobs/job-1", Stat: &zk.Stat{Mtime: now.Add(-72 * time.Hour).UnixMilli()}},
+		{Path: "/jobs/job-2", Stat: &zk.Stat{Mtime: now.Add(-time.Hour).UnixMilli()}},
+		{Path: "/jobs/job-3", Stat: &zk.Stat{Mtime: now.Add(-25 * time.Hour).UnixMilli()}},
+	}
+
+	assert.Equal([]string{"/jobs/job-1", "/jobs/job-3"}, staleChildren(children, 24*time.Hour, now))
+	assert.Equal([]string{"/jobs/job-1"}, staleChildren(children, 48*time.Hour, now))
+	assert.Equal([]string{}, staleChildren(children, 96*time.Hour, now))
+	assert.Equal([]string{}, staleChildren(nil, time.Hour, now))
+}
+
+func TestCheckMaxDeletes(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	assert.False(checkMaxDeletes(0, 1).HasError())
+	assert.False(checkMaxDeletes(100, 100).HasError())
+
+	diags := checkMaxDeletes(101, 100)
+	assert.True(diags.HasError())
+	assert.Contains(diags[0].Detail(), "101 ZNodes are older than 'max_age', more than 'max_deletes' (100)")
+}
@@ -0,0 +1,103 @@
+package provider_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceGC(t *testing.T) {
+	parentPath := "/" + acctest.RandString(10)
+	config := func(dryRun bool, maxDeletes int) string {
+		return fmt.Sprintf(`
+			resource "zookeeper_gc" "jobs" {
+				path        = "%s"
+				max_age     = "1ms"
+				max_deletes = %d
+				dry_run     = %t
+			}`, parentPath, maxDeletes, dryRun,
+		)
+	}
+	createJobs := func(names ...string) func() {
+		return func() {
+			for _, name := range names {
+				if _, err := getTestZKClient().Create(parentPath+"/"+name, nil, nil); err != nil {
+					t.Fatal(err)
+				}
+			}
+		}
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() {
+			checkPreconditions(t)
+			// Destroying zookeeper_gc deletes nothing
+			t.Cleanup(func() { _ = getTestZKClient().Delete(parentPath) })
+		},
+		ProtoV6ProviderFactories: providerFactoriesMap(),
+		Steps: []resource.TestStep{
+			{
+				PreConfig: createJobs("job-1", "job-2/step-1"),
+				Config:    config(true, 10),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("zookeeper_gc.jobs", "id", parentPath),
+					resource.TestCheckResourceAttr("zookeeper_gc.jobs", "pending.#", "2"),
+					resource.TestCheckResourceAttr("zookeeper_gc.jobs", "pending.0", parentPath+"/job-1"),
+					resource.TestCheckResourceAttr("zookeeper_gc.jobs", "pending.1", parentPath+"/job-2"),
+					resource.TestCheckResourceAttr("zookeeper_gc.jobs", "deleted.#", "0"),
+					confirmZNodeExistence(parentPath+"/job-2/step-1", true),
+				),
+			},
+			{
+				Config: config(false, 10),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("zookeeper_gc.jobs", "pending.#", "0"),
+					resource.TestCheckResourceAttr("zookeeper_gc.jobs", "deleted.#", "2"),
+					confirmZNodeExistence(parentPath+"/job-1", false),
+					confirmZNodeExistence(parentPath+"/job-2", false),
+					confirmZNodeExistence(parentPath, true),
+				),
+			},
+			{
+				// Children becoming stale are planned for deletion
+				PreConfig:          createJobs("job-3"),
+				Config:             config(false, 10),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				PreConfig:   createJobs("job-4"),
+				Config:      config(false, 1),
+				ExpectError: regexp.MustCompile("Too many ZNodes to collect"),
+			},
+			{
+				Config: config(false, 10),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("zookeeper_gc.jobs", "deleted.#", "2"),
+					resource.TestCheckResourceAttr("zookeeper_gc.jobs", "deleted.0", parentPath+"/job-3"),
+					confirmZNodeExistence(parentPath+"/job-4", false),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceGC_InvalidPath(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { checkPreconditions(t) },
+		ProtoV6ProviderFactories: providerFactoriesMap(),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "zookeeper_gc" "zookeeper" {
+						path    = "/zookeeper/quota"
+						max_age = "1h"
+					}`,
+				ExpectError: regexp.MustCompile("Collecting the children of ZNode '/zookeeper/quota' is not allowed"),
+			},
+		},
+	})
+}
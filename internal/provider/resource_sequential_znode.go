@@ -5,8 +5,9 @@ import (
 	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/tfzk/terraform-provider-zookeeper/internal/client"
+	"github.com/tfzk/terraform-provider-zookeeper/client"
 )
 
 func resourceSeqZNode() *schema.Resource {
@@ -15,44 +16,84 @@ func resourceSeqZNode() *schema.Resource {
 		ReadContext:   resourceSeqZNodeRead,
 		UpdateContext: resourceSeqZNodeUpdate,
 		DeleteContext: resourceSeqZNodeDelete,
+		CustomizeDiff: customdiff.All(
+			checkPathWritable("path_prefix"),
+			checkACLAllowed(),
+			setParentPathWhenKnown(),
+			setEmptyDataWhenConfigured(),
+			setContentVersionWhenKnown(),
+		),
+		ValidateRawResourceConfigFuncs: []schema.ValidateRawResourceConfigFunc{
+			warnWorldOpenACL,
+		},
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceSeqZNodeImport,
 		},
 		Schema: map[string]*schema.Schema{
 			"path_prefix": {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateSequentialPathPrefix,
 				Description: "Absolute path to the Sequential ZNode to create. " +
 					"ZooKeeper will append a monotonically increasing counter to the end of path. " +
 					"This counter is unique to the parent znode, and its format is " +
 					"`%010d` (10 digits with `0` padding)." +
 					"For example, the first sequential node created with a given " +
-					"`path_prefix` will be: `<path-prefix>0000000001`.",
+					"`path_prefix` will be: `<path-prefix>0000000001`. " +
+					"If it ends with `/`, the name of the ZNode is just the counter, " +
+					"and it is created as a child of the path before it (ex. `/forza/` creates `/forza/0000000001`).",
+			},
+			"parent_path": {
+				Type:     schema.TypeString,
+				Computed: true,
+				Description: "Absolute path to the parent of the Sequential ZNode, where it is created: " +
+					"the `path_prefix` up to its last `/` (ex. `/forza` for both `/forza/napoli-` and `/forza/`). " +
+					"Known when planning.",
 			},
 			"data": {
 				Type:          schema.TypeString,
 				Optional:      true,
 				Computed:      true,
-				ConflictsWith: []string{"data_base64"},
+				ConflictsWith: []string{"data_base64", "data_hex"},
 				Description: "Content to store in the ZNode, as a UTF-8 string. " +
-					"Mutually exclusive with `data_base64`.",
+					"Mutually exclusive with `data_base64` and `data_hex`. " +
+					"Set to `\"\"` to keep the ZNode empty (zero bytes): if none of them is set, the content is not managed " +
+					"(i.e. changes made outside of Terraform are left as they are).",
 			},
 			"data_base64": {
 				Type:          schema.TypeString,
 				Optional:      true,
 				Computed:      true,
-				ConflictsWith: []string{"data"},
+				ConflictsWith: []string{"data", "data_hex"},
 				Description: "Content to store in the ZNode, as Base64 encoded bytes. " +
-					"Mutually exclusive with `data`.",
+					"Mutually exclusive with `data` and `data_hex`.",
 			},
+			"data_hex": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ConflictsWith:    []string{"data", "data_base64"},
+				ValidateFunc:     validateHex,
+				DiffSuppressFunc: suppressHexCaseDiff,
+				Description: "Content to store in the ZNode, as hexadecimal encoded bytes (ex. `cafe00`), " +
+					"handy for short binary content (ex. magic bytes). Read in lowercase. " +
+					"Mutually exclusive with `data` and `data_base64`.",
+			},
+			"charset":         charsetSchema(),
+			"content_version": contentVersionSchema(),
 			"path": {
 				Type:     schema.TypeString,
 				Computed: true,
 				Description: "Absolute path to the Sequential ZNode, once it is created. " +
 					"The prefix of this will match `path_prefix`.",
 			},
-			"stat": statSchema(),
+			"light_refresh":   lightRefreshSchema(),
+			"on_missing":      onMissingSchema(),
+			"stat":            statSchema(),
+			"track_stat":      trackStatSchema(),
+			"is_ephemeral":    isEphemeralSchema(),
+			"ephemeral_owner": ephemeralOwnerSchema(),
 			"acl": {
 				Type:        schema.TypeList,
 				Optional:    true,
@@ -107,18 +148,23 @@ func resourceSeqZNodeCreate(_ context.Context, rscData *schema.ResourceData, prv
 
 	znode, err := zkClient.CreateSequential(znodePathPrefix, dataBytes, acls)
 	if err != nil {
-		return diag.Errorf("Failed to create Sequential ZNode '%s': %v", znodePathPrefix, err)
+		return zkErrorf(zkErrorHint(zkClient, zNodeOperationCreate, znodePathPrefix, err), "Failed to create Sequential ZNode '%s': %v", znodePathPrefix, err)
 	}
 
 	// Terraform will use the ZNode.Path as unique identifier for this Resource
 	rscData.SetId(znode.Path)
 	rscData.MarkNewResource()
 
-	return setAttributesFromZNode(rscData, znode, diag.Diagnostics{})
+	return setAttributesFromZNode(rscData, znode, setParentPathFromZNode(rscData, znode, diag.Diagnostics{}))
 }
 
 func resourceSeqZNodeRead(ctx context.Context, rscData *schema.ResourceData, prvClient interface{}) diag.Diagnostics {
-	return resourceZNodeRead(ctx, rscData, prvClient)
+	znode, diags := readZNodeOfResource(ctx, rscData, prvClient.(*client.Client))
+	if znode == nil {
+		return diags
+	}
+
+	return setAttributesFromZNode(rscData, znode, setParentPathFromZNode(rscData, znode, diags))
 }
 
 func resourceSeqZNodeUpdate(ctx context.Context, rscData *schema.ResourceData, prvClient interface{}) diag.Diagnostics {
@@ -138,3 +184,30 @@ func resourceSeqZNodeImport(_ context.Context, rscData *schema.ResourceData, _ i
 
 	return []*schema.ResourceData{rscData}, nil
 }
+
+// setParentPathWhenKnown returns a schema.CustomizeDiffFunc that sets the computed `parent_path`
+// of a Sequential ZNode being created (or replaced), from its `path_prefix`, so that it is known when planning.
+func setParentPathWhenKnown() schema.CustomizeDiffFunc {
+	return func(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+		if !diff.NewValueKnown("path_prefix") || (diff.Id() != "" && !diff.HasChange("path_prefix")) {
+			return nil
+		}
+
+		parentPath, _ := splitSequentialPathPrefix(diff.Get("path_prefix").(string))
+		if err := diff.SetNew("parent_path", parentPath); err != nil {
+			return fmt.Errorf("failed to set 'parent_path': %w", err)
+		}
+
+		return nil
+	}
+}
+
+// setParentPathFromZNode sets the `parent_path` attribute of a Sequential ZNode, from the path of the given ZNode.
+func setParentPathFromZNode(rscData *schema.ResourceData, znode *client.ZNode, diags diag.Diagnostics) diag.Diagnostics {
+	parentPath, _ := splitZNodePath(znode.Path)
+	if err := rscData.Set("parent_path", parentPath); err != nil {
+		diags = append(diags, diag.FromErr(err)...)
+	}
+
+	return diags
+}
@@ -0,0 +1,36 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	testifyAssert "github.com/stretchr/testify/assert"
+	"github.com/tfzk/terraform-provider-zookeeper/client"
+)
+
+func TestSeqZNodeWithoutNotifyPath(t *testing.T) {
+	assert := testifyAssert.New(t)
+
+	// Connecting happens in the background: no ZooKeeper Server is necessary
+	zkClient, err := client.NewClient("127.0.0.1:1", 1, "", "")
+	assert.NoError(err)
+	defer zkClient.Close()
+
+	seqZNodeData := func() *schema.ResourceData {
+		rscData := schema.TestResourceDataRaw(t, resourceSeqZNode().Schema, map[string]interface{}{
+			"path_prefix": "/forza/napoli-",
+			"data":        "Forza Napoli!",
+		})
+		rscData.SetId("/forza/napoli-0000000042")
+		return rscData
+	}
+
+	// zookeeper_sequential_znode shares the CRUD of zookeeper_znode, but has no `notify_path`:
+	// without a ZooKeeper Server, the operations fail, rather than panic
+	assert.True(resourceSeqZNodeUpdate(context.Background(), seqZNodeData(), zkClient).HasError())
+	assert.True(resourceSeqZNodeDelete(context.Background(), seqZNodeData(), zkClient).HasError())
+
+	// As if they succeeded: there is nothing to notify
+	assert.Empty(notifyZNodeChange(context.Background(), seqZNodeData(), zkClient, nil))
+}
@@ -2,6 +2,7 @@ package provider_test
 
 import (
 	"fmt"
+	"path"
 	"regexp"
 	"testing"
 
@@ -13,9 +14,9 @@ func TestAccResourceSeqZNode_FromDir(t *testing.T) {
 	seqFromDir := "/" + acctest.RandString(10) + "/"
 
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { checkPreconditions(t) },
-		ProviderFactories: providerFactoriesMap(),
-		CheckDestroy:      confirmAllZNodeDestroyed,
+		PreCheck:                 func() { checkPreconditions(t) },
+		ProtoV6ProviderFactories: providerFactoriesMap(),
+		CheckDestroy:             confirmAllZNodeDestroyed,
 		Steps: []resource.TestStep{
 			{
 				Config: fmt.Sprintf(`
@@ -29,6 +30,7 @@ func TestAccResourceSeqZNode_FromDir(t *testing.T) {
 					resource.TestCheckResourceAttrPair("zookeeper_sequential_znode.from_dir", "path", "zookeeper_sequential_znode.from_dir", "id"),
 					resource.TestCheckResourceAttr("zookeeper_sequential_znode.from_dir", "data", "sequential znode created by passing a dir"),
 					resource.TestCheckResourceAttr("zookeeper_sequential_znode.from_dir", "data_base64", "c2VxdWVudGlhbCB6bm9kZSBjcmVhdGVkIGJ5IHBhc3NpbmcgYSBkaXI="),
+					resource.TestCheckResourceAttr("zookeeper_sequential_znode.from_dir", "parent_path", seqFromDir[:len(seqFromDir)-1]),
 				),
 			},
 			{
@@ -44,9 +46,9 @@ func TestAccResourceSeqZNode_FromPrefix(t *testing.T) {
 	seqFromPrefix := "/" + acctest.RandString(10) + "/prefix-"
 
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { checkPreconditions(t) },
-		ProviderFactories: providerFactoriesMap(),
-		CheckDestroy:      confirmAllZNodeDestroyed,
+		PreCheck:                 func() { checkPreconditions(t) },
+		ProtoV6ProviderFactories: providerFactoriesMap(),
+		CheckDestroy:             confirmAllZNodeDestroyed,
 		Steps: []resource.TestStep{
 			{
 				Config: fmt.Sprintf(`
@@ -60,6 +62,7 @@ func TestAccResourceSeqZNode_FromPrefix(t *testing.T) {
 					resource.TestCheckResourceAttrPair("zookeeper_sequential_znode.from_prefix", "path", "zookeeper_sequential_znode.from_prefix", "id"),
 					resource.TestCheckResourceAttr("zookeeper_sequential_znode.from_prefix", "data", "sequential znode created by passing a prefix"),
 					resource.TestCheckResourceAttr("zookeeper_sequential_znode.from_prefix", "data_base64", "c2VxdWVudGlhbCB6bm9kZSBjcmVhdGVkIGJ5IHBhc3NpbmcgYSBwcmVmaXg="),
+					resource.TestCheckResourceAttr("zookeeper_sequential_znode.from_prefix", "parent_path", path.Dir(seqFromPrefix)),
 				),
 			},
 			{
@@ -71,13 +74,36 @@ func TestAccResourceSeqZNode_FromPrefix(t *testing.T) {
 	})
 }
 
+func TestAccResourceSeqZNode_InvalidPathPrefix(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { checkPreconditions(t) },
+		ProtoV6ProviderFactories: providerFactoriesMap(),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "zookeeper_sequential_znode" "relative" {
+						path_prefix = "forza/napoli-"
+					}`,
+				ExpectError: regexp.MustCompile(`expected 'path_prefix' to be an absolute path`),
+			},
+			{
+				Config: `
+					resource "zookeeper_sequential_znode" "empty_segment" {
+						path_prefix = "/forza//napoli-"
+					}`,
+				ExpectError: regexp.MustCompile(`expected 'path_prefix' to not contain empty or relative segments`),
+			},
+		},
+	})
+}
+
 func TestAccResourceSeqZNode_DefaultACL(t *testing.T) {
 	seqFromDir := "/" + acctest.RandString(10) + "/"
 
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { checkPreconditions(t) },
-		ProviderFactories: providerFactoriesMap(),
-		CheckDestroy:      confirmAllZNodeDestroyed,
+		PreCheck:                 func() { checkPreconditions(t) },
+		ProtoV6ProviderFactories: providerFactoriesMap(),
+		CheckDestroy:             confirmAllZNodeDestroyed,
 		Steps: []resource.TestStep{
 			{
 				Config: fmt.Sprintf(`
@@ -110,9 +136,9 @@ func TestAccResourceSeqZNode_WithACL(t *testing.T) {
 	seqFromDir := "/" + acctest.RandString(10) + "/"
 
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { checkPreconditions(t) },
-		ProviderFactories: providerFactoriesMap(),
-		CheckDestroy:      confirmAllZNodeDestroyed,
+		PreCheck:                 func() { checkPreconditions(t) },
+		ProtoV6ProviderFactories: providerFactoriesMap(),
+		CheckDestroy:             confirmAllZNodeDestroyed,
 		Steps: []resource.TestStep{
 			{
 				Config: fmt.Sprintf(`
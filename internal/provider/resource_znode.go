@@ -3,10 +3,12 @@ package provider
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/tfzk/terraform-provider-zookeeper/internal/client"
+	"github.com/tfzk/terraform-provider-zookeeper/client"
 )
 
 func resourceZNode() *schema.Resource {
@@ -15,33 +17,98 @@ func resourceZNode() *schema.Resource {
 		ReadContext:   resourceZNodeRead,
 		UpdateContext: resourceZNodeUpdate,
 		DeleteContext: resourceZNodeDelete,
+		CustomizeDiff: customdiff.All(
+			setPathFromPartsWhenKnown(),
+			checkPathWritable("path"),
+			checkPathWritable("notify_path"),
+			checkACLAllowed(),
+			setPathPartsWhenKnown("path"),
+			setEmptyDataWhenConfigured(),
+			setContentVersionWhenKnown(),
+			forceNewOnDataChange(),
+		),
+		ValidateRawResourceConfigFuncs: []schema.ValidateRawResourceConfigFunc{
+			warnWorldOpenACL,
+			warnSequentialSuffix,
+		},
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceZNodeImport,
+		},
+		Identity: &schema.ResourceIdentity{
+			SchemaFunc: zNodeIdentitySchema,
 		},
 		Schema: map[string]*schema.Schema{
 			"path": {
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
-				Description: "Absolute path to the ZNode to create.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"path", "parent_path"},
+				ValidateFunc: validatePath,
+				Description: "Absolute path to the ZNode to create. " +
+					"Alternatively, declare the ZNode via `parent_path` and `name`, and this is computed.",
+			},
+			"parent_path": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				RequiredWith: []string{"name"},
+				ValidateFunc: validatePath,
+				Description: "Absolute path to the parent of the ZNode (ex. `/forza` for `/forza/napoli`), " +
+					"to compose the paths of other ZNodes with. " +
+					"Set it, along with `name`, instead of `path`: referencing the `path` of another `zookeeper_znode` " +
+					"(ex. `parent_path = zookeeper_znode.forza.path`) creates the parent before its children, " +
+					"without `depends_on`.",
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				RequiredWith: []string{"parent_path"},
+				ValidateFunc: validateName,
+				Description: "Name of the ZNode, i.e. the last segment of its `path` (ex. `napoli` for `/forza/napoli`). " +
+					"Set it, along with `parent_path`, instead of `path`.",
 			},
 			"data": {
 				Type:          schema.TypeString,
 				Optional:      true,
 				Computed:      true,
-				ConflictsWith: []string{"data_base64"},
+				ConflictsWith: []string{"data_base64", "data_hex"},
 				Description: "Content to store in the ZNode, as a UTF-8 string. " +
-					"Mutually exclusive with `data_base64`.",
+					"Mutually exclusive with `data_base64` and `data_hex`. " +
+					"Set to `\"\"` to keep the ZNode empty (zero bytes): if none of them is set, the content is not managed " +
+					"(i.e. changes made outside of Terraform are left as they are).",
 			},
 			"data_base64": {
 				Type:          schema.TypeString,
 				Optional:      true,
 				Computed:      true,
-				ConflictsWith: []string{"data"},
+				ConflictsWith: []string{"data", "data_hex"},
 				Description: "Content to store in the ZNode, as Base64 encoded bytes. " +
-					"Mutually exclusive with `data`.",
+					"Mutually exclusive with `data` and `data_hex`.",
 			},
-			"stat": statSchema(),
+			"data_hex": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ConflictsWith:    []string{"data", "data_base64"},
+				ValidateFunc:     validateHex,
+				DiffSuppressFunc: suppressHexCaseDiff,
+				Description: "Content to store in the ZNode, as hexadecimal encoded bytes (ex. `cafe00`), " +
+					"handy for short binary content (ex. magic bytes). Read in lowercase. " +
+					"Mutually exclusive with `data` and `data_base64`.",
+			},
+			"charset":         charsetSchema(),
+			"content_version": contentVersionSchema(),
+			"retry":           retryBlockSchema(),
+			"light_refresh":   lightRefreshSchema(),
+			"on_missing":      onMissingSchema(),
+			"stat":            statSchema(),
+			"track_stat":      trackStatSchema(),
+			"is_ephemeral":    isEphemeralSchema(),
+			"ephemeral_owner": ephemeralOwnerSchema(),
 			"acl": {
 				Type:        schema.TypeList,
 				Optional:    true,
@@ -70,6 +137,8 @@ func resourceZNode() *schema.Resource {
 					},
 				},
 			},
+			"replace_on_data_change": replaceOnDataChangeSchema(),
+			"notify_path":            notifyPathSchema(),
 		},
 		Description: "Manages the lifecycle of a " +
 			zNodeLinkForDesc + ". " +
@@ -79,10 +148,53 @@ func resourceZNode() *schema.Resource {
 	}
 }
 
-func resourceZNodeCreate(_ context.Context, rscData *schema.ResourceData, prvClient interface{}) diag.Diagnostics {
+// zNodeIdentitySchema is the schema of the identity of a zookeeper_znode Resource: its path.
+//
+// It allows to import a ZNode via an `import` block with `identity` (Terraform 1.12+),
+// and it's what zookeeper_znode list resource produces (see zNodeListResource).
+func zNodeIdentitySchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"path": {
+			Type:              schema.TypeString,
+			RequiredForImport: true,
+			Description:       "Absolute path to the ZNode.",
+		},
+	}
+}
+
+// resourceZNodeImport imports a ZNode by its path, given as ID or identity, as is: names with special characters
+// (ex. spaces or `%`) must not be escaped, unless they are escaped in ZooKeeper too (see the `path_escape` function).
+func resourceZNodeImport(ctx context.Context, rscData *schema.ResourceData, prvClient interface{}) ([]*schema.ResourceData, error) {
+	rscDatas, err := schema.ImportStatePassthroughWithIdentity("path")(ctx, rscData, prvClient)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := client.ValidatePath(rscData.Id()); err != nil {
+		return nil, fmt.Errorf("failed to import ZNode: %w", err)
+	}
+
+	return rscDatas, nil
+}
+
+// setIdentityFromZNode sets the identity of a zookeeper_znode Resource, from the given ZNode.
+func setIdentityFromZNode(rscData *schema.ResourceData, znode *client.ZNode, diags diag.Diagnostics) diag.Diagnostics {
+	identity, err := rscData.Identity()
+	if err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+
+	if err := identity.Set("path", znode.Path); err != nil {
+		diags = append(diags, diag.FromErr(err)...)
+	}
+
+	return diags
+}
+
+func resourceZNodeCreate(ctx context.Context, rscData *schema.ResourceData, prvClient interface{}) diag.Diagnostics {
 	zkClient := prvClient.(*client.Client)
 
-	znodePath := rscData.Get("path").(string)
+	znodePath := getPathFromResourceData(rscData)
 
 	dataBytes, err := getDataBytesFromResourceData(rscData)
 	if err != nil {
@@ -94,44 +206,102 @@ func resourceZNodeCreate(_ context.Context, rscData *schema.ResourceData, prvCli
 		return diag.FromErr(err)
 	}
 
-	znode, err := zkClient.Create(znodePath, dataBytes, acls)
+	retryPolicy, err := getRetryPolicyFromRetryBlock(rscData)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var znode *client.ZNode
+	err = zkClient.Retry(ctx, retryPolicy, func() (createErr error) {
+		znode, createErr = zkClient.Create(znodePath, dataBytes, acls)
+		return createErr
+	})
 	if err != nil {
-		return diag.Errorf("Failed to create ZNode '%s': %v", znodePath, err)
+		return zkErrorf(zkErrorHint(zkClient, zNodeOperationCreate, znodePath, err), "Failed to create ZNode '%s': %v", znodePath, err)
 	}
 
 	// Terraform will use the ZNode.Path as unique identifier for this Resource
 	rscData.SetId(znode.Path)
 	rscData.MarkNewResource()
 
-	return setAttributesFromZNode(rscData, znode, diag.Diagnostics{})
+	diags := setPathPartsFromZNode(rscData, znode, setIdentityFromZNode(rscData, znode, diag.Diagnostics{}))
+	return notifyZNodeChange(ctx, rscData, zkClient, setAttributesFromZNode(rscData, znode, diags))
 }
 
-func resourceZNodeRead(_ context.Context, rscData *schema.ResourceData, prvClient interface{}) diag.Diagnostics {
-	zkClient := prvClient.(*client.Client)
+func resourceZNodeRead(ctx context.Context, rscData *schema.ResourceData, prvClient interface{}) diag.Diagnostics {
+	znode, diags := readZNodeOfResource(ctx, rscData, prvClient.(*client.Client))
+	if znode == nil {
+		return diags
+	}
 
+	diags = setPathPartsFromZNode(rscData, znode, setIdentityFromZNode(rscData, znode, diags))
+	return setAttributesFromZNode(rscData, znode, diags)
+}
+
+// readZNodeOfResource reads the ZNode managed by a Resource, for refreshing it (see readZNodeForRefresh).
+//
+// If the ZNode is not found, the Resource is removed from the state with a warning, or reading fails,
+// depending on `on_missing`: in both cases, as when reading fails, no ZNode is returned.
+// If not authorized to read it, the Resource is kept as in the state with a warning, if the provider tolerates that.
+func readZNodeOfResource(ctx context.Context, rscData *schema.ResourceData, zkClient *client.Client) (*client.ZNode, diag.Diagnostics) {
 	znodePath := rscData.Id()
 
-	znode, err := zkClient.Read(znodePath)
+	retryPolicy, err := getRetryPolicyFromRetryBlock(rscData)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	var znode *client.ZNode
+	err = zkClient.Retry(ctx, retryPolicy, func() (readErr error) {
+		znode, readErr = readZNodeForRefresh(zkClient, rscData)
+		return readErr
+	})
 	if err != nil {
 		// If the ZNode is not found, it means it was changed outside of Terraform.
-		// We set the ID to blank, so it's state will be removed.
+		// We set the ID to blank, so it's state will be removed, unless that must fail instead (see onMissingSchema).
 		if errors.Is(err, client.ErrorZNodeDoesNotExist) {
+			if rscData.Get("on_missing").(string) == onMissingFail {
+				return nil, zkErrorf(zkErrorHint(zkClient, zNodeOperationRead, znodePath, err)+
+					" As `on_missing = \""+onMissingFail+"\"`, it's not created again: restore it, "+
+					"or remove the resource from the state (ex. via `terraform state rm`) for the next apply to create it.",
+					"ZNode '%s' no longer exists", znodePath)
+			}
+
 			rscData.SetId("")
-			return diag.Diagnostics{}
+			return nil, diag.Diagnostics{
+				diag.Diagnostic{
+					Severity: diag.Warning,
+					Summary:  fmt.Sprintf("ZNode '%s' no longer exists", znodePath),
+					Detail: "It was likely deleted outside of Terraform: it's removed from the state, and will be created again. " +
+						"Set `on_missing = \"" + onMissingFail + "\"` to fail instead.",
+				},
+			}
 		}
 
-		return diag.Errorf("Failed to read ZNode '%s': %v", znodePath, err)
+		// The Resource is kept as in the state, so that the rest of the configuration can still be planned
+		if errors.Is(err, client.ErrorNotAuthorized) && zkClient.ToleratesUnreadableZNodes() {
+			return nil, diag.Diagnostics{
+				diag.Diagnostic{
+					Severity: diag.Warning,
+					Summary:  fmt.Sprintf("ZNode '%s' not refreshed: not authorized to read it", znodePath),
+					Detail: zkErrorHint(zkClient, zNodeOperationRead, znodePath, err) +
+						" It's kept as in the state, as the provider `tolerate_unreadable_znodes`: changes made outside of Terraform are not detected.",
+				},
+			}
+		}
+
+		return nil, zkErrorf(zkErrorHint(zkClient, zNodeOperationRead, znodePath, err), "Failed to read ZNode '%s': %v", znodePath, err)
 	}
 
-	return setAttributesFromZNode(rscData, znode, diag.Diagnostics{})
+	return znode, diag.Diagnostics{}
 }
 
-func resourceZNodeUpdate(_ context.Context, rscData *schema.ResourceData, prvClient interface{}) diag.Diagnostics {
+func resourceZNodeUpdate(ctx context.Context, rscData *schema.ResourceData, prvClient interface{}) diag.Diagnostics {
 	zkClient := prvClient.(*client.Client)
 
 	znodePath := rscData.Id()
 
-	if rscData.HasChanges("data", "data_base64", "acl") {
+	if rscData.HasChanges("data", "data_base64", "data_hex", "charset", "acl") {
 		dataBytes, err := getDataBytesFromResourceData(rscData)
 		if err != nil {
 			return diag.FromErr(err)
@@ -141,27 +311,52 @@ func resourceZNodeUpdate(_ context.Context, rscData *schema.ResourceData, prvCli
 		if err != nil {
 			return diag.FromErr(err)
 		}
+		// Without reading ACLs, the one in the state might not be up-to-date: only set it if changed
+		if zkClient.SkipsACLReads() && !rscData.HasChange("acl") {
+			acls = nil
+		}
+
+		retryPolicy, err := getRetryPolicyFromRetryBlock(rscData)
+		if err != nil {
+			return diag.FromErr(err)
+		}
 
-		znode, err := zkClient.Update(znodePath, dataBytes, acls)
+		var znode *client.ZNode
+		err = zkClient.Retry(ctx, retryPolicy, func() (updateErr error) {
+			znode, updateErr = zkClient.Update(znodePath, dataBytes, acls)
+			return updateErr
+		})
 		if err != nil {
-			return diag.Errorf("Failed to update ZNode '%s': %v", znodePath, err)
+			return zkErrorf(zkErrorHint(zkClient, zNodeOperationUpdate, znodePath, err), "Failed to update ZNode '%s': %v", znodePath, err)
 		}
 
-		return setAttributesFromZNode(rscData, znode, diag.Diagnostics{})
+		diags := setAttributesFromZNode(rscData, znode, diag.Diagnostics{})
+		// Only the ACL changed, otherwise
+		if rscData.HasChanges("data", "data_base64", "data_hex", "charset") {
+			diags = notifyZNodeChange(ctx, rscData, zkClient, diags)
+		}
+		return diags
 	}
 
 	return diag.Diagnostics{}
 }
 
-func resourceZNodeDelete(_ context.Context, rscData *schema.ResourceData, prvClient interface{}) diag.Diagnostics {
+func resourceZNodeDelete(ctx context.Context, rscData *schema.ResourceData, prvClient interface{}) diag.Diagnostics {
 	zkClient := prvClient.(*client.Client)
 
 	znodePath := rscData.Id()
 
-	err := zkClient.Delete(znodePath)
+	retryPolicy, err := getRetryPolicyFromRetryBlock(rscData)
 	if err != nil {
-		return diag.Errorf("Failed to delete ZNode '%s': %v", znodePath, err)
+		return diag.FromErr(err)
 	}
 
-	return diag.Diagnostics{}
+	err = zkClient.Retry(ctx, retryPolicy, func() error {
+		return zkClient.Delete(znodePath)
+	})
+	if err != nil {
+		return zkErrorf(zkErrorHint(zkClient, zNodeOperationDelete, znodePath, err), "Failed to delete ZNode '%s': %v", znodePath, err)
+	}
+
+	return notifyZNodeChange(ctx, rscData, zkClient, diag.Diagnostics{})
 }
@@ -2,19 +2,22 @@ package provider_test
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccResourceZNode(t *testing.T) {
 	parentPath := "/" + acctest.RandString(10)
 
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { checkPreconditions(t) },
-		ProviderFactories: providerFactoriesMap(),
-		CheckDestroy:      confirmAllZNodeDestroyed,
+		PreCheck:                 func() { checkPreconditions(t) },
+		ProtoV6ProviderFactories: providerFactoriesMap(),
+		CheckDestroy:             confirmAllZNodeDestroyed,
 		Steps: []resource.TestStep{
 			{
 				Config: fmt.Sprintf(`
@@ -33,11 +36,17 @@ func TestAccResourceZNode(t *testing.T) {
 					resource.TestCheckResourceAttrPair("zookeeper_znode.parent", "path", "zookeeper_znode.parent", "id"),
 					resource.TestCheckResourceAttr("zookeeper_znode.parent", "data", "parent data"),
 					resource.TestCheckResourceAttr("zookeeper_znode.parent", "data_base64", "cGFyZW50IGRhdGE="),
+					resource.TestCheckResourceAttr("zookeeper_znode.parent", "is_ephemeral", "false"),
+					resource.TestCheckResourceAttr("zookeeper_znode.parent", "ephemeral_owner", ""),
+					resource.TestCheckResourceAttr("zookeeper_znode.parent", "parent_path", "/"),
+					resource.TestCheckResourceAttr("zookeeper_znode.parent", "name", parentPath[1:]),
 					// Child checks
 					resource.TestCheckResourceAttr("zookeeper_znode.child", "path", parentPath+"/child"),
 					resource.TestCheckResourceAttrPair("zookeeper_znode.child", "path", "zookeeper_znode.child", "id"),
 					resource.TestCheckResourceAttr("zookeeper_znode.child", "data", "child data"),
 					resource.TestCheckResourceAttr("zookeeper_znode.child", "data_base64", "Y2hpbGQgZGF0YQ=="),
+					resource.TestCheckResourceAttr("zookeeper_znode.child", "parent_path", parentPath),
+					resource.TestCheckResourceAttr("zookeeper_znode.child", "name", "child"),
 				),
 			},
 			{
@@ -58,9 +67,9 @@ func TestAccResourceZNode_MultipleSharedPath(t *testing.T) {
 	sharedPath := "/" + acctest.RandString(5) + "/" + acctest.RandString(5) + "/" + acctest.RandString(5)
 
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { checkPreconditions(t) },
-		ProviderFactories: providerFactoriesMap(),
-		CheckDestroy:      confirmAllZNodeDestroyed,
+		PreCheck:                 func() { checkPreconditions(t) },
+		ProtoV6ProviderFactories: providerFactoriesMap(),
+		CheckDestroy:             confirmAllZNodeDestroyed,
 		Steps: []resource.TestStep{
 			{
 				Config: fmt.Sprintf(`
@@ -97,9 +106,9 @@ func TestAccResourceZNode_Base64(t *testing.T) {
 	sharedPath := "/" + acctest.RandString(10)
 
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { checkPreconditions(t) },
-		ProviderFactories: providerFactoriesMap(),
-		CheckDestroy:      confirmAllZNodeDestroyed,
+		PreCheck:                 func() { checkPreconditions(t) },
+		ProtoV6ProviderFactories: providerFactoriesMap(),
+		CheckDestroy:             confirmAllZNodeDestroyed,
 		Steps: []resource.TestStep{
 			{
 				Config: fmt.Sprintf(`
@@ -133,13 +142,93 @@ func TestAccResourceZNode_Base64(t *testing.T) {
 	})
 }
 
+func TestAccResourceZNode_Hex(t *testing.T) {
+	znodePath := "/" + acctest.RandString(10)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { checkPreconditions(t) },
+		ProtoV6ProviderFactories: providerFactoriesMap(),
+		CheckDestroy:             confirmAllZNodeDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "zookeeper_znode" "magic" {
+						path = "%s"
+						data_hex = "CAFEBABE00"
+					}`, znodePath,
+				),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("zookeeper_znode.magic", "data_hex", "cafebabe00"),
+					resource.TestCheckResourceAttr("zookeeper_znode.magic", "data_base64", "yv66vgA="),
+				),
+			},
+			{
+				// Switching from `data_hex` to `data` writes the configured content
+				Config: fmt.Sprintf(`
+					resource "zookeeper_znode" "magic" {
+						path = "%s"
+						data = "Forza Napoli!"
+					}`, znodePath,
+				),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("zookeeper_znode.magic", "data", "Forza Napoli!"),
+					resource.TestCheckResourceAttr("zookeeper_znode.magic", "data_hex", "466f727a61204e61706f6c6921"),
+				),
+			},
+			{
+				ResourceName:      "zookeeper_znode.magic",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccResourceZNode_Charset(t *testing.T) {
+	znodePath := "/" + acctest.RandString(10)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { checkPreconditions(t) },
+		ProtoV6ProviderFactories: providerFactoriesMap(),
+		CheckDestroy:             confirmAllZNodeDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "zookeeper_znode" "legacy" {
+						path = "%s"
+						data = "Città"
+						charset = "ISO-8859-1"
+					}`, znodePath,
+				),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("zookeeper_znode.legacy", "data", "Città"),
+					resource.TestCheckResourceAttr("zookeeper_znode.legacy", "data_hex", "43697474e0"),
+				),
+			},
+			{
+				// Changing only the charset converts the content again
+				Config: fmt.Sprintf(`
+					resource "zookeeper_znode" "legacy" {
+						path = "%s"
+						data = "Città"
+					}`, znodePath,
+				),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("zookeeper_znode.legacy", "data", "Città"),
+					resource.TestCheckResourceAttr("zookeeper_znode.legacy", "data_hex", "43697474c3a0"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccResourceZNode_DefaultACL(t *testing.T) {
 	path := "/" + acctest.RandString(10)
 
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { checkPreconditions(t) },
-		ProviderFactories: providerFactoriesMap(),
-		CheckDestroy:      confirmAllZNodeDestroyed,
+		PreCheck:                 func() { checkPreconditions(t) },
+		ProtoV6ProviderFactories: providerFactoriesMap(),
+		CheckDestroy:             confirmAllZNodeDestroyed,
 		Steps: []resource.TestStep{
 			{
 				Config: fmt.Sprintf(`
@@ -164,9 +253,9 @@ func TestAccResourceZNode_WithACL(t *testing.T) {
 	path := "/" + acctest.RandString(10)
 
 	resource.ParallelTest(t, resource.TestCase{
-		PreCheck:          func() { checkPreconditions(t) },
-		ProviderFactories: providerFactoriesMap(),
-		CheckDestroy:      confirmAllZNodeDestroyed,
+		PreCheck:                 func() { checkPreconditions(t) },
+		ProtoV6ProviderFactories: providerFactoriesMap(),
+		CheckDestroy:             confirmAllZNodeDestroyed,
 		Steps: []resource.TestStep{
 			{
 				Config: fmt.Sprintf(`
@@ -191,3 +280,541 @@ func TestAccResourceZNode_WithACL(t *testing.T) {
 		},
 	})
 }
+
+func TestAccResourceZNode_Retry(t *testing.T) {
+	srcPath := "/" + acctest.RandString(10)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { checkPreconditions(t) },
+		ProtoV6ProviderFactories: providerFactoriesMap(),
+		CheckDestroy:             confirmAllZNodeDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "zookeeper_znode" "critical" {
+						path = "%s"
+						data = "one"
+						retry {
+							retries             = 5
+							retry_interval      = "200ms"
+							retry_error_classes = { bad_version = 3 }
+						}
+					}`, srcPath,
+				),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("zookeeper_znode.critical", "data", "one"),
+					resource.TestCheckResourceAttr("zookeeper_znode.critical", "retry.0.retries", "5"),
+					resource.TestCheckResourceAttr("zookeeper_znode.critical", "retry.0.retry_error_classes.bad_version", "3"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+					resource "zookeeper_znode" "critical" {
+						path = "%s"
+						data = "two"
+						retry {
+							retries = 1
+						}
+					}`, srcPath,
+				),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("zookeeper_znode.critical", "data", "two"),
+					resource.TestCheckResourceAttr("zookeeper_znode.critical", "retry.0.retry_interval", "1s"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceZNode_AllowedPathPrefixes(t *testing.T) {
+	allowedPath := "/" + acctest.RandString(10)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { checkPreconditions(t) },
+		ProtoV6ProviderFactories: providerFactoriesMap(),
+		CheckDestroy:             confirmAllZNodeDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					provider "zookeeper" {
+						allowed_path_prefixes = ["%[1]s"]
+					}
+					resource "zookeeper_znode" "allowed" {
+						path = "%[1]s/allowed"
+						data = "allowed"
+					}`, allowedPath,
+				),
+				Check: resource.TestCheckResourceAttr("zookeeper_znode.allowed", "data", "allowed"),
+			},
+			{
+				Config: fmt.Sprintf(`
+					provider "zookeeper" {
+						allowed_path_prefixes = ["%[1]s"]
+					}
+					resource "zookeeper_znode" "allowed" {
+						path = "%[1]s/allowed"
+						data = "allowed"
+					}
+					resource "zookeeper_znode" "denied" {
+						path = "%[1]s-denied"
+					}`, allowedPath,
+				),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`outside of the allowed path prefixes`),
+			},
+		},
+	})
+}
+
+func TestAccResourceZNode_DeniedPaths(t *testing.T) {
+	parentPath := "/" + acctest.RandString(10)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { checkPreconditions(t) },
+		ProtoV6ProviderFactories: providerFactoriesMap(),
+		CheckDestroy:             confirmAllZNodeDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					provider "zookeeper" {
+						denied_paths = ["%s/locked"]
+					}
+					resource "zookeeper_znode" "locked" {
+						path = "%s/locked/child"
+					}`, parentPath, parentPath,
+				),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`is protected by the denied path prefix`),
+			},
+			{
+				Config: `
+					resource "zookeeper_znode" "system" {
+						path = "/zookeeper/terraform"
+					}`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`is protected by the denied path prefix '/zookeeper'`),
+			},
+		},
+	})
+}
+
+func TestAccResourceZNode_DenyWorldOpenACLs(t *testing.T) {
+	parentPath := "/" + acctest.RandString(10)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { checkPreconditions(t) },
+		ProtoV6ProviderFactories: providerFactoriesMap(),
+		CheckDestroy:             confirmAllZNodeDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					provider "zookeeper" {
+						deny_world_open_acls = true
+					}
+					resource "zookeeper_znode" "default_acl" {
+						path = "%s/default_acl"
+					}`, parentPath,
+				),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`invalid 'acl': ACL grants world:anyone \(permissions: 31\)`),
+			},
+			{
+				Config: fmt.Sprintf(`
+					provider "zookeeper" {
+						deny_world_open_acls = true
+					}
+					resource "zookeeper_znode" "world_readable" {
+						path = "%s/world_readable"
+						acl {
+							scheme      = "world"
+							id          = "anyone"
+							permissions = 1
+						}
+					}`, parentPath,
+				),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestAccResourceZNode_LightRefresh(t *testing.T) {
+	srcPath := "/" + acctest.RandString(10)
+	config := fmt.Sprintf(`
+		resource "zookeeper_znode" "large" {
+			path          = "%s"
+			data          = "large content"
+			light_refresh = true
+		}`, srcPath,
+	)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { checkPreconditions(t) },
+		ProtoV6ProviderFactories: providerFactoriesMap(),
+		CheckDestroy:             confirmAllZNodeDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("zookeeper_znode.large", "data", "large content"),
+					resource.TestCheckResourceAttr("zookeeper_znode.large", "light_refresh", "true"),
+				),
+			},
+			{
+				// Changes made outside of Terraform are still detected
+				PreConfig: func() {
+					if _, err := getTestZKClient().Update(srcPath, []byte("changed"), nil); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:             config,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config,
+				Check:  resource.TestCheckResourceAttr("zookeeper_znode.large", "data", "large content"),
+			},
+		},
+	})
+}
+
+func TestAccResourceZNode_EmptyData(t *testing.T) {
+	znodePath := "/" + acctest.RandString(10)
+	config := func(data string) string {
+		return fmt.Sprintf(`
+			resource "zookeeper_znode" "flag" {
+				path = "%s"
+				data = "%s"
+			}`, znodePath, data,
+		)
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { checkPreconditions(t) },
+		ProtoV6ProviderFactories: providerFactoriesMap(),
+		CheckDestroy:             confirmAllZNodeDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: config("enabled"),
+				Check:  resource.TestCheckResourceAttr("zookeeper_znode.flag", "data", "enabled"),
+			},
+			{
+				// Emptying the content is planned, rather than treated like an unset `data`
+				Config: config(""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("zookeeper_znode.flag", "data", ""),
+					resource.TestCheckResourceAttr("zookeeper_znode.flag", "data_base64", ""),
+					resource.TestCheckResourceAttr("zookeeper_znode.flag", "data_hex", ""),
+					resource.TestCheckResourceAttr("zookeeper_znode.flag", "stat.0.data_length", "0"),
+				),
+			},
+			{
+				// Content written outside of Terraform is detected
+				PreConfig: func() {
+					if _, err := getTestZKClient().Update(znodePath, []byte("changed"), nil); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:             config(""),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config(""),
+				Check:  resource.TestCheckResourceAttr("zookeeper_znode.flag", "stat.0.data_length", "0"),
+			},
+		},
+	})
+}
+
+func TestAccResourceZNode_ContentVersion(t *testing.T) {
+	znodePath := "/" + acctest.RandString(10)
+	config := func(data string) string {
+		return fmt.Sprintf(`
+			resource "zookeeper_znode" "config" {
+				path = "%s"
+				data = "%s"
+			}
+
+			resource "terraform_data" "consumer" {
+				lifecycle {
+					replace_triggered_by = [zookeeper_znode.config.content_version]
+				}
+			}`, znodePath, data,
+		)
+	}
+
+	var consumerID string
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { checkPreconditions(t) },
+		ProtoV6ProviderFactories: providerFactoriesMap(),
+		CheckDestroy:             confirmAllZNodeDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: config("Forza Napoli!"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("zookeeper_znode.config", "content_version",
+						"0ed2b2b83fd3277c64862c41ec52f8daa55edad231075b20560ff2998695b0fe"),
+					func(s *terraform.State) error {
+						consumerID = s.RootModule().Resources["terraform_data.consumer"].Primary.ID
+						return nil
+					},
+				),
+			},
+			{
+				// Writing the same content again (ex. outside of Terraform) changes `stat.version`, but not `content_version`
+				PreConfig: func() {
+					if _, err := getTestZKClient().Update(znodePath, []byte("Forza Napoli!"), nil); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:   config("Forza Napoli!"),
+				PlanOnly: true,
+			},
+			{
+				// Changing the content replaces the resources triggered by it
+				Config: config("Forza Napoli"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("zookeeper_znode.config", "content_version",
+						"1eb18d46aa177b4e2aae00ff5d604a88d2e080ebe1f8ee67a6f1b50f609e3498"),
+					func(s *terraform.State) error {
+						if id := s.RootModule().Resources["terraform_data.consumer"].Primary.ID; id == consumerID {
+							return fmt.Errorf("terraform_data.consumer not replaced: still '%s'", id)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceZNode_ReplaceOnDataChange(t *testing.T) {
+	znodePath := "/" + acctest.RandString(10)
+	config := func(data string) string {
+		return fmt.Sprintf(`
+			resource "zookeeper_znode" "config" {
+				path                   = "%s"
+				data                   = "%s"
+				replace_on_data_change = true
+			}`, znodePath, data,
+		)
+	}
+
+	var czxid string
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { checkPreconditions(t) },
+		ProtoV6ProviderFactories: providerFactoriesMap(),
+		CheckDestroy:             confirmAllZNodeDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: config("Forza Napoli!"),
+				Check: func(s *terraform.State) error {
+					czxid = s.RootModule().Resources["zookeeper_znode.config"].Primary.Attributes["stat.0.czxid"]
+					return nil
+				},
+			},
+			{
+				// Changing the content creates the ZNode again: it's version 0 of a new creation
+				Config: config("Forza Napoli"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("zookeeper_znode.config", "data", "Forza Napoli"),
+					resource.TestCheckResourceAttr("zookeeper_znode.config", "stat.0.version", "0"),
+					func(s *terraform.State) error {
+						if newCzxid := s.RootModule().Resources["zookeeper_znode.config"].Primary.Attributes["stat.0.czxid"]; newCzxid == czxid {
+							return fmt.Errorf("zookeeper_znode.config not replaced: still created at zxid '%s'", czxid)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceZNode_NotifyPath(t *testing.T) {
+	znodePath := "/" + acctest.RandString(10)
+	notifyParentPath := "/" + acctest.RandString(10)
+	notifyPath := notifyParentPath + "/version"
+	config := func(data string) string {
+		return fmt.Sprintf(`
+			resource "zookeeper_znode" "config" {
+				path        = "%s"
+				data        = "%s"
+				notify_path = "%s"
+			}`, znodePath, data, notifyPath,
+		)
+	}
+
+	// confirmNotified confirms the notification ZNode was written the given number of times
+	confirmNotified := func(times int32) func(*terraform.State) error {
+		return func(_ *terraform.State) error {
+			znode, err := getTestZKClient().Read(notifyPath)
+			if err != nil {
+				return err
+			}
+			if _, err := time.Parse(time.RFC3339Nano, string(znode.Data)); err != nil {
+				return fmt.Errorf("ZNode '%s' expected to contain a timestamp: %w", notifyPath, err)
+			}
+			if znode.Stat.Version != times-1 {
+				return fmt.Errorf("ZNode '%s' expected to be notified %d times, but has version %d", notifyPath, times, znode.Stat.Version)
+			}
+			return nil
+		}
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { checkPreconditions(t) },
+		ProtoV6ProviderFactories: providerFactoriesMap(),
+		CheckDestroy: func(s *terraform.State) error {
+			if err := confirmAllZNodeDestroyed(s); err != nil {
+				return err
+			}
+			// Deleting the ZNode notifies too
+			if err := confirmNotified(3)(s); err != nil {
+				return err
+			}
+			return getTestZKClient().Delete(notifyParentPath)
+		},
+		Steps: []resource.TestStep{
+			{
+				// Creating the ZNode creates the notification ZNode
+				Config: config("Forza Napoli!"),
+				Check:  confirmNotified(1),
+			},
+			{
+				Config: config("Forza Napoli"),
+				Check:  confirmNotified(2),
+			},
+		},
+	})
+}
+
+func TestAccResourceZNode_TrackStat(t *testing.T) {
+	znodePath := "/" + acctest.RandString(10)
+	config := func(trackStat bool) string {
+		return fmt.Sprintf(`
+			resource "zookeeper_znode" "busy" {
+				path       = "%s"
+				data       = "Forza Napoli!"
+				track_stat = %t
+			}`, znodePath, trackStat,
+		)
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { checkPreconditions(t) },
+		ProtoV6ProviderFactories: providerFactoriesMap(),
+		CheckDestroy:             confirmAllZNodeDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: config(false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("zookeeper_znode.busy", "data", "Forza Napoli!"),
+					resource.TestCheckResourceAttr("zookeeper_znode.busy", "stat.#", "0"),
+				),
+			},
+			{
+				// Refreshing doesn't store the stat either
+				RefreshState: true,
+				Check:        resource.TestCheckResourceAttr("zookeeper_znode.busy", "stat.#", "0"),
+			},
+			{
+				// Tracked again from the next refresh
+				Config: config(true),
+			},
+			{
+				RefreshState: true,
+				Check:        resource.TestCheckResourceAttr("zookeeper_znode.busy", "stat.#", "1"),
+			},
+		},
+	})
+}
+
+func TestAccResourceZNode_ParentPathAndName(t *testing.T) {
+	parentPath := "/" + acctest.RandString(10)
+	config := fmt.Sprintf(`
+		resource "zookeeper_znode" "parent" {
+			path = "%s"
+			data = "Forza"
+		}
+
+		resource "zookeeper_znode" "child" {
+			parent_path = zookeeper_znode.parent.path
+			name        = "napoli"
+			data        = "Napoli"
+		}`, parentPath,
+	)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { checkPreconditions(t) },
+		ProtoV6ProviderFactories: providerFactoriesMap(),
+		CheckDestroy:             confirmAllZNodeDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("zookeeper_znode.child", "id", parentPath+"/napoli"),
+					resource.TestCheckResourceAttr("zookeeper_znode.child", "path", parentPath+"/napoli"),
+					resource.TestCheckResourceAttr("zookeeper_znode.child", "parent_path", parentPath),
+					resource.TestCheckResourceAttr("zookeeper_znode.child", "name", "napoli"),
+					// Created after its parent, instead of creating it empty
+					resource.TestCheckResourceAttr("zookeeper_znode.parent", "data", "Forza"),
+				),
+			},
+			{
+				ResourceName:      "zookeeper_znode.child",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccResourceZNode_OnMissingFail(t *testing.T) {
+	srcPath := "/" + acctest.RandString(10)
+	config := fmt.Sprintf(`
+		resource "zookeeper_znode" "critical" {
+			path       = "%s"
+			data       = "critical content"
+			on_missing = "fail"
+		}`, srcPath,
+	)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:                 func() { checkPreconditions(t) },
+		ProtoV6ProviderFactories: providerFactoriesMap(),
+		CheckDestroy:             confirmAllZNodeDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check:  resource.TestCheckResourceAttr("zookeeper_znode.critical", "on_missing", "fail"),
+			},
+			{
+				// Deleted outside of Terraform: refreshing fails, instead of planning to create it again
+				PreConfig: func() {
+					if err := getTestZKClient().Delete(srcPath); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:      config,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(fmt.Sprintf("ZNode '%s' no longer exists", srcPath)),
+			},
+			{
+				// Restored
+				PreConfig: func() {
+					if _, err := getTestZKClient().Create(srcPath, []byte("critical content"), nil); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: config,
+				Check:  resource.TestCheckResourceAttr("zookeeper_znode.critical", "data", "critical content"),
+			},
+		},
+	})
+}
@@ -0,0 +1,60 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-mux/tf5to6server"
+	"github.com/hashicorp/terraform-plugin-mux/tf6muxserver"
+)
+
+// NewProviderServer returns a factory of the provider server, muxing together the SDKv2 provider (see New)
+// and the terraform-plugin-framework provider, while Resources and Data Sources are migrated from one to the other.
+//
+// The provider is served over protocol version 6: the SDKv2 provider, that only supports version 5,
+// is upgraded via tf5to6server. Both providers share the same client.Client.
+func NewProviderServer(ctx context.Context) (func() tfprotov6.ProviderServer, error) {
+	providerServer, _, err := NewProviderServerWithLifecycle(ctx)
+
+	return providerServer, err
+}
+
+// Lifecycle is what a provider server exposes about what it does while serving, and releases once it stops.
+type Lifecycle struct {
+	// TelemetrySummary summarizes the operations performed against ZooKeeper (see client.Telemetry),
+	// one line per ZooKeeper client.
+	TelemetrySummary func() []string
+	// Metrics serves the same telemetry, and the state of the ZooKeeper sessions, in the Prometheus text format:
+	// meant for long-lived provider servers (ex. in debug mode).
+	Metrics http.Handler
+	// Stop releases what the provider started while serving (ex. the ZooKeeper Server of `dev_server`).
+	Stop func()
+}
+
+// NewProviderServerWithLifecycle is like NewProviderServer, but also returns the Lifecycle of the provider server:
+// TelemetrySummary and Stop are meant to be called once the provider server stops, at the end of a plan or apply.
+func NewProviderServerWithLifecycle(ctx context.Context) (func() tfprotov6.ProviderServer, *Lifecycle, error) {
+	clientCache := &zkClientCache{}
+
+	upgradedSDKv2Server, err := tf5to6server.UpgradeServer(ctx, newSDKv2Provider(clientCache).GRPCProvider)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to upgrade SDKv2 provider server to protocol version 6: %w", err)
+	}
+
+	muxServer, err := tf6muxserver.NewMuxServer(ctx,
+		func() tfprotov6.ProviderServer { return upgradedSDKv2Server },
+		providerserver.NewProtocol6(newFrameworkProvider(clientCache)),
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to mux provider servers: %w", err)
+	}
+
+	return muxServer.ProviderServer, &Lifecycle{
+		TelemetrySummary: clientCache.telemetrySummary,
+		Metrics:          clientCache.metricsHandler(),
+		Stop:             clientCache.stop,
+	}, nil
+}
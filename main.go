@@ -1,27 +1,128 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
 	"fmt"
+	"log"
+	"net/http"
 	"os"
+	"time"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6/tf6server"
+	"github.com/tfzk/terraform-provider-zookeeper/client"
 	"github.com/tfzk/terraform-provider-zookeeper/internal/provider"
 )
 
 // Generate the Terraform provider documentation using `tfplugindocs`:
 //go:generate go run github.com/hashicorp/terraform-plugin-docs/cmd/tfplugindocs
 
+const (
+	providerAddress = "registry.terraform.io/tfzk/zookeeper"
+	// metricsReadHeaderTimeout is how long a scraper can take to send the headers of a request for metrics.
+	metricsReadHeaderTimeout = 5 * time.Second
+)
+
 func main() {
-	p, err := provider.New()
+	debug := flag.Bool("debug", false, "Start provider in debug mode, for use with debuggers like delve")
+	metricsAddress := flag.String("metrics-address", "",
+		"Address to serve Prometheus metrics on, at /metrics (ex. ':9464'). Requires -debug, to run as a long-lived server")
+	flag.Parse()
+
+	if flag.Arg(0) == "generate" {
+		if err := generate(flag.Args()[1:]); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to generate configuration: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Terraform starts a provider process per command, all of which would serve on the same address
+	if *metricsAddress != "" && !*debug {
+		fmt.Fprintln(os.Stderr, "-metrics-address requires -debug")
+		os.Exit(1)
+	}
+
+	providerServer, lifecycle, err := provider.NewProviderServerWithLifecycle(context.Background())
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "failed to initialize provider: %v\n", err)
 		os.Exit(1)
 	}
 
-	plugin.Serve(&plugin.ServeOpts{
-		ProviderFunc: func() *schema.Provider {
-			return p
-		},
+	stopMetrics := func() {}
+	if *metricsAddress != "" {
+		stopMetrics = serveMetrics(*metricsAddress, lifecycle.Metrics)
+	}
+
+	var serveOpts []tf6server.ServeOpt
+	if *debug {
+		serveOpts = append(serveOpts, tf6server.WithManagedDebug())
+	}
+
+	err = tf6server.Serve(providerAddress, providerServer, serveOpts...)
+	stopMetrics()
+	lifecycle.Stop()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to serve provider: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Once served, at the end of a plan or apply: for the Terraform logs (ex. `TF_LOG=DEBUG`)
+	for _, line := range lifecycle.TelemetrySummary() {
+		log.Printf("[DEBUG] %s", line)
+	}
+}
+
+// serveMetrics serves the given metrics handler at `/metrics`, on the given address, in the background.
+// It returns a function stopping the server.
+func serveMetrics(address string, metrics http.Handler) func() {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", metrics)
+	server := &http.Server{Addr: address, Handler: mux, ReadHeaderTimeout: metricsReadHeaderTimeout}
+
+	go func() {
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			fmt.Fprintf(os.Stderr, "failed to serve metrics on '%s': %v\n", address, err)
+		}
+	}()
+	fmt.Fprintf(os.Stderr, "Serving Prometheus metrics on http://%s/metrics\n", address)
+
+	return func() { _ = server.Close() }
+}
+
+// generate writes the configuration to adopt an existing subtree of ZNodes (see provider.GenerateZNodeConfig),
+// connecting to ZooKeeper via the same environment variables as the provider (ex. `ZOOKEEPER_SERVERS`).
+func generate(args []string) error {
+	flags := flag.NewFlagSet("generate", flag.ContinueOnError)
+	path := flags.String("path", "", "Absolute path to the ZNode at the root of the subtree to generate the configuration of (required)")
+	maxDepth := flags.Int("max-depth", 0, "How many levels below -path to generate: 1 means only -path and its direct children. 0 means no limit")
+	concurrency := flags.Int("concurrency", 16, "Maximum number of ZNodes read concurrently")
+	includeACL := flags.Bool("include-acl", true, "Generate the acl of each ZNode")
+	out := flags.String("out", "", "File to write the configuration to (default: standard output)")
+	if err := flags.Parse(args); err != nil {
+		return fmt.Errorf("invalid arguments: %w", err)
+	}
+	if *path == "" {
+		return fmt.Errorf("missing -path")
+	}
+
+	zkClient, err := client.NewClientFromEnv()
+	if err != nil {
+		return fmt.Errorf("unable to create ZooKeeper client: %w", err)
+	}
+
+	w := os.Stdout
+	if *out != "" {
+		if w, err = os.Create(*out); err != nil {
+			return fmt.Errorf("unable to create '%s': %w", *out, err)
+		}
+		defer w.Close()
+	}
+
+	return provider.GenerateZNodeConfig(w, zkClient, *path, provider.GenerateOptions{
+		MaxDepth:    *maxDepth,
+		Concurrency: *concurrency,
+		IncludeACL:  *includeACL,
 	})
 }